@@ -18,6 +18,7 @@ package cs
 
 import (
 	"io"
+	"math/big"
 	"time"
 
 	"github.com/consensys/gnark/backend/witness"
@@ -263,6 +264,11 @@ func (t *R1CSSolution) ReadFrom(r io.Reader) (int64, error) {
 	return n, err
 }
 
+// WireValues returns the values of the wires, indexed by wire ID.
+func (t *R1CSSolution) WireValues() []*big.Int {
+	return vectorToBigInts(t.W)
+}
+
 // SparseR1CSSolution represent a valid assignment to all the variables in the constraint system.
 type SparseR1CSSolution struct {
 	L, R, O fr.Vector
@@ -299,6 +305,20 @@ func (t *SparseR1CSSolution) ReadFrom(r io.Reader) (int64, error) {
 	return n, err
 }
 
+// TermValues returns the values of the left, right and output terms of the
+// constraints, the first rows being the placeholders of the public inputs.
+func (t *SparseR1CSSolution) TermValues() (l, r, o []*big.Int) {
+	return vectorToBigInts(t.L), vectorToBigInts(t.R), vectorToBigInts(t.O)
+}
+
+func vectorToBigInts(v fr.Vector) []*big.Int {
+	res := make([]*big.Int, len(v))
+	for i := range v {
+		res[i] = v[i].BigInt(new(big.Int))
+	}
+	return res
+}
+
 func (s *system) AddGkr(gkr constraint.GkrInfo) error {
 	return s.System.AddGkr(gkr)
 }
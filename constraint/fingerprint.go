@@ -0,0 +1,352 @@
+package constraint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Fingerprint returns a hash of the constraint system which only depends on
+// its structure: the type of the system, the field, the inputs, the
+// constraints, the hints and the commitments. Two systems compiled from the
+// same circuit have the same fingerprint, while a change of a single
+// coefficient or wire changes it.
+//
+// Unlike the serialized system, the fingerprint doesn't depend on the gnark
+// version, on the order of the coefficient table, on the blueprint used to
+// encode a constraint, nor on the debug information and the logs.
+func Fingerprint(cs ConstraintSystem) ([]byte, error) {
+	c, ok := cs.(interface{ core() *System })
+	if !ok {
+		return nil, fmt.Errorf("unsupported constraint system type %T", cs)
+	}
+	system := c.core()
+
+	hf := sha256.New()
+	h := newCanonicalHasher(cs, system, hf, false)
+	h.writeUint(uint64(system.Type))
+	h.writeString(cs.Field().String())
+	for _, names := range [][]string{system.Public, system.Secret} {
+		h.writeUint(uint64(len(names)))
+		for _, name := range names {
+			h.writeString(name)
+		}
+	}
+	h.writeUint(uint64(system.NbInternalVariables))
+	h.writeUint(uint64(len(system.Instructions)))
+	for i := range system.Instructions {
+		h.writeInstruction(i)
+	}
+	h.writeString(fmt.Sprintf("%T%+v", system.CommitmentInfo, system.CommitmentInfo))
+	if system.GkrInfo.Is() {
+		h.writeString(fmt.Sprintf("%+v", system.GkrInfo))
+	}
+	return hf.Sum(nil), nil
+}
+
+// SystemDiff is the structural difference between two constraint systems,
+// see [Diff]. Each field lists the groups of constraints which differ.
+type SystemDiff struct {
+	Blueprints []DiffEntry // constraints grouped by blueprint
+	DebugInfo  []DiffEntry // constraints grouped by debug information
+	Namespaces []DiffEntry // constraints grouped by namespace
+}
+
+// DiffEntry is a group of constraints which differs between two constraint
+// systems: either the number of constraints differs, or the constraints
+// themselves.
+type DiffEntry struct {
+	Key      string
+	From, To int  // number of constraints in the group
+	Changed  bool // same number of constraints, but different constraints
+	fromH    []byte
+	toH      []byte
+}
+
+// IsEmpty returns true if the constraint systems are structurally equal.
+func (d *SystemDiff) IsEmpty() bool {
+	return len(d.Blueprints) == 0 && len(d.DebugInfo) == 0 && len(d.Namespaces) == 0
+}
+
+// String returns a human readable representation of the difference, one group
+// per line.
+func (d *SystemDiff) String() string {
+	var sbb strings.Builder
+	for _, g := range []struct {
+		name    string
+		entries []DiffEntry
+	}{{"blueprint", d.Blueprints}, {"debug info", d.DebugInfo}, {"namespace", d.Namespaces}} {
+		for _, e := range g.entries {
+			fmt.Fprintf(&sbb, "%s %q: %d -> %d", g.name, e.Key, e.From, e.To)
+			if e.Changed {
+				sbb.WriteString(" (changed)")
+			}
+			sbb.WriteByte('\n')
+		}
+	}
+	return sbb.String()
+}
+
+// Diff returns the structural difference between the constraint systems from
+// and to. The instructions of both systems are grouped by blueprint, by debug
+// information and by namespace, and the groups are compared by number of
+// constraints and by content.
+//
+// The content of a group doesn't depend on the identifiers of the internal
+// wires, so that adding constraints in one gadget doesn't change the other
+// groups. The debug information and the namespace of a constraint are derived
+// from the stack recorded when it was added: the namespace is the package of
+// the first function outside of the frontend, which is usually the gadget
+// adding the constraint. They are only available for the constraints with
+// debug information, which are most of the assertions when compiling with the
+// debug build tag; the other constraints are grouped under the empty key.
+func Diff(from, to ConstraintSystem) (*SystemDiff, error) {
+	var groups [2]systemGroups
+	for i, cs := range []ConstraintSystem{from, to} {
+		c, ok := cs.(interface{ core() *System })
+		if !ok {
+			return nil, fmt.Errorf("unsupported constraint system type %T", cs)
+		}
+		groups[i] = newSystemGroups(cs, c.core())
+	}
+	return &SystemDiff{
+		Blueprints: diffGroups(groups[0].blueprints, groups[1].blueprints),
+		DebugInfo:  diffGroups(groups[0].debugInfo, groups[1].debugInfo),
+		Namespaces: diffGroups(groups[0].namespaces, groups[1].namespaces),
+	}, nil
+}
+
+type group struct {
+	nbConstraints int
+	h             hash.Hash
+}
+
+type systemGroups struct {
+	blueprints, debugInfo, namespaces map[string]*group
+}
+
+func newSystemGroups(cs ConstraintSystem, system *System) systemGroups {
+	g := systemGroups{
+		blueprints: make(map[string]*group),
+		debugInfo:  make(map[string]*group),
+		namespaces: make(map[string]*group),
+	}
+	get := func(m map[string]*group, key string) *group {
+		if m[key] == nil {
+			m[key] = &group{h: sha256.New()}
+		}
+		return m[key]
+	}
+
+	var buf bytes.Buffer
+	h := newCanonicalHasher(cs, system, &buf, true)
+	for i, pi := range system.Instructions {
+		// encode the instruction once for all the groups
+		buf.Reset()
+		h.writeInstruction(i)
+
+		blueprint := system.Blueprints[pi.BlueprintID]
+		nbConstraints := blueprint.NbConstraints()
+		debugInfo, namespace := "", ""
+		if dID, ok := system.MDebug[int(pi.ConstraintOffset)]; ok && nbConstraints != 0 {
+			debugInfo, namespace = system.debugInfoKeys(dID)
+		}
+		for _, grp := range []*group{
+			get(g.blueprints, strings.TrimPrefix(fmt.Sprintf("%T", blueprint), "*constraint.")),
+			get(g.debugInfo, debugInfo),
+			get(g.namespaces, namespace),
+		} {
+			grp.nbConstraints += nbConstraints
+			grp.h.Write(buf.Bytes())
+		}
+	}
+	return g
+}
+
+// debugInfoKeys returns the message of the debug information dID followed by
+// the function which added the constraint, and the package of this function.
+func (system *System) debugInfoKeys(dID int) (debugInfo, namespace string) {
+	d := system.DebugInfo[dID]
+	message, _, _ := strings.Cut(d.Format, "\n")
+	for _, lID := range d.Stack {
+		function := system.SymbolTable.Functions[system.SymbolTable.Locations[lID].FunctionID]
+		if pkg := functionPackage(function.SystemName); !isFrontendPackage(pkg) {
+			return message + " in " + function.Name, pkg
+		}
+	}
+	return message, ""
+}
+
+func isFrontendPackage(pkg string) bool {
+	for _, p := range []string{
+		"github.com/consensys/gnark/frontend",
+		"github.com/consensys/gnark/constraint",
+		"github.com/consensys/gnark/internal",
+		"github.com/consensys/gnark/debug",
+		"runtime",
+	} {
+		if pkg == p || strings.HasPrefix(pkg, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// functionPackage returns the import path of the package of a fully qualified
+// function name (for example "github.com/consensys/gnark/std/math/emulated"
+// for "github.com/consensys/gnark/std/math/emulated.(*Field[...]).Mul").
+func functionPackage(function string) string {
+	slash := strings.LastIndexByte(function, '/') + 1
+	if dot := strings.IndexByte(function[slash:], '.'); dot >= 0 {
+		return function[:slash+dot]
+	}
+	return function
+}
+
+func diffGroups(from, to map[string]*group) []DiffEntry {
+	entries := make(map[string]*DiffEntry)
+	for i, groups := range []map[string]*group{from, to} {
+		for key, g := range groups {
+			e := entries[key]
+			if e == nil {
+				e = &DiffEntry{Key: key}
+				entries[key] = e
+			}
+			if i == 0 {
+				e.From, e.fromH = g.nbConstraints, g.h.Sum(nil)
+			} else {
+				e.To, e.toH = g.nbConstraints, g.h.Sum(nil)
+			}
+		}
+	}
+	var res []DiffEntry
+	for _, e := range entries {
+		if e.From != e.To || !bytes.Equal(e.fromH, e.toH) {
+			e.Changed = e.From == e.To
+			e.fromH, e.toH = nil, nil
+			res = append(res, *e)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+	return res
+}
+
+// canonicalHasher writes to w the canonical encoding of the instructions of a
+// system. If abstractWires is set, the internal wires are not identified, so
+// that the encoding of an instruction doesn't depend on the wires allocated
+// before it.
+type canonicalHasher struct {
+	w             io.Writer
+	cs            ConstraintSystem
+	system        *System
+	offset        uint32
+	abstractWires bool
+	elementSize   int
+	r1c           R1C
+	sparse        SparseR1C
+	hint          HintMapping
+}
+
+func newCanonicalHasher(cs ConstraintSystem, system *System, w io.Writer, abstractWires bool) *canonicalHasher {
+	return &canonicalHasher{
+		w:             w,
+		cs:            cs,
+		system:        system,
+		offset:        system.internalWireOffset(),
+		abstractWires: abstractWires,
+		elementSize:   (cs.FieldBitLen() + 7) / 8,
+	}
+}
+
+func (h *canonicalHasher) writeUint(v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	h.w.Write(b[:])
+}
+
+func (h *canonicalHasher) writeString(s string) {
+	h.writeUint(uint64(len(s)))
+	h.w.Write([]byte(s))
+}
+
+// writeCoeff writes the value of the coefficient, not its index in the
+// coefficient table.
+func (h *canonicalHasher) writeCoeff(cID uint32) {
+	b := make([]byte, h.elementSize)
+	h.cs.ToBigInt(h.cs.GetCoefficient(int(cID))).FillBytes(b)
+	h.w.Write(b)
+}
+
+func (h *canonicalHasher) writeWire(wire uint32) {
+	if h.abstractWires && wire >= h.offset {
+		wire = h.offset
+	}
+	h.writeUint(uint64(wire))
+}
+
+func (h *canonicalHasher) writeLinearExpression(l LinearExpression) {
+	h.writeUint(uint64(len(l)))
+	for _, t := range l {
+		h.writeWire(t.VID)
+		h.writeCoeff(t.CID)
+	}
+}
+
+func (h *canonicalHasher) writeInstruction(i int) {
+	pi := h.system.Instructions[i]
+	inst := pi.Unpack(h.system)
+	switch b := h.system.Blueprints[pi.BlueprintID].(type) {
+	case BlueprintR1C:
+		b.DecompressR1C(&h.r1c, inst)
+		h.writeString("r1c")
+		h.writeLinearExpression(h.r1c.L)
+		h.writeLinearExpression(h.r1c.R)
+		h.writeLinearExpression(h.r1c.O)
+	case BlueprintSparseR1C:
+		b.DecompressSparseR1C(&h.sparse, inst)
+		c := &h.sparse
+		// the wires with zero coefficients depend on the blueprint
+		isZero := func(cID uint32) bool { return h.cs.ToBigInt(h.cs.GetCoefficient(int(cID))).Sign() == 0 }
+		xa, xb, xc := c.XA, c.XB, c.XC
+		if isZero(c.QL) && isZero(c.QM) {
+			xa = 0
+		}
+		if isZero(c.QR) && isZero(c.QM) {
+			xb = 0
+		}
+		if isZero(c.QO) {
+			xc = 0
+		}
+		h.writeString("sparse_r1c")
+		for _, wire := range []uint32{xa, xb, xc} {
+			h.writeWire(wire)
+		}
+		for _, cID := range []uint32{c.QL, c.QR, c.QO, c.QM, c.QC} {
+			h.writeCoeff(cID)
+		}
+		h.writeUint(uint64(c.Commitment))
+	case BlueprintHint:
+		b.DecompressHint(&h.hint, inst)
+		h.writeString("hint")
+		h.writeUint(uint64(h.hint.HintID))
+		h.writeUint(uint64(len(h.hint.Inputs)))
+		for _, in := range h.hint.Inputs {
+			h.writeLinearExpression(in)
+		}
+		h.writeWire(h.hint.OutputRange.Start)
+		h.writeUint(uint64(h.hint.OutputRange.End - h.hint.OutputRange.Start))
+	default:
+		// custom blueprints are only identified by their calldata
+		h.writeString(fmt.Sprintf("%T", b))
+		h.writeWire(pi.WireOffset)
+		h.writeUint(uint64(len(inst.Calldata)))
+		for _, v := range inst.Calldata {
+			h.writeUint(uint64(v))
+		}
+	}
+}
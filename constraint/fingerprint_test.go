@@ -0,0 +1,133 @@
+package constraint_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/debug"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+type fingerprintCircuit struct {
+	X, Y   frontend.Variable
+	Z      frontend.Variable `gnark:",public"`
+	nbBits int
+	k      int
+}
+
+func (c *fingerprintCircuit) Define(api frontend.API) error {
+	api.ToBinary(c.X, c.nbBits)
+	api.AssertIsEqual(api.Mul(c.X, c.Y, c.k), c.Z)
+	return nil
+}
+
+func fingerprint(t *testing.T, ccs constraint.ConstraintSystem) []byte {
+	h, err := constraint.Fingerprint(ccs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestFingerprint(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	compile := func(newBuilder frontend.NewBuilder, nbBits, k int) constraint.ConstraintSystem {
+		ccs, err := frontend.Compile(field, newBuilder, &fingerprintCircuit{nbBits: nbBits, k: k})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ccs
+	}
+
+	ref := fingerprint(t, compile(r1cs.NewBuilder, 8, 3))
+	if !bytes.Equal(ref, fingerprint(t, compile(r1cs.NewBuilder, 8, 3))) {
+		t.Fatal("fingerprint is not deterministic")
+	}
+	for name, ccs := range map[string]constraint.ConstraintSystem{
+		"scs":         compile(scs.NewBuilder, 8, 3),
+		"nbBits":      compile(r1cs.NewBuilder, 9, 3),
+		"coefficient": compile(r1cs.NewBuilder, 8, 4),
+	} {
+		if bytes.Equal(ref, fingerprint(t, ccs)) {
+			t.Fatalf("%s: fingerprint didn't change", name)
+		}
+	}
+
+	// the fingerprint doesn't depend on the serialization
+	ccs := compile(r1cs.NewBuilder, 8, 3)
+	var buf bytes.Buffer
+	if _, err := ccs.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	deserialized := &cs_bn254.R1CS{}
+	if _, err := deserialized.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(ref, fingerprint(t, deserialized)) {
+		t.Fatal("fingerprint changed after serialization")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	compile := func(nbBits, k int) constraint.ConstraintSystem {
+		ccs, err := frontend.Compile(field, scs.NewBuilder, &fingerprintCircuit{nbBits: nbBits, k: k})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ccs
+	}
+	diff := func(from, to constraint.ConstraintSystem) *constraint.SystemDiff {
+		d, err := constraint.Diff(from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	ref := compile(8, 3)
+	if d := diff(ref, compile(8, 3)); !d.IsEmpty() {
+		t.Fatalf("unexpected difference:\n%s", d)
+	}
+
+	// more constraints
+	d := diff(ref, compile(10, 3))
+	if d.IsEmpty() || len(d.Blueprints) == 0 {
+		t.Fatal("expected a difference")
+	}
+	nbFrom, nbTo := 0, 0
+	for _, e := range d.Blueprints {
+		nbFrom += e.From
+		nbTo += e.To
+	}
+	if nbTo-nbFrom != 2*2 {
+		t.Fatalf("expected 4 more constraints:\n%s", d)
+	}
+
+	if debug.Debug {
+		// the booleanity assertions are attributed to the decomposition gadget
+		found := false
+		for _, e := range d.Namespaces {
+			found = found || e.Key == "github.com/consensys/gnark/std/math/bits"
+		}
+		if !found {
+			t.Fatalf("expected a difference in the bits namespace:\n%s", d)
+		}
+	}
+
+	// same number of constraints, different coefficient
+	d = diff(ref, compile(8, 4))
+	if d.IsEmpty() {
+		t.Fatal("expected a difference")
+	}
+	for _, e := range d.Blueprints {
+		if !e.Changed || e.From != e.To {
+			t.Fatalf("expected changed constraints:\n%s", d)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Link appends the constraints and hints of the constraint system src to the
+// constraint system dst, so that a circuit compiled once can be instantiated
+// several times in a larger circuit.
+//
+// The public and secret wires of src are replaced by the wires of dst given by
+// inputs (in this order), and the internal wires of src are replaced by new
+// internal wires of dst. Link returns the wire of dst corresponding to each
+// wire of src.
+//
+// Both constraint systems must be of the same type and over the same field.
+// Only the R1CS, PLONK and hint instructions are supported: src must not have
+// commitments, GKR sub-circuits or custom blueprints. The debug information
+// and the logs of src are not copied.
+func Link(dst, src ConstraintSystem, inputs []int) ([]int, error) {
+	d, ok := dst.(interface{ core() *System })
+	if !ok {
+		return nil, fmt.Errorf("unsupported constraint system type %T", dst)
+	}
+	s, ok := src.(interface{ core() *System })
+	if !ok {
+		return nil, fmt.Errorf("unsupported constraint system type %T", src)
+	}
+	l := linker{
+		dst:        dst,
+		src:        src,
+		dstSystem:  d.core(),
+		srcSystem:  s.core(),
+		blueprints: make(map[BlueprintID]BlueprintID),
+	}
+	if reflect.TypeOf(dst) != reflect.TypeOf(src) || l.dstSystem.Type != l.srcSystem.Type || dst.Field().Cmp(src.Field()) != 0 {
+		return nil, fmt.Errorf("can't link constraint system %T to %T", src, dst)
+	}
+	if l.srcSystem.CommitmentInfo != nil && len(src.GetCommitments().CommitmentIndexes()) != 0 {
+		return nil, errors.New("linking constraint systems with commitments is not supported")
+	}
+	if l.srcSystem.GkrInfo.Is() {
+		return nil, errors.New("linking constraint systems with GKR sub-circuit is not supported")
+	}
+	offset := int(l.srcSystem.internalWireOffset())
+	if len(inputs) != offset {
+		return nil, fmt.Errorf("expected %d input wires, got %d", offset, len(inputs))
+	}
+	nbWires := int(l.dstSystem.internalWireOffset()) + l.dstSystem.NbInternalVariables
+	for _, wire := range inputs {
+		if wire < 0 || wire >= nbWires {
+			return nil, fmt.Errorf("input wire %d doesn't exist", wire)
+		}
+	}
+
+	l.wires = make([]int, offset+l.srcSystem.NbInternalVariables)
+	copy(l.wires, inputs)
+	for i := offset; i < len(l.wires); i++ {
+		l.wires[i] = -1
+	}
+	for i := range l.srcSystem.Instructions {
+		if err := l.linkInstruction(i); err != nil {
+			return nil, err
+		}
+	}
+	// the wires which are not used by any instruction
+	for i := range l.wires {
+		l.wire(uint32(i))
+	}
+	return l.wires, nil
+}
+
+type linker struct {
+	dst, src             ConstraintSystem
+	dstSystem, srcSystem *System
+	wires                []int
+	blueprints           map[BlueprintID]BlueprintID
+}
+
+// wire returns the wire of dst corresponding to the wire of src, allocating it
+// if needed.
+func (l *linker) wire(wire uint32) uint32 {
+	if l.wires[wire] == -1 {
+		l.wires[wire] = l.dst.AddInternalVariable()
+	}
+	return uint32(l.wires[wire])
+}
+
+func (l *linker) coeff(cID uint32) uint32 {
+	return l.dst.AddCoeff(l.src.GetCoefficient(int(cID)))
+}
+
+func (l *linker) linearExpression(le LinearExpression) LinearExpression {
+	res := make(LinearExpression, len(le))
+	for i, t := range le {
+		res[i].CID = l.coeff(t.CID)
+		if t.IsConstant() {
+			res[i].VID = t.VID
+		} else {
+			res[i].VID = l.wire(t.VID)
+		}
+	}
+	return res
+}
+
+// blueprint returns the blueprint of dst of the same type as the blueprint
+// bID of src, adding it if needed. The supported blueprints are stateless.
+func (l *linker) blueprint(bID BlueprintID) BlueprintID {
+	if id, ok := l.blueprints[bID]; ok {
+		return id
+	}
+	t := reflect.TypeOf(l.srcSystem.Blueprints[bID])
+	id := BlueprintID(len(l.dstSystem.Blueprints))
+	for i, b := range l.dstSystem.Blueprints {
+		if reflect.TypeOf(b) == t {
+			id = BlueprintID(i)
+			break
+		}
+	}
+	if int(id) == len(l.dstSystem.Blueprints) {
+		id = l.dstSystem.AddBlueprint(reflect.New(t.Elem()).Interface().(Blueprint))
+	}
+	l.blueprints[bID] = id
+	return id
+}
+
+func (l *linker) linkInstruction(i int) error {
+	pi := l.srcSystem.Instructions[i]
+	inst := pi.Unpack(l.srcSystem)
+	switch b := l.srcSystem.Blueprints[pi.BlueprintID].(type) {
+	case *BlueprintGenericR1C:
+		var c R1C
+		b.DecompressR1C(&c, inst)
+		c.L = l.linearExpression(c.L)
+		c.R = l.linearExpression(c.R)
+		c.O = l.linearExpression(c.O)
+		l.dstSystem.AddR1C(c, l.blueprint(pi.BlueprintID))
+	case *BlueprintGenericSparseR1C, *BlueprintSparseR1CMul, *BlueprintSparseR1CAdd, *BlueprintSparseR1CBool:
+		var c SparseR1C
+		b.(BlueprintSparseR1C).DecompressSparseR1C(&c, inst)
+		if c.Commitment != NOT {
+			return fmt.Errorf("instruction %d: linking commitments is not supported", i)
+		}
+		c.XA, c.XB, c.XC = l.wire(c.XA), l.wire(c.XB), l.wire(c.XC)
+		c.QL, c.QR, c.QO, c.QM, c.QC = l.coeff(c.QL), l.coeff(c.QR), l.coeff(c.QO), l.coeff(c.QM), l.coeff(c.QC)
+		l.dstSystem.AddSparseR1C(c, l.blueprint(pi.BlueprintID))
+	case *BlueprintGenericHint:
+		var h HintMapping
+		b.DecompressHint(&h, inst)
+		name := l.srcSystem.MHintsDependencies[h.HintID]
+		if registered, ok := l.dstSystem.MHintsDependencies[h.HintID]; ok && registered != name {
+			return fmt.Errorf("instruction %d: hint %s is registered as %s", i, name, registered)
+		}
+		l.dstSystem.MHintsDependencies[h.HintID] = name
+		for j := range h.Inputs {
+			h.Inputs[j] = l.linearExpression(h.Inputs[j])
+		}
+		// the outputs of the hint are contiguous new wires
+		start := uint32(l.dst.GetNbInternalVariables() + l.dst.GetNbPublicVariables() + l.dst.GetNbSecretVariables())
+		for wire := h.OutputRange.Start; wire < h.OutputRange.End; wire++ {
+			if l.wires[wire] != -1 {
+				return fmt.Errorf("instruction %d: hint output %d is already defined", i, wire)
+			}
+			l.wires[wire] = l.dst.AddInternalVariable()
+		}
+		h.OutputRange.Start, h.OutputRange.End = start, start+h.OutputRange.End-h.OutputRange.Start
+		calldata := getBuffer()
+		b.CompressHint(h, calldata)
+		l.dstSystem.AddInstruction(l.blueprint(pi.BlueprintID), *calldata)
+		putBuffer(calldata)
+	default:
+		return fmt.Errorf("instruction %d: unsupported blueprint %T", i, b)
+	}
+	return nil
+}
@@ -0,0 +1,977 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/consensys/gnark/logger"
+)
+
+// OptimizationPass identifies a transformation of a compiled constraint
+// system. See [Optimize].
+type OptimizationPass uint8
+
+const (
+	// DeadWireElimination removes the hints whose outputs are not used and
+	// the constraints which only define an otherwise unused internal wire. As
+	// the wire appears linearly with a non-zero coefficient, such a constraint
+	// can be satisfied for any value of the other wires and doesn't constrain
+	// them.
+	DeadWireElimination OptimizationPass = iota
+
+	// LinearSubstitution replaces an internal wire which is defined by a
+	// linear constraint and used by a single other instruction with its
+	// defining linear expression, and removes the defining constraint. In a
+	// SparseR1CS the substitution is only performed if the result fits in a
+	// single constraint.
+	LinearSubstitution
+
+	// ConstantFolding replaces the internal wires which are forced to a
+	// constant by a constraint with their value in all the instructions using
+	// them, and removes the constraints which hold independently of the wire
+	// values.
+	ConstantFolding
+
+	// Renumbering assigns consecutive identifiers to the internal wires which
+	// are still in use. As the solver must assign a value to every wire, it is
+	// always performed when another pass removes a wire.
+	Renumbering
+)
+
+// ErrUnsupportedOptimization is returned by [Optimize] when the constraint
+// system contains instructions which the optimizer can't process.
+var ErrUnsupportedOptimization = errors.New("constraint system not supported by the optimizer")
+
+func (p OptimizationPass) String() string {
+	switch p {
+	case DeadWireElimination:
+		return "dead-wire-elimination"
+	case LinearSubstitution:
+		return "linear-substitution"
+	case ConstantFolding:
+		return "constant-folding"
+	case Renumbering:
+		return "renumbering"
+	}
+	return fmt.Sprintf("OptimizationPass(%d)", p)
+}
+
+// Optimize applies the passes to the compiled constraint system cs in place.
+// The passes are repeated until none of them simplifies the system further,
+// after which the wires are renumbered if needed.
+//
+// The optimizer preserves the semantics of the hints and of the commitments:
+// the outputs of the hints are never substituted, the inputs of the hints are
+// rewritten with equivalent linear expressions, and the wires and constraints
+// referenced by the commitments, the logs and the debug information are kept
+// (up to renumbering).
+//
+// Only the constraint and hint blueprints defined in this package are
+// supported. If cs contains other instructions (for example lookups) or a GKR
+// sub-circuit, then it is left unchanged and an error wrapping
+// [ErrUnsupportedOptimization] is returned.
+func Optimize(cs ConstraintSystem, passes ...OptimizationPass) error {
+	c, ok := cs.(interface{ core() *System })
+	if !ok {
+		return fmt.Errorf("unsupported constraint system type %T", cs)
+	}
+	var enabled [Renumbering + 1]bool
+	for _, p := range passes {
+		if p > Renumbering {
+			return fmt.Errorf("unknown optimization pass %s", p)
+		}
+		enabled[p] = true
+	}
+	system := c.core()
+	if system.GkrInfo.Is() {
+		return fmt.Errorf("%w: GKR sub-circuit", ErrUnsupportedOptimization)
+	}
+	o, err := newOptimizer(cs, system)
+	if err != nil {
+		return err
+	}
+	nbConstraints, nbInternal := system.NbConstraints, system.NbInternalVariables
+
+	changed := enabled[Renumbering]
+	for progress := true; progress; {
+		progress = false
+		if enabled[ConstantFolding] || enabled[LinearSubstitution] {
+			progress = o.substitute(enabled[ConstantFolding], enabled[LinearSubstitution]) || progress
+		}
+		if enabled[DeadWireElimination] {
+			progress = o.eliminateDeadWires() || progress
+		}
+		changed = changed || progress
+	}
+	if changed {
+		o.rebuild()
+	}
+
+	log := logger.Logger()
+	log.Info().
+		Int("nbConstraints", system.NbConstraints).
+		Int("nbRemovedConstraints", nbConstraints-system.NbConstraints).
+		Int("nbRemovedWires", nbInternal-system.NbInternalVariables).
+		Msg("optimized constraint system")
+	return nil
+}
+
+// core returns the System. It is promoted to the curve-typed constraint
+// systems embedding a System.
+func (system *System) core() *System {
+	return system
+}
+
+type instructionKind uint8
+
+const (
+	kindR1C instructionKind = iota
+	kindSparseR1C
+	kindHint
+)
+
+// optInstruction is the decompressed form of an instruction manipulated by the
+// optimizer.
+type optInstruction struct {
+	kind       instructionKind
+	bID        BlueprintID
+	r1c        R1C
+	sparse     SparseR1C
+	hint       HintMapping
+	wireOffset uint32
+	cID        int // constraint id in the original system, -1 for hints
+
+	modified bool // constraint must be encoded with the generic blueprint
+	removed  bool
+	pinned   bool // instruction must not be modified
+}
+
+// linearTerm is a term of a linearForm.
+type linearTerm struct {
+	wire  uint32
+	coeff Element
+}
+
+// linearForm represents ∑ coeffᵢ⋅wireᵢ + constant.
+type linearForm struct {
+	terms    []linearTerm
+	constant Element
+	index    map[uint32]int // position of the wires in terms, for long forms
+}
+
+// sparsePoly represents the polynomial qm⋅qa⋅qb + linearForm of a SparseR1C.
+type sparsePoly struct {
+	linearForm
+	qa, qb uint32
+	qm     Element
+}
+
+type optimizer struct {
+	cs     ConstraintSystem
+	system *System
+	insts  []optInstruction
+
+	// per wire: number of live instructions referencing it, first instruction
+	// referencing it (the one solving it), whether it must be kept and the
+	// instructions which referenced it at some point.
+	nbUses      []int
+	def         []int
+	pinned      []bool
+	occurrences [][]int
+
+	// constWire is the wire id of constant terms in linear expressions: the
+	// wire equal to one in R1CS and the constant marker in SparseR1CS.
+	constWire uint32
+	offset    uint32
+
+	// maxTerms is the length of the longest linear expression of the system.
+	// The substitutions don't create longer expressions so that the bound
+	// enforced at compilation (see frontend.WithCompressThreshold) holds.
+	maxTerms int
+
+	minusOne Element
+
+	stamp  int
+	stamps []int
+
+	genericSparse BlueprintID
+	hasGeneric    bool
+}
+
+func newOptimizer(cs ConstraintSystem, system *System) (*optimizer, error) {
+	nbWires := int(system.internalWireOffset()) + system.NbInternalVariables
+	o := &optimizer{
+		cs:          cs,
+		system:      system,
+		insts:       make([]optInstruction, len(system.Instructions)),
+		nbUses:      make([]int, nbWires),
+		def:         make([]int, nbWires),
+		pinned:      make([]bool, nbWires),
+		occurrences: make([][]int, nbWires),
+		stamps:      make([]int, nbWires),
+		offset:      system.internalWireOffset(),
+		minusOne:    cs.Neg(cs.One()),
+		constWire:   math.MaxUint32,
+	}
+	if system.Type == SystemR1CS {
+		o.constWire = 0
+	}
+	for i := range o.def {
+		o.def[i] = -1
+	}
+
+	for i, pi := range system.Instructions {
+		inst := pi.Unpack(system)
+		blueprint := system.Blueprints[pi.BlueprintID]
+		if blueprint.NbOutputs(inst) != 0 {
+			return nil, fmt.Errorf("%w: blueprint %T", ErrUnsupportedOptimization, blueprint)
+		}
+		oi := &o.insts[i]
+		oi.bID, oi.wireOffset, oi.cID = pi.BlueprintID, pi.WireOffset, int(pi.ConstraintOffset)
+		switch b := blueprint.(type) {
+		case BlueprintR1C:
+			oi.kind = kindR1C
+			b.DecompressR1C(&oi.r1c, inst)
+		case BlueprintSparseR1C:
+			oi.kind = kindSparseR1C
+			b.DecompressSparseR1C(&oi.sparse, inst)
+			oi.pinned = oi.sparse.Commitment != NOT
+			if _, ok := b.(*BlueprintGenericSparseR1C); ok && !o.hasGeneric {
+				o.genericSparse, o.hasGeneric = pi.BlueprintID, true
+			}
+		case BlueprintHint:
+			oi.kind = kindHint
+			oi.cID = -1
+			b.DecompressHint(&oi.hint, inst)
+		default:
+			return nil, fmt.Errorf("%w: blueprint %T", ErrUnsupportedOptimization, blueprint)
+		}
+		o.updateMaxTerms(i)
+		o.forEachWire(i, func(w uint32) {
+			o.nbUses[w]++
+			o.occurrences[w] = append(o.occurrences[w], i)
+			if o.def[w] == -1 {
+				o.def[w] = i
+			}
+			if oi.pinned {
+				o.pinned[w] = true
+			}
+		})
+	}
+
+	// pin the wires referenced by the commitments, the logs and the debug
+	// information.
+	pin := func(wires []int) {
+		for _, w := range wires {
+			o.pinned[w] = true
+		}
+	}
+	if commitments, ok := system.CommitmentInfo.(Groth16Commitments); ok {
+		for i := range commitments {
+			pin(commitments[i].PublicAndCommitmentCommitted)
+			pin(commitments[i].PrivateCommitted)
+			pin([]int{commitments[i].CommitmentIndex})
+		}
+	}
+	for _, entries := range [][]LogEntry{system.Logs, system.DebugInfo} {
+		for i := range entries {
+			for _, l := range entries[i].ToResolve {
+				for _, t := range l {
+					if int(t.VID) < nbWires {
+						o.pinned[t.VID] = true
+					}
+				}
+			}
+		}
+	}
+	return o, nil
+}
+
+// forEachWire calls f once for every wire referenced by the instruction i.
+// The terms of a SparseR1C with zero coefficient are not references.
+func (o *optimizer) forEachWire(i int, f func(w uint32)) {
+	o.stamp++
+	visit := func(w uint32) {
+		if w == math.MaxUint32 || o.stamps[w] == o.stamp {
+			return
+		}
+		o.stamps[w] = o.stamp
+		f(w)
+	}
+	inst := &o.insts[i]
+	switch inst.kind {
+	case kindR1C:
+		for _, l := range []LinearExpression{inst.r1c.L, inst.r1c.R, inst.r1c.O} {
+			for _, t := range l {
+				visit(t.VID)
+			}
+		}
+	case kindSparseR1C:
+		c := &inst.sparse
+		if c.QL != CoeffIdZero || c.QM != CoeffIdZero {
+			visit(c.XA)
+		}
+		if c.QR != CoeffIdZero || c.QM != CoeffIdZero {
+			visit(c.XB)
+		}
+		if c.QO != CoeffIdZero {
+			visit(c.XC)
+		}
+	case kindHint:
+		for _, l := range inst.hint.Inputs {
+			for _, t := range l {
+				visit(t.VID)
+			}
+		}
+		for w := inst.hint.OutputRange.Start; w < inst.hint.OutputRange.End; w++ {
+			visit(w)
+		}
+	}
+}
+
+func (o *optimizer) updateMaxTerms(i int) {
+	inst := &o.insts[i]
+	var ls []LinearExpression
+	switch inst.kind {
+	case kindR1C:
+		ls = []LinearExpression{inst.r1c.L, inst.r1c.R, inst.r1c.O}
+	case kindHint:
+		ls = inst.hint.Inputs
+	}
+	for _, l := range ls {
+		o.maxTerms = max(o.maxTerms, len(l))
+	}
+}
+
+func (o *optimizer) wires(i int) []uint32 {
+	var res []uint32
+	o.forEachWire(i, func(w uint32) { res = append(res, w) })
+	return res
+}
+
+func (o *optimizer) remove(i int) {
+	o.forEachWire(i, func(w uint32) { o.nbUses[w]-- })
+	o.insts[i].removed = true
+}
+
+// replace replaces the instruction i with inst and updates the wire uses.
+func (o *optimizer) replace(i int, inst optInstruction) {
+	old := o.wires(i)
+	for _, w := range old {
+		o.nbUses[w]--
+	}
+	o.insts[i] = inst
+	o.forEachWire(i, func(w uint32) {
+		o.nbUses[w]++
+		o.occurrences[w] = append(o.occurrences[w], i)
+	})
+}
+
+func (o *optimizer) isInternal(w uint32) bool {
+	return w >= o.offset && w != math.MaxUint32
+}
+
+func (o *optimizer) coeff(cID uint32) Element {
+	return o.cs.GetCoefficient(int(cID))
+}
+
+// add adds coeff⋅wire to f.
+func (o *optimizer) add(f *linearForm, wire uint32, coeff Element) {
+	if coeff.IsZero() {
+		return
+	}
+	if wire == o.constWire {
+		f.constant = o.cs.Add(f.constant, coeff)
+		return
+	}
+	if f.index == nil && len(f.terms) >= 8 {
+		f.index = make(map[uint32]int, 2*len(f.terms))
+		for i, t := range f.terms {
+			f.index[t.wire] = i
+		}
+	}
+	if f.index != nil {
+		if i, ok := f.index[wire]; ok {
+			f.terms[i].coeff = o.cs.Add(f.terms[i].coeff, coeff)
+			return
+		}
+		f.index[wire] = len(f.terms)
+	} else {
+		for i := range f.terms {
+			if f.terms[i].wire == wire {
+				f.terms[i].coeff = o.cs.Add(f.terms[i].coeff, coeff)
+				return
+			}
+		}
+	}
+	f.terms = append(f.terms, linearTerm{wire: wire, coeff: coeff})
+}
+
+// addScaled adds k⋅l to f.
+func (o *optimizer) addScaled(f *linearForm, l LinearExpression, k Element) {
+	for _, t := range l {
+		o.add(f, t.VID, o.cs.Mul(o.coeff(t.CID), k))
+	}
+}
+
+// normalize removes the terms with zero coefficient.
+func (f *linearForm) normalize() {
+	j := 0
+	for _, t := range f.terms {
+		if !t.coeff.IsZero() {
+			f.terms[j] = t
+			j++
+		}
+	}
+	f.terms = f.terms[:j]
+	f.index = nil
+}
+
+func (f *linearForm) coeffOf(w uint32) (Element, bool) {
+	for _, t := range f.terms {
+		if t.wire == w {
+			return t.coeff, true
+		}
+	}
+	return Element{}, false
+}
+
+// constantValue returns the value of l if it only has constant terms.
+func (o *optimizer) constantValue(l LinearExpression) (Element, bool) {
+	var res Element
+	for _, t := range l {
+		if t.VID != o.constWire {
+			return Element{}, false
+		}
+		res = o.cs.Add(res, o.coeff(t.CID))
+	}
+	return res, true
+}
+
+// r1cLinearForm returns L⋅R-O if either L or R is constant.
+func (o *optimizer) r1cLinearForm(r *R1C) (linearForm, bool) {
+	var f linearForm
+	if k, ok := o.constantValue(r.L); ok {
+		o.addScaled(&f, r.R, k)
+	} else if k, ok := o.constantValue(r.R); ok {
+		o.addScaled(&f, r.L, k)
+	} else {
+		return f, false
+	}
+	o.addScaled(&f, r.O, o.minusOne)
+	f.normalize()
+	return f, true
+}
+
+func (o *optimizer) sparsePoly(c *SparseR1C) sparsePoly {
+	var p sparsePoly
+	o.add(&p.linearForm, c.XA, o.coeff(c.QL))
+	o.add(&p.linearForm, c.XB, o.coeff(c.QR))
+	o.add(&p.linearForm, c.XC, o.coeff(c.QO))
+	p.constant = o.coeff(c.QC)
+	if qm := o.coeff(c.QM); !qm.IsZero() {
+		p.qa, p.qb, p.qm = c.XA, c.XB, qm
+	}
+	p.normalize()
+	return p
+}
+
+// linearForm returns the linear form which must be zero for the constraint i
+// to hold, if the constraint is linear.
+func (o *optimizer) linearForm(i int) (linearForm, bool) {
+	inst := &o.insts[i]
+	switch inst.kind {
+	case kindR1C:
+		return o.r1cLinearForm(&inst.r1c)
+	case kindSparseR1C:
+		p := o.sparsePoly(&inst.sparse)
+		return p.linearForm, p.qm.IsZero()
+	}
+	return linearForm{}, false
+}
+
+// isFree returns true if the wire w appears in the constraint i such that for
+// any value of the other wires, there exists a value of w satisfying it.
+func (o *optimizer) isFree(i int, w uint32) bool {
+	inst := &o.insts[i]
+	switch inst.kind {
+	case kindR1C:
+		inL, inR := false, false
+		var inO Element
+		for _, t := range inst.r1c.L {
+			inL = inL || t.VID == w
+		}
+		for _, t := range inst.r1c.R {
+			inR = inR || t.VID == w
+		}
+		for _, t := range inst.r1c.O {
+			if t.VID == w {
+				inO = o.cs.Add(inO, o.coeff(t.CID))
+			}
+		}
+		if !inL && !inR {
+			return !inO.IsZero()
+		}
+		f, ok := o.r1cLinearForm(&inst.r1c)
+		if !ok {
+			return false
+		}
+		_, ok = f.coeffOf(w)
+		return ok
+	case kindSparseR1C:
+		p := o.sparsePoly(&inst.sparse)
+		if !p.qm.IsZero() && (p.qa == w || p.qb == w) {
+			return false
+		}
+		_, ok := p.coeffOf(w)
+		return ok
+	}
+	return false
+}
+
+// eliminateDeadWires removes the unused hints and the constraints defining an
+// unused wire. It processes the instructions backwards so that the removals
+// propagate to the instructions defining the inputs.
+func (o *optimizer) eliminateDeadWires() bool {
+	progress := false
+	for i := len(o.insts) - 1; i >= 0; i-- {
+		inst := &o.insts[i]
+		if inst.removed || inst.pinned {
+			continue
+		}
+		if inst.kind == kindHint {
+			dead := true
+			for w := inst.hint.OutputRange.Start; w < inst.hint.OutputRange.End; w++ {
+				dead = dead && o.nbUses[w] == 1 && !o.pinned[w]
+			}
+			if dead {
+				o.remove(i)
+				progress = true
+			}
+			continue
+		}
+		for _, w := range o.wires(i) {
+			if o.isInternal(w) && o.nbUses[w] == 1 && !o.pinned[w] && o.isFree(i, w) {
+				o.remove(i)
+				progress = true
+				break
+			}
+		}
+	}
+	return progress
+}
+
+// substitute processes the linear constraints in order. The constraints which
+// hold independently of the wires are removed and the wires defined by the
+// remaining ones are substituted: constants in all their uses when
+// constantFolding is set and linear expressions in their single use when
+// linearSubstitution is set.
+func (o *optimizer) substitute(constantFolding, linearSubstitution bool) bool {
+	progress := false
+	for i := range o.insts {
+		inst := &o.insts[i]
+		if inst.removed || inst.pinned || inst.kind == kindHint {
+			continue
+		}
+		f, ok := o.linearForm(i)
+		if !ok {
+			continue
+		}
+		if constantFolding && len(f.terms) == 0 && f.constant.IsZero() {
+			hasWire := false
+			o.forEachWire(i, func(w uint32) { hasWire = hasWire || w != o.constWire })
+			if !hasWire {
+				o.remove(i)
+				progress = true
+			}
+			continue
+		}
+		// find the wire solved by the constraint
+		for _, t := range f.terms {
+			w := t.wire
+			if !o.isInternal(w) || o.def[w] != i || o.pinned[w] {
+				continue
+			}
+			// w = -(f - coeff⋅w) / coeff
+			inv, ok := o.cs.Inverse(t.coeff)
+			if !ok {
+				break
+			}
+			k := o.cs.Neg(inv)
+			var value linearForm
+			for _, s := range f.terms {
+				if s.wire != w {
+					value.terms = append(value.terms, linearTerm{wire: s.wire, coeff: o.cs.Mul(s.coeff, k)})
+				}
+			}
+			value.constant = o.cs.Mul(f.constant, k)
+
+			if len(value.terms) == 0 && constantFolding {
+				progress = o.replaceWire(i, w, value) || progress
+			} else if linearSubstitution && o.nbUses[w] == 2 {
+				progress = o.replaceWire(i, w, value) || progress
+			}
+			break
+		}
+	}
+	return progress
+}
+
+// replaceWire substitutes w with value in all the live instructions except its
+// definition i, and removes i. It doesn't modify anything if one of the
+// instructions can not be rewritten.
+func (o *optimizer) replaceWire(i int, w uint32, value linearForm) bool {
+	var uses []int
+	var rewritten []optInstruction
+	for _, j := range o.occurrences[w] {
+		if j == i || o.insts[j].removed || (len(uses) > 0 && uses[len(uses)-1] == j) {
+			continue
+		}
+		found := false
+		o.forEachWire(j, func(v uint32) { found = found || v == w })
+		if !found {
+			continue
+		}
+		if o.insts[j].pinned {
+			return false
+		}
+		inst, ok := o.substituteInstruction(j, w, value)
+		if !ok {
+			return false
+		}
+		uses = append(uses, j)
+		rewritten = append(rewritten, inst)
+	}
+	for k, j := range uses {
+		o.replace(j, rewritten[k])
+	}
+	o.remove(i)
+	return true
+}
+
+// substituteInstruction returns the instruction j where w is replaced with
+// value.
+func (o *optimizer) substituteInstruction(j int, w uint32, value linearForm) (optInstruction, bool) {
+	inst := o.insts[j]
+	switch inst.kind {
+	case kindR1C:
+		var ok [3]bool
+		inst.r1c.L, ok[0] = o.substituteLinearExpression(inst.r1c.L, w, value)
+		inst.r1c.R, ok[1] = o.substituteLinearExpression(inst.r1c.R, w, value)
+		inst.r1c.O, ok[2] = o.substituteLinearExpression(inst.r1c.O, w, value)
+		if !ok[0] || !ok[1] || !ok[2] {
+			return inst, false
+		}
+	case kindSparseR1C:
+		p := o.sparsePoly(&inst.sparse)
+		if !o.substituteSparsePoly(&p, w, value) {
+			return inst, false
+		}
+		c, ok := o.encodeSparsePoly(&p)
+		if !ok {
+			return inst, false
+		}
+		inst.sparse = c
+		inst.modified = true
+	case kindHint:
+		inputs := make([]LinearExpression, len(inst.hint.Inputs))
+		for k := range inputs {
+			var ok bool
+			if inputs[k], ok = o.substituteLinearExpression(inst.hint.Inputs[k], w, value); !ok {
+				return inst, false
+			}
+		}
+		inst.hint.Inputs = inputs
+	}
+	return inst, true
+}
+
+// substituteLinearExpression returns l where w is replaced with value. It
+// returns false if the result would be longer than both l and the longest
+// linear expression of the system.
+func (o *optimizer) substituteLinearExpression(l LinearExpression, w uint32, value linearForm) (LinearExpression, bool) {
+	var k Element
+	found := false
+	res := make(LinearExpression, 0, len(l)+len(value.terms))
+	for _, t := range l {
+		if t.VID == w {
+			k = o.cs.Add(k, o.coeff(t.CID))
+			found = true
+			continue
+		}
+		res = append(res, t)
+	}
+	if !found {
+		return l, true
+	}
+	index := make(map[uint32]int, len(res)+len(value.terms))
+	for i, t := range res {
+		index[t.VID] = i
+	}
+	merge := func(wire uint32, coeff Element) {
+		if coeff.IsZero() {
+			return
+		}
+		if i, ok := index[wire]; ok {
+			res[i] = o.cs.MakeTerm(o.cs.Add(o.coeff(res[i].CID), coeff), int(wire))
+			return
+		}
+		index[wire] = len(res)
+		res = append(res, o.cs.MakeTerm(coeff, int(wire)))
+	}
+	for _, t := range value.terms {
+		merge(t.wire, o.cs.Mul(t.coeff, k))
+	}
+	merge(o.constWire, o.cs.Mul(value.constant, k))
+
+	// remove the terms which cancelled out
+	j := 0
+	for _, t := range res {
+		if t.CID != CoeffIdZero {
+			res[j] = t
+			j++
+		}
+	}
+	res = res[:j]
+	if len(res) > len(l) && len(res) > o.maxTerms {
+		return nil, false
+	}
+	return res, true
+}
+
+// substituteSparsePoly replaces w with value in p. It returns false if the
+// result has more than one quadratic term.
+func (o *optimizer) substituteSparsePoly(p *sparsePoly, w uint32, value linearForm) bool {
+	if !p.qm.IsZero() && (p.qa == w || p.qb == w) {
+		if len(value.terms) > 1 {
+			return false
+		}
+		c := value.constant
+		if p.qa == w && p.qb == w {
+			// qm⋅(αa+c)² = qm⋅α²⋅a² + 2⋅qm⋅α⋅c⋅a + qm⋅c²
+			if len(value.terms) == 1 {
+				a, alpha := value.terms[0].wire, value.terms[0].coeff
+				twoQm := o.cs.Add(p.qm, p.qm)
+				o.add(&p.linearForm, a, o.cs.Mul(o.cs.Mul(twoQm, alpha), c))
+				p.qa, p.qb, p.qm = a, a, o.cs.Mul(p.qm, o.cs.Mul(alpha, alpha))
+			}
+			p.constant = o.cs.Add(p.constant, o.cs.Mul(p.qm, o.cs.Mul(c, c)))
+			if len(value.terms) == 0 {
+				p.qm = Element{}
+			}
+		} else {
+			// qm⋅(αa+c)⋅b = qm⋅α⋅a⋅b + qm⋅c⋅b
+			other := p.qb
+			if p.qb == w {
+				other = p.qa
+			}
+			o.add(&p.linearForm, other, o.cs.Mul(p.qm, c))
+			if len(value.terms) == 1 {
+				p.qa, p.qb, p.qm = value.terms[0].wire, other, o.cs.Mul(p.qm, value.terms[0].coeff)
+			} else {
+				p.qm = Element{}
+			}
+		}
+	}
+	if k, ok := p.coeffOf(w); ok {
+		for i := range p.terms {
+			if p.terms[i].wire == w {
+				p.terms[i].coeff = Element{}
+			}
+		}
+		for _, t := range value.terms {
+			o.add(&p.linearForm, t.wire, o.cs.Mul(t.coeff, k))
+		}
+		p.constant = o.cs.Add(p.constant, o.cs.Mul(value.constant, k))
+	}
+	p.normalize()
+	return true
+}
+
+// encodeSparsePoly returns the SparseR1C representing p == 0, if it fits.
+func (o *optimizer) encodeSparsePoly(p *sparsePoly) (SparseR1C, bool) {
+	var c SparseR1C
+	c.QC = o.cs.AddCoeff(p.constant)
+	slots := []*uint32{&c.XA, &c.XB, &c.XC}
+	coeffs := []*uint32{&c.QL, &c.QR, &c.QO}
+	used := 0
+	if !p.qm.IsZero() {
+		c.XA, c.XB, c.QM = p.qa, p.qb, o.cs.AddCoeff(p.qm)
+		used = 2
+	}
+	for _, t := range p.terms {
+		switch {
+		case !p.qm.IsZero() && t.wire == p.qa:
+			c.QL = o.cs.AddCoeff(t.coeff)
+		case !p.qm.IsZero() && t.wire == p.qb:
+			c.QR = o.cs.AddCoeff(t.coeff)
+		case used < len(slots):
+			*slots[used] = t.wire
+			*coeffs[used] = o.cs.AddCoeff(t.coeff)
+			used++
+		default:
+			return c, false
+		}
+	}
+	return c, true
+}
+
+// rebuild encodes the remaining instructions in the system with consecutive
+// internal wire ids and recomputes the levels.
+func (o *optimizer) rebuild() {
+	system := o.system
+	offset := o.offset
+	nbWires := len(o.nbUses)
+
+	// liveBefore[w] is the number of internal wires before w which are kept.
+	liveBefore := make([]uint32, nbWires+1)
+	for w := offset; w < uint32(nbWires); w++ {
+		liveBefore[w+1] = liveBefore[w]
+		if o.nbUses[w] > 0 || o.pinned[w] {
+			liveBefore[w+1]++
+		}
+	}
+	isLive := func(w uint32) bool {
+		return liveBefore[w+1] != liveBefore[w]
+	}
+	remap := func(w uint32) uint32 {
+		if w < offset || w == math.MaxUint32 {
+			return w
+		}
+		if !isLive(w) {
+			// only happens for the terms with zero coefficient of a SparseR1C
+			return 0
+		}
+		return offset + liveBefore[w]
+	}
+	remapLinearExpression := func(l LinearExpression) {
+		for i := range l {
+			l[i].VID = remap(l[i].VID)
+		}
+	}
+
+	nbConstraints := system.NbConstraints
+	system.Instructions = system.Instructions[:0]
+	system.CallData = system.CallData[:0]
+	system.Levels = system.Levels[:0]
+	system.NbConstraints = 0
+	system.NbInternalVariables = int(liveBefore[nbWires])
+	system.lbWireLevel = make([]Level, system.NbInternalVariables)
+	for i := range system.lbWireLevel {
+		system.lbWireLevel[i] = LevelUnset
+	}
+
+	cIDs := make([]int, nbConstraints)
+	for i := range cIDs {
+		cIDs[i] = -1
+	}
+	calldata := getBuffer()
+	defer putBuffer(calldata)
+	for i := range o.insts {
+		inst := &o.insts[i]
+		if inst.removed {
+			continue
+		}
+		*calldata = (*calldata)[:0]
+		bID := inst.bID
+		switch inst.kind {
+		case kindR1C:
+			remapLinearExpression(inst.r1c.L)
+			remapLinearExpression(inst.r1c.R)
+			remapLinearExpression(inst.r1c.O)
+			system.Blueprints[bID].(BlueprintR1C).CompressR1C(&inst.r1c, calldata)
+		case kindSparseR1C:
+			c := &inst.sparse
+			c.XA, c.XB, c.XC = remap(c.XA), remap(c.XB), remap(c.XC)
+			if inst.modified {
+				if !o.hasGeneric {
+					o.genericSparse, o.hasGeneric = system.AddBlueprint(&BlueprintGenericSparseR1C{}), true
+				}
+				bID = o.genericSparse
+			}
+			system.Blueprints[bID].(BlueprintSparseR1C).CompressSparseR1C(c, calldata)
+		case kindHint:
+			for _, l := range inst.hint.Inputs {
+				remapLinearExpression(l)
+			}
+			nbOutputs := inst.hint.OutputRange.End - inst.hint.OutputRange.Start
+			inst.hint.OutputRange.Start = remap(inst.hint.OutputRange.Start)
+			inst.hint.OutputRange.End = inst.hint.OutputRange.Start + nbOutputs
+			system.Blueprints[bID].(BlueprintHint).CompressHint(inst.hint, calldata)
+		}
+		if inst.kind != kindHint {
+			cIDs[inst.cID] = system.NbConstraints
+		}
+		wireOffset := inst.wireOffset
+		if wireOffset >= offset {
+			wireOffset = offset + liveBefore[wireOffset]
+		}
+		system.appendInstruction(bID, wireOffset, *calldata)
+	}
+
+	// update the references to the constraints and the wires
+	mDebug := make(map[int]int, len(system.MDebug))
+	for cID, dID := range system.MDebug {
+		if cID < len(cIDs) && cIDs[cID] != -1 {
+			mDebug[cIDs[cID]] = dID
+		}
+	}
+	system.MDebug = mDebug
+	for _, entries := range [][]LogEntry{system.Logs, system.DebugInfo} {
+		for i := range entries {
+			for _, l := range entries[i].ToResolve {
+				remapLinearExpression(l)
+			}
+		}
+	}
+	remapWires := func(wires []int) []int {
+		res := make([]int, len(wires))
+		for i, w := range wires {
+			res[i] = int(remap(uint32(w)))
+		}
+		return res
+	}
+	switch commitments := system.CommitmentInfo.(type) {
+	case Groth16Commitments:
+		for i := range commitments {
+			c := &commitments[i]
+			c.PublicAndCommitmentCommitted = remapWires(c.PublicAndCommitmentCommitted)
+			c.PrivateCommitted = remapWires(c.PrivateCommitted)
+			c.CommitmentIndex = int(remap(uint32(c.CommitmentIndex)))
+		}
+	case PlonkCommitments:
+		for i := range commitments {
+			c := &commitments[i]
+			committed := make([]int, len(c.Committed))
+			for j := range committed {
+				committed[j] = cIDs[c.Committed[j]]
+			}
+			c.Committed = committed
+			c.CommitmentIndex = cIDs[c.CommitmentIndex]
+		}
+	}
+}
+
+// appendInstruction adds an instruction without allocating its output wires
+// and updates the levels.
+func (system *System) appendInstruction(bID BlueprintID, wireOffset uint32, calldata []uint32) {
+	pi := PackedInstruction{
+		StartCallData:    uint64(len(system.CallData)),
+		ConstraintOffset: uint32(system.NbConstraints),
+		WireOffset:       wireOffset,
+		BlueprintID:      bID,
+	}
+	system.CallData = append(system.CallData, calldata...)
+	blueprint := system.Blueprints[bID]
+	system.NbConstraints += blueprint.NbConstraints()
+	system.Instructions = append(system.Instructions, pi)
+
+	level := blueprint.UpdateInstructionTree(pi.Unpack(system), system)
+	iID := uint32(len(system.Instructions) - 1)
+	if int(level) >= len(system.Levels) {
+		system.Levels = append(system.Levels, []uint32{iID})
+	} else {
+		system.Levels[level] = append(system.Levels[level], iID)
+	}
+}
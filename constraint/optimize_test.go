@@ -0,0 +1,185 @@
+package constraint_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/test"
+)
+
+var allPasses = []constraint.OptimizationPass{
+	constraint.DeadWireElimination,
+	constraint.LinearSubstitution,
+	constraint.ConstantFolding,
+}
+
+func identityHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	for i := range outputs {
+		outputs[i].Set(inputs[i])
+	}
+	return nil
+}
+
+type optimizeCircuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+}
+
+func (c *optimizeCircuit) Define(api frontend.API) error {
+	// linear definitions used once
+	a := api.Mul(api.Add(c.X, 3), c.Y)
+	b := api.Mul(api.Sub(1, c.Y), c.X)
+
+	// unused results
+	api.Mul(c.X, c.Y, c.Y)
+	if _, err := api.Compiler().NewHint(identityHint, 2, c.X, c.Y); err != nil {
+		return err
+	}
+
+	// wire forced to a constant
+	h, err := api.Compiler().NewHint(identityHint, 1, 5)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(h[0], 5)
+	d := api.Mul(h[0], c.X, c.X)
+
+	// hint input which can be simplified
+	e, err := api.Compiler().NewHint(identityHint, 1, api.Add(a, 1))
+	if err != nil {
+		return err
+	}
+
+	api.AssertIsEqual(api.Add(a, b, d, e[0]), c.Z)
+	return nil
+}
+
+func (c *optimizeCircuit) assignment(x, y int) *optimizeCircuit {
+	// a + b + d + a + 1
+	z := 2*(x+3)*y + (1-y)*x + 5*x*x + 1
+	return &optimizeCircuit{X: x, Y: y, Z: z}
+}
+
+func TestOptimize(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	for _, builder := range []struct {
+		name string
+		new  frontend.NewBuilder
+	}{{"r1cs", r1cs.NewBuilder}, {"scs", scs.NewBuilder}} {
+		t.Run(builder.name, func(t *testing.T) {
+			var circuit optimizeCircuit
+			ccs, err := frontend.Compile(field, builder.new, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			optimized, err := frontend.Compile(field, builder.new, &circuit, frontend.WithOptimizations(allPasses...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if optimized.GetNbConstraints() >= ccs.GetNbConstraints() {
+				t.Fatalf("expected less constraints, got %d (from %d)", optimized.GetNbConstraints(), ccs.GetNbConstraints())
+			}
+			if optimized.GetNbInternalVariables() >= ccs.GetNbInternalVariables() {
+				t.Fatalf("expected less internal variables, got %d (from %d)", optimized.GetNbInternalVariables(), ccs.GetNbInternalVariables())
+			}
+
+			valid, err := frontend.NewWitness(circuit.assignment(3, 4), field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = optimized.IsSolved(valid, solver.WithHints(identityHint)); err != nil {
+				t.Fatal(err)
+			}
+			invalid := circuit.assignment(3, 4)
+			invalid.Z = 42
+			invalidWitness, err := frontend.NewWitness(invalid, field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = optimized.IsSolved(invalidWitness, solver.WithHints(identityHint)); err == nil {
+				t.Fatal("expected invalid witness to fail")
+			}
+		})
+	}
+}
+
+func TestOptimizeRenumbering(t *testing.T) {
+	// renumbering alone doesn't remove anything
+	field := ecc.BN254.ScalarField()
+	var circuit optimizeCircuit
+	ccs, err := frontend.Compile(field, scs.NewBuilder, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renumbered, err := frontend.Compile(field, scs.NewBuilder, &circuit, frontend.WithOptimizations(constraint.Renumbering))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renumbered.GetNbConstraints() != ccs.GetNbConstraints() || renumbered.GetNbInternalVariables() != ccs.GetNbInternalVariables() {
+		t.Fatal("renumbering modified the constraint system")
+	}
+}
+
+type optimizeCommitmentCircuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+}
+
+func (c *optimizeCommitmentCircuit) Define(api frontend.API) error {
+	a := api.Add(c.X, 2)
+	b := api.Mul(api.Sub(1, c.Y), c.X)
+	api.Mul(a, a)
+	cmt, err := api.(frontend.Committer).Commit(a, b)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(cmt, 0)
+	api.AssertIsEqual(api.Add(a, b, api.Mul(cmt, 0)), c.Z)
+	return nil
+}
+
+func TestOptimizeCommitment(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&optimizeCommitmentCircuit{},
+		test.WithValidAssignment(&optimizeCommitmentCircuit{X: 3, Y: 4, Z: 3 + 2 + (1-4)*3}),
+		test.WithInvalidAssignment(&optimizeCommitmentCircuit{X: 3, Y: 4, Z: 0}),
+		test.WithCurves(ecc.BN254),
+		test.WithBackends(backend.GROTH16, backend.PLONK),
+		test.WithCompileOpts(frontend.WithOptimizations(allPasses...)),
+	)
+}
+
+type optimizeLookupCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *optimizeLookupCircuit) Define(api frontend.API) error {
+	t := logderivlookup.New(api)
+	for i := 0; i < 4; i++ {
+		t.Insert(i * i)
+	}
+	api.AssertIsEqual(t.Lookup(c.X)[0], c.Y)
+	return nil
+}
+
+func TestOptimizeUnsupported(t *testing.T) {
+	// the lookups are not supported, the compilation must fail instead of
+	// silently skipping the optimizations
+	field := ecc.BN254.ScalarField()
+	for _, newBuilder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		_, err := frontend.Compile(field, newBuilder, &optimizeLookupCircuit{}, frontend.WithOptimizations(allPasses...))
+		if !errors.Is(err, constraint.ErrUnsupportedOptimization) {
+			t.Fatalf("expected unsupported optimization error, got %v", err)
+		}
+	}
+}
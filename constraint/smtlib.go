@@ -0,0 +1,336 @@
+package constraint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// SMTLIBOption configures the export of a constraint system with
+// [WriteSMTLIB].
+type SMTLIBOption func(*smtlibConfig) error
+
+type smtlibConfig struct {
+	determinism bool
+}
+
+// WithDeterminismQuery adds a query to the exported constraint system checking
+// that the internal wires are uniquely determined by the public and secret
+// inputs. The internal wires are declared twice and constrained by two copies
+// of the constraints sharing the inputs, and the query asserts that one of the
+// internal wires used in the constraints differs between the copies. An unsat
+// result means that the wires are deterministic.
+//
+// The outputs of the hints are free variables which are excluded from the
+// query, as some gadgets leave them free by design (for example the inverse
+// computed by IsZero when the input is zero). The query only checks the wires
+// computed by the constraints, so that a hint output which is only used in
+// assertions is not checked. The constraint systems with commitments are not
+// supported, as the commitments are not algebraically determined by the
+// committed values.
+func WithDeterminismQuery() SMTLIBOption {
+	return func(opt *smtlibConfig) error {
+		opt.determinism = true
+		return nil
+	}
+}
+
+// WriteSMTLIB writes the constraint system cs to w in the SMT-LIB2 format,
+// over the finite field theory of cvc5 (logic QF_FF).
+//
+// The public and secret inputs are named after the leaves of the circuit
+// schema (for example |A.Limbs_0|), and the internal wires are named |vN|
+// as in the debug output. The outputs of the hints are unconstrained
+// variables, with a comment giving the hint name. Without options, the output
+// only declares the wires and asserts the constraints, so that queries can be
+// appended; see [WithDeterminismQuery] for a complete query. The output is
+// deterministic.
+//
+// Only the R1CS, PLONK and hint instructions are supported; the constraint
+// systems with lookups, GKR sub-circuits or custom blueprints are not.
+func WriteSMTLIB(w io.Writer, cs ConstraintSystem, opts ...SMTLIBOption) error {
+	var cfg smtlibConfig
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return err
+		}
+	}
+	c, ok := cs.(interface{ core() *System })
+	if !ok {
+		return fmt.Errorf("unsupported constraint system type %T", cs)
+	}
+	system := c.core()
+	if system.GkrInfo.Is() {
+		return errors.New("constraint systems with GKR sub-circuit are not supported")
+	}
+	if cfg.determinism && len(cs.GetCommitments().CommitmentIndexes()) != 0 {
+		return errors.New("determinism query of constraint systems with commitments is not supported")
+	}
+
+	e := &smtlibExporter{
+		cs:        cs,
+		system:    system,
+		nbWires:   int(system.internalWireOffset()) + system.NbInternalVariables,
+		offset:    int(system.internalWireOffset()),
+		constWire: -1,
+		twoCopies: cfg.determinism,
+		used:      make(map[int]bool),
+		hinted:    make(map[int]bool),
+	}
+	if system.Type == SystemR1CS {
+		// the first public wire is the constant 1
+		e.constWire = 0
+	}
+	if err := e.nameWires(); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	e.w = bw
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	nbCopies := 1
+	if e.twoCopies {
+		nbCopies = 2
+	}
+	for side := 0; side < nbCopies; side++ {
+		if side == 1 {
+			e.printf("\n; second copy of the constraints\n")
+		}
+		if err := e.writeInstructions(side); err != nil {
+			return err
+		}
+	}
+	if e.twoCopies {
+		e.writeDeterminismQuery()
+	}
+	if e.err != nil {
+		return e.err
+	}
+	return bw.Flush()
+}
+
+type smtlibExporter struct {
+	cs        ConstraintSystem
+	system    *System
+	w         *bufio.Writer
+	err       error
+	nbWires   int
+	offset    int
+	constWire int
+	names     [2][]string
+	twoCopies bool
+	inHint    bool         // set when writing the inputs of a hint
+	used      map[int]bool // internal wires used in the constraints
+	hinted    map[int]bool // outputs of the hints
+}
+
+func (e *smtlibExporter) printf(format string, args ...any) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+// nameWires sets the SMT-LIB symbols of the wires. The inputs are shared by
+// the two copies of the constraints.
+func (e *smtlibExporter) nameWires() error {
+	seen := make(map[string]bool)
+	add := func(name string) (string, error) {
+		if strings.ContainsAny(name, `|\`) {
+			return "", fmt.Errorf("wire name %q can't be used as a SMT-LIB symbol", name)
+		}
+		if seen[name] {
+			return "", fmt.Errorf("duplicate wire name %q", name)
+		}
+		seen[name] = true
+		return "|" + name + "|", nil
+	}
+	for side := range e.names {
+		e.names[side] = make([]string, e.nbWires)
+	}
+	for wire := 0; wire < e.nbWires; wire++ {
+		name := e.system.VariableToString(wire)
+		var err error
+		if e.names[0][wire], err = add(name); err != nil {
+			return err
+		}
+		e.names[1][wire] = e.names[0][wire]
+		if e.twoCopies && wire >= e.offset {
+			if e.names[1][wire], err = add(name + "'"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *smtlibExporter) writeHeader() error {
+	e.printf("; constraint system exported by gnark\n")
+	e.printf("; %d constraints, %d public, %d secret and %d internal wires\n",
+		e.cs.GetNbConstraints(), e.system.GetNbPublicVariables(), e.system.GetNbSecretVariables(), e.system.NbInternalVariables)
+	e.printf("(set-logic QF_FF)\n")
+	e.printf("(define-sort F () (_ FiniteField %s))\n", e.cs.Field())
+
+	declare := func(title string, from, to int, side int) {
+		if from == to {
+			return
+		}
+		e.printf("\n; %s\n", title)
+		for wire := from; wire < to; wire++ {
+			if wire == e.constWire {
+				continue
+			}
+			e.printf("(declare-const %s F)\n", e.names[side][wire])
+		}
+	}
+	nbPublic := e.system.GetNbPublicVariables()
+	declare("public inputs", 0, nbPublic, 0)
+	declare("secret inputs", nbPublic, e.offset, 0)
+	declare("internal wires", e.offset, e.nbWires, 0)
+	if e.twoCopies {
+		declare("internal wires of the second copy", e.offset, e.nbWires, 1)
+	}
+	e.printf("\n")
+	return e.err
+}
+
+func (e *smtlibExporter) writeInstructions(side int) error {
+	var (
+		r1c    R1C
+		sparse SparseR1C
+		hint   HintMapping
+	)
+	for i, pi := range e.system.Instructions {
+		inst := pi.Unpack(e.system)
+		switch b := e.system.Blueprints[pi.BlueprintID].(type) {
+		case BlueprintR1C:
+			b.DecompressR1C(&r1c, inst)
+			e.printf("(assert (= (ff.mul %s %s) %s))\n",
+				e.linearExpression(r1c.L, side), e.linearExpression(r1c.R, side), e.linearExpression(r1c.O, side))
+		case BlueprintSparseR1C:
+			b.DecompressSparseR1C(&sparse, inst)
+			e.printf("(assert (= %s %s))\n", e.sparseR1C(&sparse, side), e.constant(new(big.Int)))
+		case BlueprintHint:
+			b.DecompressHint(&hint, inst)
+			outputs := make([]string, 0, hint.OutputRange.End-hint.OutputRange.Start)
+			for wire := hint.OutputRange.Start; wire < hint.OutputRange.End; wire++ {
+				outputs = append(outputs, e.names[side][wire])
+				e.hinted[int(wire)] = true
+			}
+			inputs := make([]string, len(hint.Inputs))
+			e.inHint = true
+			for j := range hint.Inputs {
+				inputs[j] = e.linearExpression(hint.Inputs[j], side)
+			}
+			e.inHint = false
+			e.printf("; %s = %s(%s)\n", strings.Join(outputs, " "), e.system.MHintsDependencies[hint.HintID], strings.Join(inputs, ", "))
+		default:
+			return fmt.Errorf("instruction %d: unsupported blueprint %T", i, b)
+		}
+	}
+	return e.err
+}
+
+// writeDeterminismQuery asserts that an internal wire used in the constraints
+// which is not a hint output differs between the two copies.
+func (e *smtlibExporter) writeDeterminismQuery() {
+	e.printf("\n; an internal wire differs between the copies\n")
+	var differences []string
+	for wire := e.offset; wire < e.nbWires; wire++ {
+		if e.used[wire] && !e.hinted[wire] {
+			differences = append(differences, fmt.Sprintf("(not (= %s %s))", e.names[0][wire], e.names[1][wire]))
+		}
+	}
+	switch len(differences) {
+	case 0:
+		e.printf("(assert false)\n")
+	case 1:
+		e.printf("(assert %s)\n", differences[0])
+	default:
+		e.printf("(assert (or\n  %s))\n", strings.Join(differences, "\n  "))
+	}
+	e.printf("(check-sat)\n")
+}
+
+// constant returns the field element v, written as a negative integer when it
+// is larger than half the modulus for readability.
+func (e *smtlibExporter) constant(v *big.Int) string {
+	q := e.cs.Field()
+	if new(big.Int).Lsh(v, 1).Cmp(q) > 0 {
+		return fmt.Sprintf("(as ff%s F)", new(big.Int).Sub(v, q))
+	}
+	return fmt.Sprintf("(as ff%s F)", v)
+}
+
+// term returns the product of the coefficient cID by the wires, or the empty
+// string if the coefficient is zero.
+func (e *smtlibExporter) term(cID uint32, side int, wires ...uint32) string {
+	coeff := e.cs.ToBigInt(e.cs.GetCoefficient(int(cID)))
+	if coeff.Sign() == 0 {
+		return ""
+	}
+	factors := make([]string, 0, len(wires)+1)
+	if coeff.Cmp(big.NewInt(1)) != 0 {
+		factors = append(factors, e.constant(coeff))
+	}
+	for _, wire := range wires {
+		if int(wire) == e.constWire {
+			continue
+		}
+		if int(wire) >= e.offset && !e.inHint {
+			e.used[int(wire)] = true
+		}
+		factors = append(factors, e.names[side][wire])
+	}
+	switch len(factors) {
+	case 0:
+		return e.constant(coeff)
+	case 1:
+		return factors[0]
+	}
+	return "(ff.mul " + strings.Join(factors, " ") + ")"
+}
+
+func (e *smtlibExporter) sum(terms []string) string {
+	nonZero := terms[:0]
+	for _, t := range terms {
+		if t != "" {
+			nonZero = append(nonZero, t)
+		}
+	}
+	switch len(nonZero) {
+	case 0:
+		return e.constant(new(big.Int))
+	case 1:
+		return nonZero[0]
+	}
+	return "(ff.add " + strings.Join(nonZero, " ") + ")"
+}
+
+func (e *smtlibExporter) linearExpression(l LinearExpression, side int) string {
+	terms := make([]string, len(l))
+	for i, t := range l {
+		if t.IsConstant() {
+			// constant term of a hint input, whose VID is not a wire
+			terms[i] = e.term(t.CID, side)
+			continue
+		}
+		terms[i] = e.term(t.CID, side, t.VID)
+	}
+	return e.sum(terms)
+}
+
+// sparseR1C returns qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa⋅xb) + qC.
+func (e *smtlibExporter) sparseR1C(c *SparseR1C, side int) string {
+	return e.sum([]string{
+		e.term(c.QL, side, c.XA),
+		e.term(c.QR, side, c.XB),
+		e.term(c.QO, side, c.XC),
+		e.term(c.QM, side, c.XA, c.XB),
+		e.term(c.QC, side),
+	})
+}
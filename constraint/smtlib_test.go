@@ -0,0 +1,184 @@
+package constraint_test
+
+import (
+	"bytes"
+	"math/big"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+type smtlibCircuit struct {
+	X [2]frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *smtlibCircuit) Define(api frontend.API) error {
+	bits := api.ToBinary(c.X[0], 4)
+	api.AssertIsEqual(api.Mul(api.FromBinary(bits...), c.X[1]), api.Add(c.Y, 3))
+	return nil
+}
+
+func TestWriteSMTLIB(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	for _, builder := range []struct {
+		name string
+		new  frontend.NewBuilder
+	}{{"r1cs", r1cs.NewBuilder}, {"scs", scs.NewBuilder}} {
+		t.Run(builder.name, func(t *testing.T) {
+			export := func(opts ...constraint.SMTLIBOption) string {
+				ccs, err := frontend.Compile(field, builder.new, &smtlibCircuit{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				var buf bytes.Buffer
+				if err = constraint.WriteSMTLIB(&buf, ccs, opts...); err != nil {
+					t.Fatal(err)
+				}
+				return buf.String()
+			}
+
+			smt := export()
+			if smt != export() {
+				t.Fatal("export is not deterministic")
+			}
+			for _, s := range []string{
+				"(set-logic QF_FF)",
+				"(define-sort F () (_ FiniteField " + field.String() + "))",
+				"(declare-const |Y| F)",
+				"(declare-const |X_0| F)",
+				"(declare-const |X_1| F)",
+				"(declare-const |v0| F)",
+				"(assert ",
+			} {
+				if !strings.Contains(smt, s) {
+					t.Fatalf("missing %q in\n%s", s, smt)
+				}
+			}
+			if strings.Contains(smt, "check-sat") {
+				t.Fatal("unexpected query")
+			}
+
+			query := export(constraint.WithDeterminismQuery())
+			for _, s := range []string{
+				"(declare-const |v0'| F)",
+				"(not (= |v4| |v4'|))",
+				"(check-sat)",
+			} {
+				if !strings.Contains(query, s) {
+					t.Fatalf("missing %q in\n%s", s, query)
+				}
+			}
+			// the bits are hint outputs
+			if strings.Contains(query, "(not (= |v0| |v0'|))") {
+				t.Fatalf("unexpected hint output in the query\n%s", query)
+			}
+		})
+	}
+}
+
+func TestWriteSMTLIBCommitment(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &optimizeCommitmentCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = constraint.WriteSMTLIB(&buf, ccs); err != nil {
+		t.Fatal(err)
+	}
+	if err = constraint.WriteSMTLIB(&buf, ccs, constraint.WithDeterminismQuery()); err == nil {
+		t.Fatal("expected determinism query with commitment to fail")
+	}
+}
+
+type smtlibHintCircuit struct {
+	X frontend.Variable
+}
+
+func smtlibHint(_ *big.Int, inputs, outputs []*big.Int) error {
+	outputs[0].Add(inputs[0], inputs[1])
+	return nil
+}
+
+func (c *smtlibHintCircuit) Define(api frontend.API) error {
+	res, err := api.Compiler().NewHint(smtlibHint, 1, c.X, 5)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(res[0], api.Add(c.X, 5))
+	return nil
+}
+
+func TestWriteSMTLIBHintConstantInput(t *testing.T) {
+	// the constant inputs of the hints are not wires in a SparseR1CS
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &smtlibHintCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = constraint.WriteSMTLIB(&buf, ccs, constraint.WithDeterminismQuery()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "(as ff5 F)") {
+		t.Fatalf("missing constant hint input in\n%s", buf.String())
+	}
+}
+
+type smtlibIsZeroCircuit struct {
+	A, B frontend.Variable
+}
+
+func (c *smtlibIsZeroCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.IsZero(c.A), c.B)
+	return nil
+}
+
+func TestWriteSMTLIBIsZero(t *testing.T) {
+	// the inverse computed by IsZero is free when the input is zero, but the
+	// result is determined.
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), builder, &smtlibIsZeroCircuit{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err = constraint.WriteSMTLIB(&buf, ccs, constraint.WithDeterminismQuery()); err != nil {
+			t.Fatal(err)
+		}
+		query := buf.String()
+		if !strings.Contains(query, "(not (= ") {
+			t.Fatalf("missing result of IsZero in the query\n%s", query)
+		}
+		for _, line := range strings.Split(query, "\n") {
+			if !strings.HasPrefix(line, "; |") {
+				continue
+			}
+			// the outputs of the hints are listed in the comment
+			outputs := strings.Fields(line[2:strings.Index(line, " = ")])
+			for _, o := range outputs {
+				if strings.Contains(query, "(not (= "+o+" ") {
+					t.Fatalf("unexpected hint output %s in the query\n%s", o, query)
+				}
+			}
+		}
+
+		if _, err := exec.LookPath("cvc5"); err != nil {
+			continue
+		}
+		cmd := exec.Command("cvc5", "--lang=smt2")
+		cmd.Stdin = strings.NewReader(query)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.TrimSpace(string(out)) != "unsat" {
+			t.Fatalf("expected unsat, got %s", out)
+		}
+	}
+}
@@ -72,7 +72,8 @@ type ConstraintSystem interface {
 	AttachDebugInfo(debugInfo DebugInfo, constraintID []int)
 
 	// CheckUnconstrainedWires returns and error if the constraint system has wires that are not uniquely constrained.
-	// This is experimental.
+	// This is experimental. See [FindUnderconstrainedWires] for finding the wires which are not uniquely
+	// determined by the inputs of a given witness.
 	CheckUnconstrainedWires() error
 
 	GetInstruction(int) Instruction
@@ -0,0 +1,627 @@
+package constraint
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+// UnderconstrainedWire is an internal wire which is not uniquely determined by
+// the public and secret inputs. See [FindUnderconstrainedWires].
+type UnderconstrainedWire struct {
+	// WireID is the identifier of the wire in the constraint system.
+	WireID int
+	// Hint is the name of the hint whose mutated outputs lead to a different
+	// value of the wire.
+	Hint string
+	// Value and AlternativeValue are two values of the wire, both satisfying
+	// all the constraints for the same inputs.
+	Value, AlternativeValue *big.Int
+	// Stack is the stack trace of the first constraint involving the wire. It
+	// is only available if the circuit was compiled with the debug build tag.
+	Stack string
+}
+
+func (w UnderconstrainedWire) String() string {
+	var sbb strings.Builder
+	sbb.WriteString("wire ")
+	sbb.WriteString(strconv.Itoa(w.WireID))
+	sbb.WriteString(" is not uniquely determined (values ")
+	sbb.WriteString(w.Value.String())
+	sbb.WriteString(" and ")
+	sbb.WriteString(w.AlternativeValue.String())
+	sbb.WriteString(" after mutating hint ")
+	sbb.WriteString(w.Hint)
+	sbb.WriteString(")")
+	if w.Stack != "" {
+		sbb.WriteByte('\n')
+		sbb.WriteString(w.Stack)
+	}
+	return sbb.String()
+}
+
+// HintMutation is a transformation of a hint output, used for looking for
+// alternative solutions of a constraint system.
+type HintMutation struct {
+	Name string
+	// Mutate returns the mutated value of the output v modulo mod.
+	Mutate func(rng *rand.Rand, mod, v *big.Int) *big.Int
+}
+
+// HintMutations returns the transformations applied to the hint outputs by
+// [FindUnderconstrainedWires] and by the hint fuzzing of the test package.
+func HintMutations() []HintMutation {
+	return []HintMutation{
+		{"random", func(rng *rand.Rand, mod, v *big.Int) *big.Int {
+			r := new(big.Int).Rand(rng, mod)
+			for r.Cmp(v) == 0 {
+				r.Rand(rng, mod)
+			}
+			return r
+		}},
+		// off by one
+		{"plus_one", func(_ *rand.Rand, mod, v *big.Int) *big.Int {
+			r := new(big.Int).Add(v, big.NewInt(1))
+			return r.Mod(r, mod)
+		}},
+		{"minus_one", func(_ *rand.Rand, mod, v *big.Int) *big.Int {
+			r := new(big.Int).Sub(v, big.NewInt(1))
+			return r.Mod(r, mod)
+		}},
+		// other square root
+		{"negate", func(_ *rand.Rand, mod, v *big.Int) *big.Int {
+			r := new(big.Int).Neg(v)
+			return r.Mod(r, mod)
+		}},
+		// flipped bit
+		{"flip_bit", func(_ *rand.Rand, mod, v *big.Int) *big.Int {
+			r := new(big.Int).Sub(big.NewInt(1), v)
+			return r.Mod(r, mod)
+		}},
+		// shift by the modulus of one of the other fields, which is how an
+		// unreduced emulated value differs from the reduced one.
+		{"modulus_shift", func(rng *rand.Rand, mod, v *big.Int) *big.Int {
+			var moduli []*big.Int
+			for _, curve := range gnark.Curves() {
+				for _, q := range []*big.Int{curve.BaseField(), curve.ScalarField()} {
+					if new(big.Int).Mod(q, mod).Sign() != 0 {
+						moduli = append(moduli, q)
+					}
+				}
+			}
+			r := new(big.Int).Add(v, moduli[rng.Intn(len(moduli))])
+			return r.Mod(r, mod)
+		}},
+	}
+}
+
+// FindUnderconstrainedWires returns the internal wires of cs which are not
+// uniquely determined by the public and secret inputs of witness. The witness
+// must be valid, and opts are passed to the solver (for example for providing
+// the hint functions).
+//
+// The analysis first propagates the inputs through the constraints to find
+// the hint outputs which are not algebraically determined by the inputs. For
+// example, the outputs of a binary decomposition are not determined as they
+// are only constrained by a sum of many unknown terms and booleanity
+// constraints. Then, for every such hint, the system is solved again with the
+// hint outputs mutated by each of the [HintMutations]. If all the constraints
+// still hold, then the wires whose values differ from the honest solution are
+// reported, sorted by identifier.
+//
+// The analysis is neither sound nor complete: a wire is only reported when an
+// alternative solution has been found, and a wire which is not reported may
+// still be underconstrained for other values. The outputs of the commitment
+// hints are computed deterministically from the committed values, and the
+// outputs of the blueprints which are not hints (for example lookups) are
+// assumed to be determined. The constraint systems with a GKR sub-circuit are
+// not supported.
+func FindUnderconstrainedWires(cs ConstraintSystem, witness witness.Witness, opts ...solver.Option) ([]UnderconstrainedWire, error) {
+	c, ok := cs.(interface{ core() *System })
+	if !ok {
+		return nil, fmt.Errorf("unsupported constraint system type %T", cs)
+	}
+	system := c.core()
+	if system.GkrInfo.Is() {
+		return nil, errors.New("constraint systems with GKR sub-circuit are not supported")
+	}
+	a := newUnderconstrainedAnalysis(cs, system)
+	candidates := a.propagate()
+
+	honest, err := a.solve(witness, opts, -1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("solve: %w", err)
+	}
+
+	var res []UnderconstrainedWire
+	reported := make(map[int]bool)
+	rng := rand.New(rand.NewSource(0)) //#nosec G404 -- reproducible analysis
+	for _, iID := range candidates {
+		for _, mutation := range HintMutations() {
+			mutate := func(outputs []*big.Int, start uint32) {
+				for i := range outputs {
+					if !a.determined[start+uint32(i)] {
+						outputs[i].Set(mutation.Mutate(rng, a.q, outputs[i]))
+					}
+				}
+			}
+			values, err := a.solve(witness, opts, iID, mutate)
+			if err != nil {
+				// the constraints caught the mutation
+				continue
+			}
+			found := false
+			for w := int(a.offset); w < len(values); w++ {
+				if honest[w] == nil || values[w] == nil || honest[w].Cmp(values[w]) == 0 {
+					continue
+				}
+				found = true
+				if reported[w] {
+					continue
+				}
+				reported[w] = true
+				res = append(res, UnderconstrainedWire{
+					WireID:           w,
+					Hint:             system.MHintsDependencies[a.hints[iID].HintID],
+					Value:            honest[w],
+					AlternativeValue: values[w],
+				})
+			}
+			if found {
+				break
+			}
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].WireID < res[j].WireID })
+	a.attachStacks(res)
+	return res, nil
+}
+
+type underconstrainedAnalysis struct {
+	cs     ConstraintSystem
+	system *System
+	q      *big.Int
+	offset uint32
+
+	// decompressed hint instructions, by instruction index
+	hints map[int]*HintMapping
+	// instructions of the commitment hints
+	commitmentHints map[int]bool
+	// wires determined by the inputs
+	determined []bool
+}
+
+func newUnderconstrainedAnalysis(cs ConstraintSystem, system *System) *underconstrainedAnalysis {
+	a := &underconstrainedAnalysis{
+		cs:              cs,
+		system:          system,
+		q:               system.Field(),
+		offset:          system.internalWireOffset(),
+		hints:           make(map[int]*HintMapping),
+		commitmentHints: make(map[int]bool),
+		determined:      make([]bool, int(system.internalWireOffset())+system.NbInternalVariables),
+	}
+	for i, pi := range system.Instructions {
+		if b, ok := system.Blueprints[pi.BlueprintID].(BlueprintHint); ok {
+			var h HintMapping
+			b.DecompressHint(&h, pi.Unpack(system))
+			a.hints[i] = &h
+		}
+	}
+
+	// the commitment wires are the outputs of the commitment hints
+	var commitmentWires []uint32
+	switch commitments := system.CommitmentInfo.(type) {
+	case Groth16Commitments:
+		for i := range commitments {
+			commitmentWires = append(commitmentWires, uint32(commitments[i].CommitmentIndex))
+		}
+	case PlonkCommitments:
+		it := system.GetSparseR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			if c.Commitment == COMMITMENT {
+				commitmentWires = append(commitmentWires, c.XA)
+			}
+		}
+	}
+	for i, h := range a.hints {
+		for _, w := range commitmentWires {
+			if w >= h.OutputRange.Start && w < h.OutputRange.End {
+				a.commitmentHints[i] = true
+			}
+		}
+	}
+	return a
+}
+
+// propagate marks the wires which are algebraically determined by the inputs
+// and returns the indexes of the hint instructions which have undetermined
+// outputs.
+func (a *underconstrainedAnalysis) propagate() []int {
+	system := a.system
+	for w := uint32(0); w < a.offset; w++ {
+		a.determined[w] = true
+	}
+
+	// a constraint determines its last undetermined wire if the wire appears
+	// linearly in it.
+	type item struct {
+		wires    []uint32
+		solvable func(w uint32) bool
+		// for the hints whose outputs are determined by the inputs
+		outputs []uint32
+	}
+	var items []item
+	for i, pi := range system.Instructions {
+		inst := pi.Unpack(system)
+		switch b := system.Blueprints[pi.BlueprintID].(type) {
+		case BlueprintR1C:
+			var r R1C
+			b.DecompressR1C(&r, inst)
+			items = append(items, item{
+				wires: r1cWires(&r),
+				solvable: func(w uint32) bool {
+					return !(r.L.hasWire(w) && r.R.hasWire(w))
+				},
+			})
+		case BlueprintSparseR1C:
+			var c SparseR1C
+			b.DecompressSparseR1C(&c, inst)
+			items = append(items, item{
+				wires: sparseR1CWires(&c),
+				solvable: func(w uint32) bool {
+					return !(c.QM != CoeffIdZero && c.XA == w && c.XB == w)
+				},
+			})
+		case BlueprintHint:
+			if a.commitmentHints[i] {
+				var wires []uint32
+				for _, l := range a.hints[i].Inputs {
+					for _, t := range l {
+						if !t.IsConstant() {
+							wires = append(wires, t.VID)
+						}
+					}
+				}
+				items = append(items, item{wires: wires, outputs: hintOutputs(a.hints[i])})
+			}
+		default:
+			// outputs of other blueprints are assumed to be determined
+			for w := 0; w < b.NbOutputs(inst); w++ {
+				a.determined[pi.WireOffset+uint32(w)] = true
+			}
+		}
+	}
+
+	// nbUndetermined[i] is the number of undetermined wires of items[i]
+	occurrences := make([][]int, len(a.determined))
+	nbUndetermined := make([]int, len(items))
+	for i := range items {
+		seen := make(map[uint32]bool, len(items[i].wires))
+		for _, w := range items[i].wires {
+			if seen[w] {
+				continue
+			}
+			seen[w] = true
+			occurrences[w] = append(occurrences[w], i)
+			if !a.determined[w] {
+				nbUndetermined[i]++
+			}
+		}
+	}
+	var queue []uint32
+	setDetermined := func(w uint32) {
+		if !a.determined[w] {
+			a.determined[w] = true
+			queue = append(queue, w)
+		}
+	}
+	process := func(i int) {
+		it := &items[i]
+		if it.outputs != nil {
+			if nbUndetermined[i] == 0 {
+				for _, w := range it.outputs {
+					setDetermined(w)
+				}
+			}
+			return
+		}
+		if nbUndetermined[i] != 1 {
+			return
+		}
+		for _, w := range it.wires {
+			if !a.determined[w] {
+				if it.solvable(w) {
+					setDetermined(w)
+				}
+				return
+			}
+		}
+	}
+	for i := range items {
+		process(i)
+	}
+	for len(queue) > 0 {
+		w := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		for _, i := range occurrences[w] {
+			nbUndetermined[i]--
+			process(i)
+		}
+	}
+
+	var candidates []int
+	for i := range system.Instructions {
+		h, ok := a.hints[i]
+		if !ok || a.commitmentHints[i] {
+			continue
+		}
+		for _, w := range hintOutputs(h) {
+			if !a.determined[w] {
+				candidates = append(candidates, i)
+				break
+			}
+		}
+	}
+	return candidates
+}
+
+// solve solves the system with witness and returns the values of the wires.
+// If target is not negative, then the outputs of the hint instruction target
+// are modified with mutate.
+func (a *underconstrainedAnalysis) solve(witness witness.Witness, opts []solver.Option, target int, mutate func([]*big.Int, uint32)) ([]*big.Int, error) {
+	config, err := solver.NewConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*big.Int, len(a.determined))
+
+	// the solver calls the hints in the order of the levels when running on a
+	// single task, which gives the instruction of every hint call.
+	calls := make(map[solver.HintID][]int)
+	for _, level := range a.system.Levels {
+		for _, iID := range level {
+			if h, ok := a.hints[int(iID)]; ok {
+				calls[h.HintID] = append(calls[h.HintID], int(iID))
+			}
+		}
+	}
+	opts = append(opts, solver.WithNbTasks(1))
+	for id, instructions := range calls {
+		f, ok := config.HintFunctions[id]
+		if !ok {
+			return nil, fmt.Errorf("missing hint function %s", a.system.MHintsDependencies[id])
+		}
+		nbCalls := 0
+		instructions := instructions
+		opts = append(opts, solver.OverrideHint(id, func(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+			iID := instructions[nbCalls]
+			nbCalls++
+			if a.commitmentHints[iID] {
+				if err := DeterministicCommitmentHint(mod, inputs, outputs); err != nil {
+					return err
+				}
+			} else if err := f(mod, inputs, outputs); err != nil {
+				return err
+			}
+			start := a.hints[iID].OutputRange.Start
+			if iID == target {
+				mutate(outputs, start)
+			}
+			for i := range outputs {
+				values[start+uint32(i)] = new(big.Int).Mod(outputs[i], mod)
+			}
+			return nil
+		}))
+	}
+
+	solution, err := a.cs.Solve(witness, opts...)
+	if err != nil {
+		return nil, err
+	}
+	solved, err := SolutionValues(a.cs, solution)
+	if err != nil {
+		return nil, err
+	}
+	for w, v := range solved {
+		if v != nil {
+			values[w] = v
+		}
+	}
+	return values, nil
+}
+
+// DeterministicCommitmentHint replaces the commitment placeholder with a
+// deterministic function of the committed values, so that the commitments of
+// two solutions only differ if the committed values differ. It is used when
+// comparing solutions, as the actual commitments are only computed by the
+// provers.
+func DeterministicCommitmentHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(outputs) != 1 {
+		return errors.New("expecting one output")
+	}
+	h := sha256.New()
+	for _, in := range inputs {
+		h.Write(in.Bytes())
+		h.Write([]byte{0})
+	}
+	outputs[0].SetBytes(h.Sum(nil))
+	outputs[0].Mod(outputs[0], mod)
+	if outputs[0].Sign() == 0 {
+		outputs[0].SetUint64(1)
+	}
+	return nil
+}
+
+// SolutionValues returns the values of the wires in a solution returned by
+// cs.Solve, indexed by wire identifier. For SparseR1CS, only the public inputs
+// and the wires involved in the constraints have a value, the other values are
+// nil.
+func SolutionValues(cs ConstraintSystem, solution any) ([]*big.Int, error) {
+	c, ok := cs.(interface{ core() *System })
+	if !ok {
+		return nil, fmt.Errorf("unsupported constraint system type %T", cs)
+	}
+	system := c.core()
+	values := make([]*big.Int, int(system.internalWireOffset())+system.NbInternalVariables)
+
+	switch s := solution.(type) {
+	case interface{ WireValues() []*big.Int }:
+		copy(values, s.WireValues())
+	case interface {
+		TermValues() (l, r, o []*big.Int)
+	}:
+		// the first rows are the placeholders of the public inputs
+		l, r, o := s.TermValues()
+		copy(values, l[:len(system.Public)])
+		it := system.GetSparseR1CIterator()
+		row := len(system.Public)
+		for c := it.Next(); c != nil; c = it.Next() {
+			values[c.XA], values[c.XB], values[c.XC] = l[row], r[row], o[row]
+			row++
+		}
+	default:
+		return nil, fmt.Errorf("unsupported solution type %T", solution)
+	}
+	return values, nil
+}
+
+// attachStacks sets the stack trace of the first constraint with debug
+// information involving each wire. As the debug information is only attached
+// to the assertions, if no such constraint involves the wire, then the
+// constraints involving the wires computed from it are searched.
+func (a *underconstrainedAnalysis) attachStacks(wires []UnderconstrainedWire) {
+	system := a.system
+	if len(system.MDebug) == 0 || len(wires) == 0 {
+		return
+	}
+
+	// uses[w] are the constraints involving w, and solves[i] the wires first
+	// appearing in the constraint i (the ones it solves).
+	uses := make([][]int, len(a.determined))
+	solves := make([][]uint32, len(system.Instructions))
+	seen := make([]bool, len(a.determined))
+	for i, pi := range system.Instructions {
+		var constraintWires []uint32
+		switch b := system.Blueprints[pi.BlueprintID].(type) {
+		case BlueprintR1C:
+			var r R1C
+			b.DecompressR1C(&r, pi.Unpack(system))
+			constraintWires = r1cWires(&r)
+		case BlueprintSparseR1C:
+			var c SparseR1C
+			b.DecompressSparseR1C(&c, pi.Unpack(system))
+			constraintWires = sparseR1CWires(&c)
+		case BlueprintHint:
+			for _, w := range hintOutputs(a.hints[i]) {
+				seen[w] = true
+			}
+			continue
+		default:
+			continue
+		}
+		for _, w := range constraintWires {
+			if n := len(uses[w]); n == 0 || uses[w][n-1] != i {
+				uses[w] = append(uses[w], i)
+			}
+			if !seen[w] {
+				seen[w] = true
+				solves[i] = append(solves[i], w)
+			}
+		}
+	}
+
+	for k := range wires {
+		visited := map[uint32]bool{uint32(wires[k].WireID): true}
+		queue := []uint32{uint32(wires[k].WireID)}
+	search:
+		for len(queue) > 0 {
+			w := queue[0]
+			queue = queue[1:]
+			for _, i := range uses[w] {
+				if dID, ok := system.MDebug[int(system.Instructions[i].ConstraintOffset)]; ok {
+					wires[k].Stack = system.formatStack(system.DebugInfo[dID].Stack)
+					break search
+				}
+				for _, v := range solves[i] {
+					if !visited[v] {
+						visited[v] = true
+						queue = append(queue, v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// formatStack returns the functions and locations of a stack collected by the
+// symbol table.
+func (system *System) formatStack(stack []int) string {
+	var sbb strings.Builder
+	for _, lID := range stack {
+		location := system.SymbolTable.Locations[lID]
+		function := system.SymbolTable.Functions[location.FunctionID]
+		sbb.WriteString(function.Name)
+		sbb.WriteString("\n\t")
+		sbb.WriteString(function.Filename)
+		sbb.WriteByte(':')
+		sbb.WriteString(strconv.Itoa(int(location.Line)))
+		sbb.WriteByte('\n')
+	}
+	return sbb.String()
+}
+
+// r1cWires returns the wires of the terms of r with non-zero coefficients.
+func r1cWires(r *R1C) []uint32 {
+	wires := make([]uint32, 0, len(r.L)+len(r.R)+len(r.O))
+	for _, l := range []LinearExpression{r.L, r.R, r.O} {
+		for _, t := range l {
+			if t.CID != CoeffIdZero {
+				wires = append(wires, t.VID)
+			}
+		}
+	}
+	return wires
+}
+
+// sparseR1CWires returns the wires of c with non-zero coefficients.
+func sparseR1CWires(c *SparseR1C) []uint32 {
+	wires := make([]uint32, 0, 3)
+	if c.QL != CoeffIdZero || c.QM != CoeffIdZero {
+		wires = append(wires, c.XA)
+	}
+	if c.QR != CoeffIdZero || c.QM != CoeffIdZero {
+		wires = append(wires, c.XB)
+	}
+	if c.QO != CoeffIdZero {
+		wires = append(wires, c.XC)
+	}
+	return wires
+}
+
+func hintOutputs(h *HintMapping) []uint32 {
+	outputs := make([]uint32, 0, h.OutputRange.End-h.OutputRange.Start)
+	for w := h.OutputRange.Start; w < h.OutputRange.End; w++ {
+		outputs = append(outputs, w)
+	}
+	return outputs
+}
+
+func (l LinearExpression) hasWire(w uint32) bool {
+	for _, t := range l {
+		if t.VID == w && t.CID != CoeffIdZero {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package constraint_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+func squareRootHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if outputs[0].ModSqrt(inputs[0], mod) == nil {
+		outputs[0].SetUint64(0)
+	}
+	return nil
+}
+
+type soundCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *soundCircuit) Define(api frontend.API) error {
+	// the bits are only determined by the sum and the booleanity constraints
+	bits := api.ToBinary(c.X, 8)
+	api.AssertIsEqual(api.FromBinary(bits...), c.X)
+	api.AssertIsEqual(api.Mul(api.Inverse(c.X), c.Y), 1)
+	api.AssertIsEqual(api.IsZero(c.X), 0)
+	return nil
+}
+
+type underconstrainedCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *underconstrainedCircuit) Define(api frontend.API) error {
+	// the sign of the square root is not constrained
+	r, err := api.Compiler().NewHint(squareRootHint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(api.Mul(r[0], r[0]), c.X)
+	// the unconstrained root is propagated
+	api.AssertIsEqual(api.Mul(api.Add(r[0], c.Y), 0), 0)
+	api.Mul(r[0], c.Y)
+	api.AssertIsEqual(c.Y, c.Y)
+	return nil
+}
+
+func TestFindUnderconstrainedWires(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	for _, builder := range []struct {
+		name string
+		new  frontend.NewBuilder
+	}{{"r1cs", r1cs.NewBuilder}, {"scs", scs.NewBuilder}} {
+		t.Run(builder.name, func(t *testing.T) {
+			ccs, err := frontend.Compile(field, builder.new, &soundCircuit{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err := frontend.NewWitness(&soundCircuit{X: 42, Y: 42}, field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wires, err := constraint.FindUnderconstrainedWires(ccs, w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(wires) != 0 {
+				t.Fatalf("unexpected underconstrained wires: %v", wires)
+			}
+
+			ccs, err = frontend.Compile(field, builder.new, &underconstrainedCircuit{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err = frontend.NewWitness(&underconstrainedCircuit{X: 16, Y: 3}, field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wires, err = constraint.FindUnderconstrainedWires(ccs, w, solver.WithHints(squareRootHint))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the root and the wires computed from it
+			if len(wires) < 2 {
+				t.Fatalf("expected underconstrained wires, got %v", wires)
+			}
+			root := new(big.Int).Neg(wires[0].Value)
+			root.Mod(root, field)
+			if wires[0].AlternativeValue.Cmp(root) != 0 {
+				t.Fatalf("expected the other square root, got %s", wires[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package frontend
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+
+	"github.com/consensys/gnark"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/debug"
+)
+
+// ConstraintSystemReader is implemented by the builders supporting the
+// compilation cache, see [WithCache].
+type ConstraintSystemReader interface {
+	// ReadConstraintSystem reads a serialized constraint system of the type
+	// built by the builder.
+	ReadConstraintSystem(r io.Reader) (constraint.ConstraintSystem, error)
+}
+
+// maxCacheKeyDepth bounds the nesting of the circuit structure when computing
+// the cache key, in case of cyclic pointers.
+const maxCacheKeyDepth = 64
+
+// compileCachePath returns the path of the cached constraint system compiled
+// from circuit with the given field, builder and options.
+func compileCachePath(field *big.Int, newBuilder NewBuilder, circuit Circuit, opt CompileConfig) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "gnark %s\n", gnark.Version)
+	fmt.Fprintf(h, "version %q\n", opt.CacheVersion)
+	fmt.Fprintf(h, "field %s\n", field)
+	fmt.Fprintf(h, "builder %s\n", runtime.FuncForPC(reflect.ValueOf(newBuilder).Pointer()).Name())
+	fmt.Fprintf(h, "debug %t\n", debug.Debug)
+	// the capacity and the scope statistics don't change the constraint system
+	fmt.Fprintf(h, "options %t %d %t %v %d\n", opt.IgnoreUnconstrainedInputs, opt.CompressThreshold, opt.Deduplicate, opt.Optimizations, opt.LazyLinearExpressions)
+	v := reflect.ValueOf(circuit)
+	fmt.Fprintf(h, "circuit %s.%s\n", v.Type().Elem().PkgPath(), v.Type())
+	if err := writeCacheKey(h, v, 0); err != nil {
+		return "", err
+	}
+	return filepath.Join(opt.CacheDir, hex.EncodeToString(h.Sum(nil))+".ccs"), nil
+}
+
+// writeCacheKey writes the structure of v to h: the names and tags of the
+// fields (which define the schema), and the values of the fields which are not
+// variables (which usually parametrize the circuit). The values of the
+// variables are ignored, so that an assigned circuit has the same key.
+func writeCacheKey(h hash.Hash, v reflect.Value, depth int) error {
+	if depth > maxCacheKeyDepth {
+		return errors.New("circuit structure is too deep")
+	}
+	if v.Kind() == reflect.Interface && v.Type() == tVariable {
+		h.Write([]byte{'v'})
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			h.Write([]byte{'0'})
+			return nil
+		}
+		fmt.Fprintf(h, "*%s", v.Elem().Type())
+		return writeCacheKey(h, v.Elem(), depth+1)
+	case reflect.Struct:
+		t := v.Type()
+		fmt.Fprintf(h, "{%d", t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fmt.Fprintf(h, "%q %q:", f.Name, f.Tag)
+			if err := writeCacheKey(h, v.Field(i), depth+1); err != nil {
+				return err
+			}
+		}
+		h.Write([]byte{'}'})
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(h, "[%d", v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if err := writeCacheKey(h, v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+		h.Write([]byte{']'})
+	case reflect.Map:
+		// the keys are sorted by their representation for determinism
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		fmt.Fprintf(h, "map[%d", len(keys))
+		for _, k := range keys {
+			if err := writeCacheKey(h, k, depth+1); err != nil {
+				return err
+			}
+			if err := writeCacheKey(h, v.MapIndex(k), depth+1); err != nil {
+				return err
+			}
+		}
+		h.Write([]byte{']'})
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		fmt.Fprintf(h, "%q", fmt.Sprint(v))
+	default:
+		return fmt.Errorf("circuit field of type %s can't be part of the cache key", v.Type())
+	}
+	return nil
+}
+
+// readCompileCache returns the cached constraint system at path, or nil if it
+// is not cached or can't be read.
+func readCompileCache(builder Builder, path string) (constraint.ConstraintSystem, error) {
+	reader, ok := builder.(ConstraintSystemReader)
+	if !ok {
+		return nil, fmt.Errorf("builder %T doesn't support the compile cache", builder)
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return reader.ReadConstraintSystem(bufio.NewReader(f))
+}
+
+// writeCompileCache writes ccs at path. The file is renamed once written, so
+// that concurrent compilations never read a partial constraint system.
+func writeCompileCache(path string, ccs constraint.ConstraintSystem) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	w := bufio.NewWriter(f)
+	if _, err = ccs.WriteTo(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -44,6 +44,12 @@ func Compile(field *big.Int, newBuilder NewBuilder, circuit Circuit, opts ...Com
 			return nil, fmt.Errorf("apply option: %w", err)
 		}
 	}
+	return compile(field, newBuilder, circuit, opt)
+}
+
+// compile compiles the circuit with the given configuration, see [Compile].
+func compile(field *big.Int, newBuilder NewBuilder, circuit Circuit, opt CompileConfig) (constraint.ConstraintSystem, error) {
+	log := logger.Logger()
 
 	// instantiate new builder
 	builder, err := newBuilder(field, opt)
@@ -52,6 +58,23 @@ func Compile(field *big.Int, newBuilder NewBuilder, circuit Circuit, opts ...Com
 		return nil, fmt.Errorf("new compiler: %w", err)
 	}
 
+	// look up the constraint system in the cache, unless the scope statistics
+	// must be computed by compiling the circuit
+	var cachePath string
+	if opt.CacheDir != "" {
+		if cachePath, err = compileCachePath(field, newBuilder, circuit, opt); err != nil {
+			log.Warn().Err(err).Msg("circuit can't be cached")
+		} else if opt.ScopeStats == nil {
+			ccs, err := readCompileCache(builder, cachePath)
+			if err != nil {
+				log.Warn().Err(err).Str("path", cachePath).Msg("reading compile cache")
+			} else if ccs != nil {
+				log.Info().Str("path", cachePath).Int("nbConstraints", ccs.GetNbConstraints()).Msg("loaded constraint system from compile cache")
+				return ccs, nil
+			}
+		}
+	}
+
 	// parse the circuit builds a schema of the circuit
 	// and call circuit.Define() method to initialize a list of constraints in the compiler
 	if err = parseCircuit(builder, circuit); err != nil {
@@ -61,7 +84,22 @@ func Compile(field *big.Int, newBuilder NewBuilder, circuit Circuit, opts ...Com
 	}
 
 	// compile the circuit into its final form
-	return builder.Compile()
+	ccs, err := builder.Compile()
+	if err != nil {
+		return nil, err
+	}
+	if len(opt.Optimizations) > 0 {
+		if err = constraint.Optimize(ccs, opt.Optimizations...); err != nil {
+			log.Err(err).Msg("optimizing constraint system")
+			return nil, fmt.Errorf("optimize: %w", err)
+		}
+	}
+	if cachePath != "" {
+		if err = writeCompileCache(cachePath, ccs); err != nil {
+			log.Warn().Err(err).Str("path", cachePath).Msg("writing compile cache")
+		}
+	}
+	return ccs, nil
 }
 
 func parseCircuit(builder Builder, circuit Circuit) (err error) {
@@ -154,6 +192,12 @@ type CompileConfig struct {
 	Capacity                  int
 	IgnoreUnconstrainedInputs bool
 	CompressThreshold         int
+	Deduplicate               bool
+	Optimizations             []constraint.OptimizationPass
+	ScopeStats                *ScopeStats
+	CacheDir                  string
+	CacheVersion              string
+	LazyLinearExpressions     int
 }
 
 // WithCapacity is a compile option that specifies the estimated capacity needed
@@ -203,8 +247,129 @@ func WithCompressThreshold(threshold int) CompileOption {
 	}
 }
 
+// WithDeduplication is a compile option which makes the builders reuse the
+// output wires of pure operations (multiplications, divisions, inversions,
+// zero tests and binary decompositions) which were already computed on
+// identical inputs instead of adding new constraints. The number of saved
+// constraints is logged at the end of the compilation.
+//
+// The operations are matched on the exact representation of their inputs, so
+// for example x*y and (x+0)*y are only deduplicated if both operands are
+// represented identically by the builder. Reusing the outputs only removes
+// degrees of freedom from the prover, and as such doesn't weaken the circuit.
+//
+// This option is useful for circuits composed of gadgets which independently
+// perform the same operations, for example decomposing the same variable
+// into bits.
+func WithDeduplication() CompileOption {
+	return func(opt *CompileConfig) error {
+		opt.Deduplicate = true
+		return nil
+	}
+}
+
+// WithOptimizations is a compile option which applies the given passes to the
+// compiled constraint system, see [constraint.Optimize]. For example:
+//
+//	frontend.WithOptimizations(constraint.DeadWireElimination, constraint.LinearSubstitution, constraint.ConstantFolding)
+//
+// The passes may reduce the number of constraints compared to the constraint
+// system built from the circuit definition as is, but increase the
+// compilation time. The internal wires are renumbered when some are removed,
+// so the wire identifiers differ from the ones in a constraint system
+// compiled without this option. The compilation fails if the constraint system
+// can't be optimized, for example if it contains lookups.
+func WithOptimizations(passes ...constraint.OptimizationPass) CompileOption {
+	return func(opt *CompileConfig) error {
+		opt.Optimizations = append(opt.Optimizations, passes...)
+		return nil
+	}
+}
+
 var tVariable reflect.Type
 
 func init() {
 	tVariable = reflect.ValueOf(struct{ A Variable }{}).FieldByName("A").Type()
 }
+
+// WithScopeStats is a compile option which fills stats with the number of
+// constraints, wires, hints and commitments of the whole circuit and of the
+// named scopes opened with [Scope], as a tree. The statistics are computed on
+// the constraint system built from the circuit definition, before the
+// optimizations (see [WithOptimizations]).
+//
+// The counts of a scope include its sub-scopes. Opening the same scope several
+// times in the same parent scope (for example in a loop) accumulates the
+// counts, and the deferred callbacks (see [Compiler.Defer]) are attributed to
+// the scope in which they were registered.
+func WithScopeStats(stats *ScopeStats) CompileOption {
+	return func(opt *CompileConfig) error {
+		if stats == nil {
+			return errors.New("scope stats must not be nil")
+		}
+		opt.ScopeStats = stats
+		return nil
+	}
+}
+
+// WithCache is a compile option which stores the compiled constraint system
+// in the directory dir, and returns it from there when compiling the same
+// circuit again instead of compiling it.
+//
+// The constraint systems are identified by the type of the circuit, its
+// schema, the values of its fields which are not variables (usually the
+// parameters of the circuit), the field, the builder, the compile options, the
+// gnark version and the user-supplied version string. As the key doesn't
+// depend on the code of the circuit and of the gadgets it uses, version must be
+// changed when it is modified. When loaded from the cache, the variables of
+// the circuit are not set by the builder.
+//
+// The cache is not used when the circuit contains fields which can't be part
+// of the key (for example functions), when the builder doesn't implement
+// [ConstraintSystemReader], nor when computing the scope statistics (see
+// [WithScopeStats]). Failing to read or write the cache only logs a warning.
+func WithCache(dir, version string) CompileOption {
+	return func(opt *CompileConfig) error {
+		if dir == "" {
+			return errors.New("cache directory must not be empty")
+		}
+		opt.CacheDir = dir
+		opt.CacheVersion = version
+		return nil
+	}
+}
+
+// WithLazyLinearExpressions is a compile option which makes the PLONK builder
+// keep the sums of up to maxTerms variables as linear expressions, instead of
+// adding a constraint for each addition. The linear expressions are only
+// materialized as wires when consumed by an operation which can't absorb them:
+//
+//   - the additions, subtractions and multiplications by a constant of linear
+//     expressions return linear expressions;
+//   - the product of two linear expressions of one term (plus a constant), for
+//     example (x+k)², is computed in a single constraint;
+//   - the equality of linear expressions with up to 3 terms in total is
+//     asserted in a single constraint.
+//
+// A linear expression is copied in at most one other linear expression, the
+// next ones use its materialized wire so that the constraints of a reused sum
+// are not duplicated. The sums which are not consumed don't add constraints.
+// This reduces the number of constraints of the gadgets with many additions,
+// such as MiMC or the emulated arithmetic. The R1CS builder already keeps the
+// linear expressions, so that the option has no effect on it.
+//
+// A larger maxTerms doesn't always give fewer constraints: a PLONK constraint
+// has only 3 wires, so the longer expressions are split when materialized and
+// the intermediate sums may not be shared anymore. For example, a circuit
+// combining MiMC, emulated multiplications, range checks and a log-derivative
+// lookup compiles to 5517, 5519 and 5521 constraints for maxTerms 2, 4 and 8.
+// maxTerms 2 is a good default.
+func WithLazyLinearExpressions(maxTerms int) CompileOption {
+	return func(opt *CompileConfig) error {
+		if maxTerms < 2 {
+			return errors.New("lazy linear expressions must have at least 2 terms")
+		}
+		opt.LazyLinearExpressions = maxTerms
+		return nil
+	}
+}
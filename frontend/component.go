@@ -0,0 +1,204 @@
+package frontend
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/consensys/gnark/frontend/schema"
+	"github.com/consensys/gnark/internal/kvstore"
+)
+
+// Component is a reusable part of a circuit with typed input and output ports.
+// The ports In and Out are structures (or arrays) of variables, like the
+// circuits. Different implementations of the same Component can be swapped in
+// a [Template].
+type Component[In, Out any] interface {
+	// Define defines the constraints of the component on its inputs and
+	// returns its outputs.
+	Define(api API, in In) (Out, error)
+}
+
+// Template is a named component which is compiled once per builder and
+// instantiated for each call, see [SubCircuit].
+//
+// The variables of the ports are the leaves of type [Variable] of In and Out,
+// found as in the circuits (the fields tagged with `gnark:"-"` are ignored);
+// the other fields are copied from the first call (for In) and from the
+// compiled component (for Out). As the component is compiled once, the ports
+// must have the same shape (length of the slices) for all the calls.
+//
+// Each instance is added in the scope named after the template, so that the
+// statistics of the components are reported when compiling with
+// [WithScopeStats].
+type Template[In, Out any] struct {
+	name      string
+	component Component[In, Out]
+
+	lock     sync.Mutex
+	nbInputs int
+	// the sub-circuit and the shapes of the ports hold variables, they must
+	// not be walked when parsing the schema of a circuit holding the template
+	subCircuit *SubCircuit `gnark:"-"`
+	in         In          `gnark:"-"`
+	out        Out         `gnark:"-"`
+}
+
+// templateInstances is the key of the number of instances of a template in
+// the key-value store of a builder.
+type templateInstances struct {
+	template any
+}
+
+// NewTemplate returns the template of the component named name.
+func NewTemplate[In, Out any](name string, component Component[In, Out]) *Template[In, Out] {
+	return &Template[In, Out]{name: name, component: component}
+}
+
+// Name returns the name of the template.
+func (t *Template[In, Out]) Name() string {
+	return t.name
+}
+
+// NbInstances returns the number of instances of the template in the circuit
+// defined in api.
+func (t *Template[In, Out]) NbInstances(api API) int {
+	kv, ok := api.Compiler().(kvstore.Store)
+	if !ok {
+		panic("builder should implement key-value store")
+	}
+	n, _ := kv.GetKeyValue(templateInstances{t}).(int)
+	return n
+}
+
+// Call instantiates the component in api on the inputs in and returns its
+// outputs.
+func (t *Template[In, Out]) Call(api API, in In) (Out, error) {
+	var out Out
+	defer Scope(api, t.name)()
+	kv, ok := api.Compiler().(kvstore.Store)
+	if !ok {
+		panic("builder should implement key-value store")
+	}
+	kv.SetKeyValue(templateInstances{t}, t.NbInstances(api)+1)
+
+	inputs, err := flattenPorts(&in)
+	if err != nil {
+		return out, fmt.Errorf("%s: %w", t.name, err)
+	}
+	subCircuit, err := t.getSubCircuit(in, len(inputs))
+	if err != nil {
+		return out, err
+	}
+	outputs, err := subCircuit.Call(api, inputs...)
+	if err != nil {
+		return out, fmt.Errorf("%s: %w", t.name, err)
+	}
+
+	t.lock.Lock()
+	shape := t.out
+	t.lock.Unlock()
+	if out, err = buildPorts(shape, outputs); err != nil {
+		return out, fmt.Errorf("%s: %w", t.name, err)
+	}
+	return out, nil
+}
+
+// getSubCircuit returns the sub-circuit of the component, creating it on the
+// first call with the shape of in.
+func (t *Template[In, Out]) getSubCircuit(in In, nbInputs int) (*SubCircuit, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.subCircuit != nil {
+		if nbInputs != t.nbInputs {
+			return nil, fmt.Errorf("%s: expected %d input variables, got %d", t.name, t.nbInputs, nbInputs)
+		}
+		return t.subCircuit, nil
+	}
+	t.in, t.nbInputs = in, nbInputs
+	t.subCircuit = NewSubCircuit(nbInputs, func(api API, inputs []Variable) ([]Variable, error) {
+		t.lock.Lock()
+		shape := t.in
+		t.lock.Unlock()
+		in, err := buildPorts(shape, inputs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := t.component.Define(api, in)
+		if err != nil {
+			return nil, err
+		}
+		t.lock.Lock()
+		t.out = out
+		t.lock.Unlock()
+		return flattenPorts(&out)
+	})
+	return t.subCircuit, nil
+}
+
+// flattenPorts returns the variables of the ports v, in the order of the
+// schema of v.
+func flattenPorts(v any) ([]Variable, error) {
+	var vars []Variable
+	_, err := schema.Walk(v, tVariable, func(_ schema.LeafInfo, tValue reflect.Value) error {
+		vars = append(vars, tValue.Interface())
+		return nil
+	})
+	return vars, err
+}
+
+// buildPorts returns a copy of shape whose variables are taken from vars, in
+// the order of the schema of shape.
+func buildPorts[T any](shape T, vars []Variable) (T, error) {
+	res := copyPorts(reflect.ValueOf(&shape).Elem()).Interface().(T)
+	nbVars := 0
+	_, err := schema.Walk(&res, tVariable, func(_ schema.LeafInfo, tValue reflect.Value) error {
+		if nbVars < len(vars) {
+			tValue.Set(reflect.ValueOf(&vars[nbVars]).Elem())
+		}
+		nbVars++
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	if nbVars != len(vars) {
+		return res, fmt.Errorf("expected %d variables, got %d", nbVars, len(vars))
+	}
+	return res, nil
+}
+
+// copyPorts returns a deep copy of the slices, arrays, pointers and structures
+// of v, so that setting the variables of the copy doesn't modify v.
+func copyPorts(v reflect.Value) reflect.Value {
+	res := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			res.Set(reflect.New(v.Type().Elem()))
+			res.Elem().Set(copyPorts(v.Elem()))
+		}
+	case reflect.Struct:
+		// the unexported fields are copied
+		res.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				res.Field(i).Set(copyPorts(v.Field(i)))
+			}
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			res.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				res.Index(i).Set(copyPorts(v.Index(i)))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(copyPorts(v.Index(i)))
+		}
+	default:
+		res.Set(v)
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package cs
+
+import (
+	"encoding/binary"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/internal/expr"
+)
+
+// Memo records the outputs of pure operations (multiplications, inversions,
+// binary decompositions...) so that repeating an operation on identical inputs
+// reuses the existing wires instead of adding new constraints. It is used by
+// the builders when compiling with [frontend.WithDeduplication].
+//
+// The operations are identified by their exact inputs (see [MemoKey]) and not
+// by a hash, so that a collision can not make two different operations share
+// the same output wires.
+type Memo struct {
+	entries map[string]memoEntry
+	nbSaved int
+}
+
+type memoEntry struct {
+	outputs       []frontend.Variable
+	nbConstraints int
+}
+
+// NewMemo returns an empty memo.
+func NewMemo() *Memo {
+	return &Memo{entries: make(map[string]memoEntry)}
+}
+
+// Get returns the outputs recorded for the operation identified by key. On
+// success, the number of constraints which were needed for computing the
+// outputs is added to the number of saved constraints.
+func (m *Memo) Get(key MemoKey) ([]frontend.Variable, bool) {
+	e, ok := m.entries[string(key)]
+	if !ok {
+		return nil, false
+	}
+	m.nbSaved += e.nbConstraints
+	return e.outputs, true
+}
+
+// Set records the outputs of the operation identified by key, which needed
+// nbConstraints constraints. The caller must not modify the outputs
+// afterwards.
+func (m *Memo) Set(key MemoKey, outputs []frontend.Variable, nbConstraints int) {
+	m.entries[string(key)] = memoEntry{outputs: outputs, nbConstraints: nbConstraints}
+}
+
+// NbSaved returns the number of constraints which were not added thanks to
+// the recorded operations.
+func (m *Memo) NbSaved() int {
+	return m.nbSaved
+}
+
+// MemoKey identifies an operation and its inputs in a [Memo].
+type MemoKey []byte
+
+// NewMemoKey returns a key for the operation op with the parameters params
+// (for example the number of bits of a decomposition). The inputs of the
+// operation are then appended with [MemoKey.AppendTerm] and
+// [MemoKey.AppendLinearExpression].
+func NewMemoKey(op string, params ...int) MemoKey {
+	k := make(MemoKey, 0, 64)
+	k = append(k, op...)
+	k = append(k, 0)
+	for _, p := range params {
+		k = binary.BigEndian.AppendUint64(k, uint64(p))
+	}
+	return k
+}
+
+// AppendTerm appends the wire and the coefficient of t to the key.
+func (k MemoKey) AppendTerm(t expr.Term) MemoKey {
+	k = binary.BigEndian.AppendUint32(k, uint32(t.VID))
+	for _, w := range t.Coeff {
+		k = binary.BigEndian.AppendUint64(k, w)
+	}
+	return k
+}
+
+// AppendLinearExpression appends all the terms of l to the key. The number of
+// terms is prepended so that the encoding of consecutive linear expressions is
+// unambiguous.
+func (k MemoKey) AppendLinearExpression(l expr.LinearExpression) MemoKey {
+	k = binary.BigEndian.AppendUint32(k, uint32(len(l)))
+	for _, t := range l {
+		k = k.AppendTerm(t)
+	}
+	return k
+}
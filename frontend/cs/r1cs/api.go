@@ -17,6 +17,7 @@ limitations under the License.
 package r1cs
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/consensys/gnark/internal/hints"
@@ -206,9 +207,20 @@ func (builder *builder) Mul(i1, i2 frontend.Variable, in ...frontend.Variable) f
 
 		// v1 and v2 are both unknown, this is the only case we add a constraint
 		if !v1Constant && !v2Constant {
-			res := builder.newInternalVariable()
-			builder.cs.AddR1C(builder.newR1C(v1, v2, res), builder.genericGate)
-			return res
+			res := builder.memoize(func() cs.MemoKey {
+				// the multiplication is commutative, order the operands
+				k1 := cs.MemoKey(nil).AppendLinearExpression(v1)
+				k2 := cs.MemoKey(nil).AppendLinearExpression(v2)
+				if bytes.Compare(k1, k2) > 0 {
+					k1, k2 = k2, k1
+				}
+				return append(append(cs.NewMemoKey("mul"), k1...), k2...)
+			}, func() []frontend.Variable {
+				res := builder.newInternalVariable()
+				builder.cs.AddR1C(builder.newR1C(v1, v2, res), builder.genericGate)
+				return []frontend.Variable{res}
+			})
+			return res[0].(expr.LinearExpression)
 		}
 
 		// v1 and v2 are constants, we multiply big.Int values and return resulting constant
@@ -258,14 +270,19 @@ func (builder *builder) DivUnchecked(i1, i2 frontend.Variable) frontend.Variable
 	n2, v2Constant := builder.constantValue(v2)
 
 	if !v2Constant {
-		res := builder.newInternalVariable()
-		// note that here we don't ensure that divisor is != 0
-		cID := builder.cs.AddR1C(builder.newR1C(v2, res, v1), builder.genericGate)
-		if debug.Debug {
-			debug := builder.newDebugInfo("div", v1, "/", v2, " == ", res)
-			builder.cs.AttachDebugInfo(debug, []int{cID})
-		}
-		return res
+		res := builder.memoize(func() cs.MemoKey {
+			return cs.NewMemoKey("divUnchecked").AppendLinearExpression(v1).AppendLinearExpression(v2)
+		}, func() []frontend.Variable {
+			res := builder.newInternalVariable()
+			// note that here we don't ensure that divisor is != 0
+			cID := builder.cs.AddR1C(builder.newR1C(v2, res, v1), builder.genericGate)
+			if debug.Debug {
+				debug := builder.newDebugInfo("div", v1, "/", v2, " == ", res)
+				builder.cs.AttachDebugInfo(debug, []int{cID})
+			}
+			return []frontend.Variable{res}
+		})
+		return res[0]
 	}
 
 	// v2 is constant
@@ -294,16 +311,21 @@ func (builder *builder) Div(i1, i2 frontend.Variable) frontend.Variable {
 	n2, v2Constant := builder.constantValue(v2)
 
 	if !v2Constant {
-		res := builder.newInternalVariable()
-		v2Inv := builder.newInternalVariable()
-		// note that here we ensure that v2 can't be 0, but it costs us one extra constraint
-		c1 := builder.cs.AddR1C(builder.newR1C(v2, v2Inv, builder.cstOne()), builder.genericGate)
-		c2 := builder.cs.AddR1C(builder.newR1C(v1, v2Inv, res), builder.genericGate)
-		if debug.Debug {
-			debug := builder.newDebugInfo("div", v1, "/", v2, " == ", res)
-			builder.cs.AttachDebugInfo(debug, []int{c1, c2})
-		}
-		return res
+		res := builder.memoize(func() cs.MemoKey {
+			return cs.NewMemoKey("div").AppendLinearExpression(v1).AppendLinearExpression(v2)
+		}, func() []frontend.Variable {
+			res := builder.newInternalVariable()
+			v2Inv := builder.newInternalVariable()
+			// note that here we ensure that v2 can't be 0, but it costs us one extra constraint
+			c1 := builder.cs.AddR1C(builder.newR1C(v2, v2Inv, builder.cstOne()), builder.genericGate)
+			c2 := builder.cs.AddR1C(builder.newR1C(v1, v2Inv, res), builder.genericGate)
+			if debug.Debug {
+				debug := builder.newDebugInfo("div", v1, "/", v2, " == ", res)
+				builder.cs.AttachDebugInfo(debug, []int{c1, c2})
+			}
+			return []frontend.Variable{res}
+		})
+		return res[0]
 	}
 
 	// v2 is constant
@@ -334,16 +356,21 @@ func (builder *builder) Inverse(i1 frontend.Variable) frontend.Variable {
 		return expr.NewLinearExpression(0, c)
 	}
 
-	// allocate resulting frontend.Variable
-	res := builder.newInternalVariable()
+	res := builder.memoize(func() cs.MemoKey {
+		return cs.NewMemoKey("inverse").AppendLinearExpression(vars[0])
+	}, func() []frontend.Variable {
+		// allocate resulting frontend.Variable
+		res := builder.newInternalVariable()
 
-	cID := builder.cs.AddR1C(builder.newR1C(res, vars[0], builder.cstOne()), builder.genericGate)
-	if debug.Debug {
-		debug := builder.newDebugInfo("inverse", vars[0], "*", res, " == 1")
-		builder.cs.AttachDebugInfo(debug, []int{cID})
-	}
+		cID := builder.cs.AddR1C(builder.newR1C(res, vars[0], builder.cstOne()), builder.genericGate)
+		if debug.Debug {
+			debug := builder.newDebugInfo("inverse", vars[0], "*", res, " == 1")
+			builder.cs.AttachDebugInfo(debug, []int{cID})
+		}
+		return []frontend.Variable{res}
+	})
 
-	return res
+	return res[0]
 }
 
 // ---------------------------------------------------------------------------------------------
@@ -364,7 +391,12 @@ func (builder *builder) ToBinary(i1 frontend.Variable, n ...int) []frontend.Vari
 		}
 	}
 
-	return bits.ToBinary(builder, i1, bits.WithNbDigits(nbBits))
+	return builder.memoize(func() cs.MemoKey {
+		vars, _ := builder.toVariables(i1)
+		return cs.NewMemoKey("toBinary", nbBits).AppendLinearExpression(vars[0])
+	}, func() []frontend.Variable {
+		return bits.ToBinary(builder, i1, bits.WithNbDigits(nbBits))
+	})
 }
 
 // FromBinary packs b, seen as a fr.Element in little endian
@@ -545,6 +577,15 @@ func (builder *builder) IsZero(i1 frontend.Variable) frontend.Variable {
 		return builder.cstZero()
 	}
 
+	res := builder.memoize(func() cs.MemoKey {
+		return cs.NewMemoKey("isZero").AppendLinearExpression(a)
+	}, func() []frontend.Variable {
+		return []frontend.Variable{builder.isZero(a)}
+	})
+	return res[0]
+}
+
+func (builder *builder) isZero(a expr.LinearExpression) frontend.Variable {
 	// x = 1/a 				// in a hint (x == 0 if a == 0)
 	// m = -a*x + 1         // constrain m to be 1 if a == 0
 	// a * m = 0            // constrain m to be 0 if a != 0
@@ -18,6 +18,7 @@ package r1cs
 
 import (
 	"errors"
+	"io"
 	"math/big"
 	"reflect"
 	"sort"
@@ -26,6 +27,7 @@ import (
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/debug"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs"
 	"github.com/consensys/gnark/frontend/internal/expr"
 	"github.com/consensys/gnark/frontend/schema"
 	"github.com/consensys/gnark/internal/circuitdefer"
@@ -72,6 +74,15 @@ type builder struct {
 	mbuf2 expr.LinearExpression
 
 	genericGate constraint.BlueprintID
+
+	// records the outputs of pure operations when compiling with
+	// frontend.WithDeduplication, nil otherwise. See memoize(...)
+	memo *cs.Memo
+
+	// records the statistics of the scopes when compiling with
+	// frontend.WithScopeStats, nil otherwise. See PushScope(...)
+	scopes  *cs.Scopes
+	nbHints int
 }
 
 // initialCapacity has quite some impact on frontend performance, especially on large circuits size
@@ -89,6 +100,12 @@ func newBuilder(field *big.Int, config frontend.CompileConfig) *builder {
 		mbuf2:      make(expr.LinearExpression, 0, macCapacity),
 		Store:      kvstore.New(),
 	}
+	if config.Deduplicate {
+		builder.memo = cs.NewMemo()
+	}
+	if config.ScopeStats != nil {
+		builder.scopes = cs.NewScopes(config.ScopeStats, builder.scopeTotals)
+	}
 
 	// by default the circuit is given a public wire equal to 1
 
@@ -276,6 +293,15 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 		Int("nbConstraints", builder.cs.GetNbConstraints()).
 		Msg("building constraint builder")
 
+	if builder.memo != nil {
+		log.Info().
+			Int("nbDeduplicated", builder.memo.NbSaved()).
+			Msg("deduplicated constraints of repeated operations")
+	}
+	if builder.scopes != nil {
+		builder.scopes.Finish()
+	}
+
 	// ensure all inputs and hints are constrained
 	if err := builder.cs.CheckUnconstrainedWires(); err != nil {
 		log.Warn().Msg("circuit has unconstrained inputs")
@@ -287,6 +313,40 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 	return builder.cs, nil
 }
 
+// memoize returns the outputs of the operation identified by key if they were
+// already computed. Otherwise it computes them with compute and records them.
+// If deduplication is disabled, it only calls compute.
+//
+// The key is computed lazily, before calling compute, as compute may modify
+// its inputs in place.
+func (builder *builder) memoize(key func() cs.MemoKey, compute func() []frontend.Variable) []frontend.Variable {
+	if builder.memo == nil {
+		return compute()
+	}
+	k := key()
+	if outputs, ok := builder.memo.Get(k); ok {
+		return cloneVariables(outputs)
+	}
+	nbConstraints := builder.cs.GetNbConstraints()
+	outputs := compute()
+	builder.memo.Set(k, cloneVariables(outputs), builder.cs.GetNbConstraints()-nbConstraints)
+	return outputs
+}
+
+// cloneVariables returns a copy of v so that the linear expressions can be
+// modified in place without affecting the recorded outputs.
+func cloneVariables(v []frontend.Variable) []frontend.Variable {
+	res := make([]frontend.Variable, len(v))
+	for i := range v {
+		if l, ok := v[i].(expr.LinearExpression); ok {
+			res[i] = l.Clone()
+		} else {
+			res[i] = v[i]
+		}
+	}
+	return res
+}
+
 // ConstantValue returns the big.Int value of v.
 // Will panic if v.IsConstant() == false
 func (builder *builder) ConstantValue(v frontend.Variable) (*big.Int, bool) {
@@ -400,6 +460,7 @@ func (builder *builder) newHint(f solver.Hint, id solver.HintID, nbOutputs int,
 	if err != nil {
 		return nil, err
 	}
+	builder.nbHints++
 
 	// make the variables
 	res := make([]frontend.Variable, len(internalVariables))
@@ -474,9 +535,93 @@ func (builder *builder) compress(le expr.LinearExpression) expr.LinearExpression
 }
 
 func (builder *builder) Defer(cb func(frontend.API) error) {
+	if builder.scopes != nil {
+		cb = builder.scopes.Wrap(cb)
+	}
 	circuitdefer.Put(builder, cb)
 }
 
+// ReadConstraintSystem reads a constraint system of the type built by the
+// builder, see [frontend.WithCache].
+func (builder *builder) ReadConstraintSystem(r io.Reader) (constraint.ConstraintSystem, error) {
+	ccs := reflect.New(reflect.TypeOf(builder.cs).Elem()).Interface().(constraint.ConstraintSystem)
+	if _, err := ccs.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return ccs, nil
+}
+
+// SubCircuitConfig returns the constructor and the configuration of the
+// builders compiling the sub-circuits, see [frontend.SubCircuit].
+func (builder *builder) SubCircuitConfig() (frontend.NewBuilder, frontend.CompileConfig) {
+	return NewBuilder, builder.config
+}
+
+// InstantiateSubCircuit adds the constraints of the compiled sub-circuit on the
+// given inputs and returns its outputs, see [frontend.SubCircuit].
+func (builder *builder) InstantiateSubCircuit(c *frontend.CompiledSubCircuit, inputs []frontend.Variable) ([]frontend.Variable, error) {
+	// the first public wire is the constant 1
+	wires := make([]int, 1, len(inputs)+1)
+	for _, in := range inputs {
+		wires = append(wires, builder.toWire(in))
+	}
+	linked, err := constraint.Link(builder.cs, c.System, wires)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]frontend.Variable, len(c.Outputs))
+	for i, o := range c.Outputs {
+		le, ok := o.(expr.LinearExpression)
+		if !ok {
+			// constant output
+			outputs[i] = o
+			continue
+		}
+		res := make(expr.LinearExpression, len(le))
+		for j, t := range le {
+			res[j] = expr.Term{VID: linked[t.VID], Coeff: t.Coeff}
+		}
+		sort.Sort(res)
+		outputs[i] = res
+	}
+	return outputs, nil
+}
+
+// toWire returns the wire equal to the variable v, adding an equality
+// constraint if v isn't a single wire.
+func (builder *builder) toWire(v frontend.Variable) int {
+	le := builder.toVariable(v)
+	if len(le) == 1 && le[0].VID != 0 && builder.isCstOne(le[0].Coeff) {
+		return le[0].VID
+	}
+	t := builder.newInternalVariable()
+	builder.cs.AddR1C(builder.newR1C(le, builder.cstOne(), t), builder.genericGate)
+	return t[0].VID
+}
+
+// PushScope opens the named scope, see [frontend.Scope].
+func (builder *builder) PushScope(name string) {
+	if builder.scopes != nil {
+		builder.scopes.Push(name)
+	}
+}
+
+// PopScope closes the scopes opened by the last call to PushScope.
+func (builder *builder) PopScope() {
+	if builder.scopes != nil {
+		builder.scopes.Pop()
+	}
+}
+
+func (builder *builder) scopeTotals() frontend.ScopeStats {
+	return frontend.ScopeStats{
+		NbConstraints: builder.cs.GetNbConstraints(),
+		NbWires:       builder.cs.GetNbInternalVariables(),
+		NbHints:       builder.nbHints,
+		NbCommitments: len(builder.cs.GetCommitments().CommitmentIndexes()),
+	}
+}
+
 func (*builder) FrontendType() frontendtype.Type {
 	return frontendtype.R1CS
 }
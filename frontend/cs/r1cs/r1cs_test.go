@@ -17,12 +17,14 @@ limitations under the License.
 package r1cs
 
 import (
+	"bytes"
 	"math/rand"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/internal/expr"
 )
@@ -180,3 +182,156 @@ func TestSubSameNoConstraint(t *testing.T) {
 		t.Fatal("expected 0 constraints")
 	}
 }
+
+type deduplicateCircuit struct {
+	A, B frontend.Variable
+}
+
+func (c *deduplicateCircuit) Define(api frontend.API) error {
+	for i := 0; i < 2; i++ {
+		api.AssertIsEqual(api.Mul(c.A, c.B), api.Mul(c.B, c.A))
+		api.AssertIsEqual(api.Mul(api.Inverse(c.B), api.Div(c.A, c.B)), api.DivUnchecked(c.A, api.Mul(c.B, c.B)))
+		api.AssertIsEqual(api.IsZero(c.A), 0)
+		api.AssertIsEqual(api.FromBinary(api.ToBinary(c.A, 16)...), c.A)
+	}
+	return nil
+}
+
+func TestDeduplicate(t *testing.T) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &deduplicateCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ccsDedup, err := frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &deduplicateCircuit{}, frontend.WithDeduplication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// in the second iteration 4 (mul) + 1 (inverse) + 2 (div) + 1 (div
+	// unchecked) + 2 (is zero) + 17 (binary decomposition) constraints are
+	// deduplicated. Additionally, A*B is reused for B*A in the first iteration.
+	if ccs.GetNbConstraints()-ccsDedup.GetNbConstraints() != 28 {
+		t.Fatalf("expected 28 deduplicated constraints, got %d", ccs.GetNbConstraints()-ccsDedup.GetNbConstraints())
+	}
+	w, err := frontend.NewWitness(&deduplicateCircuit{A: 12345, B: 678}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ccsDedup.Solve(w); err != nil {
+		t.Fatal(err)
+	}
+	w, err = frontend.NewWitness(&deduplicateCircuit{A: 1 << 16, B: 678}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ccsDedup.Solve(w); err == nil {
+		t.Fatal("expected solving to fail")
+	}
+}
+
+type scopeCircuit struct {
+	A, B frontend.Variable
+}
+
+func (c *scopeCircuit) Define(api frontend.API) error {
+	end := frontend.Scope(api, "a/b")
+	x := api.Mul(c.A, c.B)
+	end()
+	for i := 0; i < 2; i++ {
+		end = frontend.Scope(api, "a")
+		api.ToBinary(x, 8)
+		end()
+	}
+	end = frontend.Scope(api, "c")
+	api.Compiler().Defer(func(api frontend.API) error {
+		api.AssertIsEqual(api.Mul(x, x), c.A)
+		return nil
+	})
+	end()
+	api.AssertIsEqual(x, c.B)
+	return nil
+}
+
+func TestScopeStats(t *testing.T) {
+	var stats frontend.ScopeStats
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &scopeCircuit{}, frontend.WithScopeStats(&stats))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(stats.String())
+	if stats.NbConstraints != ccs.GetNbConstraints() || stats.NbWires != ccs.GetNbInternalVariables() {
+		t.Fatal("root scope doesn't match the constraint system")
+	}
+	ab, a, c := stats.Child("a/b"), stats.Child("a"), stats.Child("c")
+	if ab == nil || a == nil || c == nil || len(stats.Children) != 2 {
+		t.Fatalf("unexpected scope tree:\n%s", stats.String())
+	}
+	if ab.NbConstraints != 1 || ab.NbWires != 1 || ab.NbHints != 0 {
+		t.Fatalf("unexpected a/b stats:\n%s", stats.String())
+	}
+	// the two binary decompositions are accumulated in a, which includes a/b
+	if a.NbHints != 2 || a.NbWires != 17 || a.NbConstraints != 1+2*9 {
+		t.Fatalf("unexpected a stats:\n%s", stats.String())
+	}
+	// the deferred callback is attributed to the scope in which it was registered
+	if c.NbConstraints != 2 || c.NbWires != 1 {
+		t.Fatalf("unexpected c stats:\n%s", stats.String())
+	}
+}
+
+var nbCacheCircuitDefine int
+
+type cacheCircuit struct {
+	A, B   frontend.Variable `gnark:",public"`
+	nbBits int
+}
+
+func (c *cacheCircuit) Define(api frontend.API) error {
+	nbCacheCircuitDefine++
+	api.ToBinary(api.Mul(c.A, c.B), c.nbBits)
+	return nil
+}
+
+func TestCompileCache(t *testing.T) {
+	dir := t.TempDir()
+	compile := func(nbBits int, version string) constraint.ConstraintSystem {
+		t.Helper()
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), NewBuilder, &cacheCircuit{nbBits: nbBits}, frontend.WithCache(dir, version))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ccs
+	}
+	nbCacheCircuitDefine = 0
+	ccs := compile(8, "v1")
+	cached := compile(8, "v1")
+	if nbCacheCircuitDefine != 1 {
+		t.Fatalf("expected the second compilation to be cached, circuit defined %d times", nbCacheCircuitDefine)
+	}
+	f1, err := constraint.Fingerprint(ccs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f2, err := constraint.Fingerprint(cached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(f1, f2) {
+		t.Fatal("cached constraint system differs")
+	}
+	w, err := frontend.NewWitness(&cacheCircuit{A: 3, B: 5}, ecc.BN254.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cached.Solve(w); err != nil {
+		t.Fatal(err)
+	}
+
+	// changing a parameter of the circuit or the version invalidates the cache
+	if compile(10, "v1").GetNbConstraints() == ccs.GetNbConstraints() {
+		t.Fatal("expected a different constraint system")
+	}
+	compile(8, "v2")
+	if nbCacheCircuitDefine != 3 {
+		t.Fatalf("expected 3 compilations, circuit defined %d times", nbCacheCircuitDefine)
+	}
+}
@@ -0,0 +1,117 @@
+package cs
+
+import (
+	"strings"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Scopes records the statistics of the named scopes opened with
+// [frontend.Scope]. It is used by the builders when compiling with
+// [frontend.WithScopeStats].
+//
+// The statistics are computed from the totals of the builder when opening and
+// closing a scope, so that a scope includes its sub-scopes.
+type Scopes struct {
+	root   *frontend.ScopeStats
+	totals func() frontend.ScopeStats
+
+	// one group of open scopes per call to Push
+	stack [][]openScope
+}
+
+type openScope struct {
+	stats  *frontend.ScopeStats
+	totals frontend.ScopeStats
+}
+
+// NewScopes returns an empty scope tree with root as the root scope. The
+// function totals returns the current counts of the builder.
+func NewScopes(root *frontend.ScopeStats, totals func() frontend.ScopeStats) *Scopes {
+	*root = frontend.ScopeStats{}
+	return &Scopes{root: root, totals: totals}
+}
+
+// Push opens the scopes in the path name inside the current scope.
+func (s *Scopes) Push(name string) {
+	parent := s.current()
+	var group []openScope
+	for _, n := range strings.Split(name, "/") {
+		var child *frontend.ScopeStats
+		for _, c := range parent.Children {
+			if c.Name == n {
+				child = c
+				break
+			}
+		}
+		if child == nil {
+			child = &frontend.ScopeStats{Name: n}
+			parent.Children = append(parent.Children, child)
+		}
+		group = append(group, openScope{stats: child})
+		parent = child
+	}
+	s.open(group)
+}
+
+// Pop closes the scopes opened by the last call to Push.
+func (s *Scopes) Pop() {
+	if len(s.stack) == 0 {
+		panic("no scope to close")
+	}
+	totals := s.totals()
+	group := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	for _, o := range group {
+		o.stats.NbConstraints += totals.NbConstraints - o.totals.NbConstraints
+		o.stats.NbWires += totals.NbWires - o.totals.NbWires
+		o.stats.NbHints += totals.NbHints - o.totals.NbHints
+		o.stats.NbCommitments += totals.NbCommitments - o.totals.NbCommitments
+	}
+}
+
+// Wrap returns a callback running cb in the current scope, for the callbacks
+// which are deferred to the end of the compilation.
+func (s *Scopes) Wrap(cb func(frontend.API) error) func(frontend.API) error {
+	var path []openScope
+	for _, group := range s.stack {
+		path = append(path, group...)
+	}
+	return func(api frontend.API) error {
+		saved := s.stack
+		s.stack = nil
+		group := make([]openScope, len(path))
+		copy(group, path)
+		s.open(group)
+		err := cb(api)
+		s.stack = s.stack[:1]
+		s.Pop()
+		s.stack = saved
+		return err
+	}
+}
+
+// Finish sets the statistics of the root scope to the totals of the builder.
+func (s *Scopes) Finish() {
+	totals := s.totals()
+	s.root.NbConstraints = totals.NbConstraints
+	s.root.NbWires = totals.NbWires
+	s.root.NbHints = totals.NbHints
+	s.root.NbCommitments = totals.NbCommitments
+}
+
+func (s *Scopes) current() *frontend.ScopeStats {
+	if len(s.stack) == 0 {
+		return s.root
+	}
+	group := s.stack[len(s.stack)-1]
+	return group[len(group)-1].stats
+}
+
+func (s *Scopes) open(group []openScope) {
+	totals := s.totals()
+	for i := range group {
+		group[i].totals = totals
+	}
+	s.stack = append(s.stack, group)
+}
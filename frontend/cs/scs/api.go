@@ -182,17 +182,22 @@ func (builder *builder) DivUnchecked(i1, i2 frontend.Variable) frontend.Variable
 		return builder.mulConstant(res.(expr.Term), c1)
 	}
 
-	// res * i2 == i1
-	res := builder.newInternalVariable()
-	builder.addPlonkConstraint(sparseR1C{
-		xa: res.VID,
-		xb: i2.(expr.Term).VID,
-		xc: i1.(expr.Term).VID,
-		qM: i2.(expr.Term).Coeff,
-		qO: builder.cs.Neg(i1.(expr.Term).Coeff),
+	res := builder.memoize(func() cs.MemoKey {
+		return cs.NewMemoKey("divUnchecked").AppendTerm(i1.(expr.Term)).AppendTerm(i2.(expr.Term))
+	}, func() []frontend.Variable {
+		// res * i2 == i1
+		res := builder.newInternalVariable()
+		builder.addPlonkConstraint(sparseR1C{
+			xa: res.VID,
+			xb: i2.(expr.Term).VID,
+			xc: i1.(expr.Term).VID,
+			qM: i2.(expr.Term).Coeff,
+			qO: builder.cs.Neg(i1.(expr.Term).Coeff),
+		})
+		return []frontend.Variable{res}
 	})
 
-	return res
+	return res[0]
 }
 
 // Div returns i1 / i2
@@ -213,24 +218,29 @@ func (builder *builder) Inverse(i1 frontend.Variable) frontend.Variable {
 		return builder.cs.ToBigInt(c)
 	}
 	t := i1.(expr.Term)
-	res := builder.newInternalVariable()
-
-	// res * i1 - 1 == 0
-	constraint := sparseR1C{
-		xa: res.VID,
-		xb: t.VID,
-		qM: t.Coeff,
-		qC: builder.tMinusOne,
-	}
+	res := builder.memoize(func() cs.MemoKey {
+		return cs.NewMemoKey("inverse").AppendTerm(t)
+	}, func() []frontend.Variable {
+		res := builder.newInternalVariable()
+
+		// res * i1 - 1 == 0
+		constraint := sparseR1C{
+			xa: res.VID,
+			xb: t.VID,
+			qM: t.Coeff,
+			qC: builder.tMinusOne,
+		}
 
-	if debug.Debug {
-		debug := builder.newDebugInfo("inverse", "1/", i1, " < ∞")
-		builder.addPlonkConstraint(constraint, debug)
-	} else {
-		builder.addPlonkConstraint(constraint)
-	}
+		if debug.Debug {
+			debug := builder.newDebugInfo("inverse", "1/", i1, " < ∞")
+			builder.addPlonkConstraint(constraint, debug)
+		} else {
+			builder.addPlonkConstraint(constraint)
+		}
+		return []frontend.Variable{res}
+	})
 
-	return res
+	return res[0]
 }
 
 // ---------------------------------------------------------------------------------------------
@@ -251,7 +261,14 @@ func (builder *builder) ToBinary(i1 frontend.Variable, n ...int) []frontend.Vari
 		}
 	}
 
-	return bits.ToBinary(builder, i1, bits.WithNbDigits(nbBits))
+	if _, ok := builder.constantValue(i1); ok {
+		return bits.ToBinary(builder, i1, bits.WithNbDigits(nbBits))
+	}
+	return builder.memoize(func() cs.MemoKey {
+		return cs.NewMemoKey("toBinary", nbBits).AppendTerm(i1.(expr.Term))
+	}, func() []frontend.Variable {
+		return bits.ToBinary(builder, i1, bits.WithNbDigits(nbBits))
+	})
 }
 
 // FromBinary packs b, seen as a fr.Element in little endian
@@ -482,10 +499,19 @@ func (builder *builder) IsZero(i1 frontend.Variable) frontend.Variable {
 		return 0
 	}
 
+	a := i1.(expr.Term)
+	res := builder.memoize(func() cs.MemoKey {
+		return cs.NewMemoKey("isZero").AppendTerm(a)
+	}, func() []frontend.Variable {
+		return []frontend.Variable{builder.isZero(a)}
+	})
+	return res[0]
+}
+
+func (builder *builder) isZero(a expr.Term) expr.Term {
 	// x = 1/a 				// in a hint (x == 0 if a == 0)
 	// m = -a*x + 1         // constrain m to be 1 if a == 0
 	// a * m = 0            // constrain m to be 0 if a != 0
-	a := i1.(expr.Term)
 	m := builder.newInternalVariable()
 
 	// x = 1/a 				// in a hint (x == 0 if a == 0)
@@ -555,10 +581,15 @@ func (builder *builder) Cmp(i1, i2 frontend.Variable) frontend.Variable {
 //
 // if one of the input is a variable, its value will be resolved when R1CS.Solve() method is called
 func (builder *builder) Println(a ...frontend.Variable) {
+	builder.println(2, a...)
+}
+
+// println adds the log of a, prefixed with the caller skip frames above.
+func (builder *builder) println(skip int, a ...frontend.Variable) {
 	var log constraint.LogEntry
 
 	// prefix log line with file.go:line
-	if _, file, line, ok := runtime.Caller(1); ok {
+	if _, file, line, ok := runtime.Caller(skip); ok {
 		log.Caller = fmt.Sprintf("%s:%d", filepath.Base(file), line)
 	}
 
@@ -568,12 +599,12 @@ func (builder *builder) Println(a ...frontend.Variable) {
 		if i > 0 {
 			sbb.WriteByte(' ')
 		}
-		if v, ok := arg.(expr.Term); ok {
+		if v, ok := builder.toResolve(arg); ok {
 
 			sbb.WriteString("%s")
 			// we set limits to the linear expression, so that the log printer
 			// can evaluate it before printing it
-			log.ToResolve = append(log.ToResolve, constraint.LinearExpression{builder.cs.MakeTerm(v.Coeff, v.VID)})
+			log.ToResolve = append(log.ToResolve, v)
 		} else {
 			builder.printArg(&log, &sbb, arg)
 		}
@@ -606,10 +637,10 @@ func (builder *builder) printArg(log *constraint.LogEntry, sbb *strings.Builder,
 			sbb.WriteString(", ")
 		}
 
-		v := tValue.Interface().(expr.Term)
+		v, _ := builder.toResolve(tValue.Interface())
 		// we set limits to the linear expression, so that the log printer
 		// can evaluate it before printing it
-		log.ToResolve = append(log.ToResolve, constraint.LinearExpression{builder.cs.MakeTerm(v.Coeff, v.VID)})
+		log.ToResolve = append(log.ToResolve, v)
 		return nil
 	}
 	// ignoring error, printer() doesn't return errors
@@ -617,6 +648,18 @@ func (builder *builder) printArg(log *constraint.LogEntry, sbb *strings.Builder,
 	sbb.WriteByte('}')
 }
 
+// toResolve returns the linear expression of the variable v to be resolved by
+// the log printer, if v isn't a constant.
+func (builder *builder) toResolve(v frontend.Variable) (constraint.LinearExpression, bool) {
+	switch t := v.(type) {
+	case expr.Term:
+		return constraint.LinearExpression{builder.cs.MakeTerm(t.Coeff, t.VID)}, true
+	case *lazySum:
+		return builder.linearExpression(t), true
+	}
+	return nil, false
+}
+
 func (builder *builder) Compiler() frontend.Compiler {
 	return builder
 }
@@ -1,11 +1,17 @@
 package scs_test
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
 	"github.com/consensys/gnark/test"
 	"github.com/stretchr/testify/require"
 )
@@ -290,3 +296,296 @@ func TestRegressionOr(t *testing.T) {
 		}
 	}
 }
+
+type deduplicateCircuit struct {
+	A, B frontend.Variable
+}
+
+func (c *deduplicateCircuit) Define(api frontend.API) error {
+	for i := 0; i < 2; i++ {
+		api.AssertIsEqual(api.Mul(api.Inverse(c.B), api.Div(c.A, c.B)), api.DivUnchecked(c.A, api.Mul(c.B, c.B)))
+		api.AssertIsEqual(api.IsZero(c.A), 0)
+		api.AssertIsEqual(api.FromBinary(api.ToBinary(c.A, 16)...), c.A)
+	}
+	return nil
+}
+
+func TestDeduplicate(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &deduplicateCircuit{})
+	assert.NoError(err)
+	ccsDedup, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &deduplicateCircuit{}, frontend.WithDeduplication())
+	assert.NoError(err)
+	// the operations of the second iteration are deduplicated, only the
+	// assertions remain.
+	assert.Equal(40, ccs.GetNbConstraints()-ccsDedup.GetNbConstraints())
+
+	w, err := frontend.NewWitness(&deduplicateCircuit{A: 12345, B: 678}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = ccsDedup.Solve(w)
+	assert.NoError(err)
+	w, err = frontend.NewWitness(&deduplicateCircuit{A: 1 << 16, B: 678}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = ccsDedup.Solve(w)
+	assert.Error(err)
+}
+
+type scopeLookupCircuit struct {
+	Entries [4]frontend.Variable
+	Queries [2]frontend.Variable
+}
+
+func (c *scopeLookupCircuit) Define(api frontend.API) error {
+	end := frontend.Scope(api, "lookup")
+	t := logderivlookup.New(api)
+	for i := range c.Entries {
+		t.Insert(c.Entries[i])
+	}
+	t.Lookup(c.Queries[:]...)
+	end()
+	defer frontend.Scope(api, "mul")()
+	api.Mul(c.Queries[0], c.Queries[1])
+	return nil
+}
+
+func TestScopeStats(t *testing.T) {
+	var stats frontend.ScopeStats
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &scopeLookupCircuit{}, frontend.WithScopeStats(&stats))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.NbConstraints != ccs.GetNbConstraints() || stats.NbCommitments != 1 {
+		t.Fatalf("unexpected root scope:\n%s", stats.String())
+	}
+	// the commitment of the table is deferred to the end of the compilation,
+	// but is attributed to the scope in which the table was created
+	lookup, mul := stats.Child("lookup"), stats.Child("mul")
+	if lookup == nil || mul == nil {
+		t.Fatalf("unexpected scope tree:\n%s", stats.String())
+	}
+	if lookup.NbCommitments != 1 || lookup.NbHints == 0 || lookup.NbConstraints+mul.NbConstraints != stats.NbConstraints {
+		t.Fatalf("unexpected lookup scope:\n%s", stats.String())
+	}
+	if mul.NbConstraints != 1 || mul.NbCommitments != 0 {
+		t.Fatalf("unexpected mul scope:\n%s", stats.String())
+	}
+}
+
+// cube returns x³+x+5 and the two lowest bits of x
+var cube = frontend.NewSubCircuit(1, func(api frontend.API, inputs []frontend.Variable) ([]frontend.Variable, error) {
+	x := inputs[0]
+	bits := api.ToBinary(x, 8)
+	return []frontend.Variable{api.Add(api.Mul(x, x, x), x, 5), bits[0], bits[1]}, nil
+})
+
+var addSub = frontend.NewSubCircuit(2, func(api frontend.API, inputs []frontend.Variable) ([]frontend.Variable, error) {
+	return []frontend.Variable{api.Sub(inputs[0], inputs[1]), 7}, nil
+})
+
+type subCircuitCircuit struct {
+	X [4]frontend.Variable
+	Y [4]frontend.Variable `gnark:",public"`
+}
+
+func (c *subCircuitCircuit) Define(api frontend.API) error {
+	if err := frontend.CompileSubCircuits(api, cube, addSub); err != nil {
+		return err
+	}
+	for i := range c.X {
+		outputs, err := cube.Call(api, c.X[i])
+		if err != nil {
+			return err
+		}
+		api.AssertIsEqual(outputs[0], c.Y[i])
+		bits := api.ToBinary(c.X[i], 8)
+		api.AssertIsEqual(outputs[1], bits[0])
+		api.AssertIsEqual(outputs[2], bits[1])
+	}
+	// constant and linear expression inputs
+	outputs, err := cube.Call(api, 2)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(outputs[0], 15)
+	outputs, err = addSub.Call(api, api.Add(c.X[0], c.X[1]), 3)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(outputs[0], api.Sub(api.Add(c.X[0], c.X[1]), 3))
+	api.AssertIsEqual(outputs[1], 7)
+	return nil
+}
+
+func TestSubCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	var circuit, assignment, wrong subCircuitCircuit
+	for i := range assignment.X {
+		x := i + 3
+		assignment.X[i], assignment.Y[i] = x, x*x*x+x+5
+		wrong.X[i], wrong.Y[i] = x, x*x*x+x+5
+	}
+	wrong.Y[2] = 0
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&wrong), test.WithCurves(ecc.BN254))
+}
+
+type pairPorts struct {
+	X, Y frontend.Variable
+}
+
+type mulAddIn struct {
+	P pairPorts
+	K frontend.Variable
+}
+
+// mulAdd returns (X+Y, X*Y*K)
+type mulAdd struct{}
+
+func (mulAdd) Define(api frontend.API, in mulAddIn) (pairPorts, error) {
+	return pairPorts{X: api.Add(in.P.X, in.P.Y), Y: api.Mul(in.P.X, in.P.Y, in.K)}, nil
+}
+
+// mulAddHint computes X*Y*K with a hint
+type mulAddHint struct{}
+
+func (mulAddHint) Define(api frontend.API, in mulAddIn) (pairPorts, error) {
+	res, err := api.Compiler().NewHint(mulHint, 1, in.P.X, in.P.Y, in.K)
+	if err != nil {
+		return pairPorts{}, err
+	}
+	api.AssertIsEqual(api.Mul(in.P.X, in.P.Y, in.K), res[0])
+	return pairPorts{X: api.Add(in.P.X, in.P.Y), Y: res[0]}, nil
+}
+
+func mulHint(q *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	outputs[0].Mul(inputs[0], inputs[1]).Mul(outputs[0], inputs[2]).Mod(outputs[0], q)
+	return nil
+}
+
+type componentCircuit struct {
+	P   [3]pairPorts
+	K   frontend.Variable
+	Sum pairPorts `gnark:",public"`
+
+	mulAdd      *frontend.Template[mulAddIn, pairPorts]
+	nbInstances int
+}
+
+func (c *componentCircuit) Define(api frontend.API) error {
+	sum := pairPorts{X: 0, Y: 0}
+	for i := range c.P {
+		out, err := c.mulAdd.Call(api, mulAddIn{P: c.P[i], K: c.K})
+		if err != nil {
+			return err
+		}
+		sum.X, sum.Y = api.Add(sum.X, out.X), api.Add(sum.Y, out.Y)
+	}
+	api.AssertIsEqual(sum.X, c.Sum.X)
+	api.AssertIsEqual(sum.Y, c.Sum.Y)
+	c.nbInstances = c.mulAdd.NbInstances(api)
+	return nil
+}
+
+func TestComponent(t *testing.T) {
+	assert := test.NewAssert(t)
+	solver.RegisterHint(mulHint)
+	assignment := componentCircuit{K: 2}
+	sum := pairPorts{X: 0, Y: 0}
+	for i := range assignment.P {
+		x, y := i+2, i+5
+		assignment.P[i] = pairPorts{X: x, Y: y}
+		sum.X, sum.Y = sum.X.(int)+x+y, sum.Y.(int)+2*x*y
+	}
+	assignment.Sum = sum
+	for _, component := range []frontend.Component[mulAddIn, pairPorts]{mulAdd{}, mulAddHint{}} {
+		circuit := componentCircuit{mulAdd: frontend.NewTemplate("mul_add", component)}
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
+
+		// the instances are reported in the scope of the component
+		var stats frontend.ScopeStats
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &circuit, frontend.WithScopeStats(&stats))
+		assert.NoError(err)
+		compiled := stats.Child("mul_add")
+		assert.NotNil(compiled)
+		assert.Less(compiled.NbConstraints, ccs.GetNbConstraints())
+		assert.Equal(0, compiled.NbConstraints%len(assignment.P))
+		// the instances are counted per compiled circuit
+		assert.Equal(len(assignment.P), circuit.nbInstances)
+	}
+}
+
+type lazyCircuit struct {
+	X [4]frontend.Variable
+	H frontend.Variable `gnark:",public"`
+}
+
+func (c *lazyCircuit) Define(api frontend.API) error {
+	// a = x0 + 2⋅x1 + 5 and b = (x2 + 1)(x3 - 3) are kept lazy up to the
+	// assertion
+	a := api.Add(c.X[0], api.Mul(c.X[1], 2), 5)
+	b := api.Mul(api.Add(c.X[2], 1), api.Sub(c.X[3], 3))
+	api.AssertIsEqual(a, b)
+
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.X[:]...)
+	api.AssertIsEqual(h.Sum(), c.H)
+	return nil
+}
+
+func TestLazyLinearExpressions(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	eager, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &lazyCircuit{})
+	assert.NoError(err)
+	lazy, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &lazyCircuit{}, frontend.WithLazyLinearExpressions(4))
+	assert.NoError(err)
+	assert.Less(lazy.GetNbConstraints(), eager.GetNbConstraints())
+
+	x := []int64{1, 2, 4, 5}
+	goMimc := hash.MIMC_BN254.New()
+	var assignment, wrong lazyCircuit
+	for i := range x {
+		var e fr.Element
+		e.SetInt64(x[i])
+		b := e.Bytes()
+		goMimc.Write(b[:])
+		assignment.X[i], wrong.X[i] = x[i], x[i]
+	}
+	assignment.H, wrong.H = goMimc.Sum(nil), goMimc.Sum(nil)
+	wrong.X[0] = 2
+	assert.CheckCircuit(&lazyCircuit{}, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&wrong),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.WithLazyLinearExpressions(4)))
+
+	_, err = frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &lazyCircuit{}, frontend.WithLazyLinearExpressions(1))
+	assert.Error(err)
+}
+
+var sumSubCircuit = frontend.NewSubCircuit(2, func(api frontend.API, inputs []frontend.Variable) ([]frontend.Variable, error) {
+	return []frontend.Variable{api.Add(inputs[0], inputs[1])}, nil
+})
+
+type lazySubCircuitCircuit struct {
+	A, B, P, Q frontend.Variable
+	S          frontend.Variable `gnark:",public"`
+}
+
+func (c *lazySubCircuitCircuit) Define(api frontend.API) error {
+	// the inputs of the sub-circuit are not the first wires of the circuit
+	outputs, err := sumSubCircuit.Call(api, c.P, c.Q)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(outputs[0], c.S)
+	api.AssertIsDifferent(c.A, c.B)
+	return nil
+}
+
+func TestLazySubCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&lazySubCircuitCircuit{},
+		test.WithValidAssignment(&lazySubCircuitCircuit{A: 1, B: 2, P: 3, Q: 4, S: 7}),
+		test.WithInvalidAssignment(&lazySubCircuitCircuit{A: 1, B: 2, P: 3, Q: 4, S: 3}),
+		test.WithCurves(ecc.BN254), test.WithCompileOpts(frontend.WithLazyLinearExpressions(4)))
+}
@@ -18,6 +18,7 @@ package scs
 
 import (
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"sort"
@@ -26,6 +27,7 @@ import (
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/debug"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs"
 	"github.com/consensys/gnark/frontend/internal/expr"
 	"github.com/consensys/gnark/frontend/schema"
 	"github.com/consensys/gnark/internal/circuitdefer"
@@ -46,7 +48,11 @@ import (
 )
 
 func NewBuilder(field *big.Int, config frontend.CompileConfig) (frontend.Builder, error) {
-	return newBuilder(field, config), nil
+	b := newBuilder(field, config)
+	if config.LazyLinearExpressions > 0 {
+		return &lazyBuilder{builder: b, maxTerms: config.LazyLinearExpressions}, nil
+	}
+	return b, nil
 }
 
 type builder struct {
@@ -71,6 +77,15 @@ type builder struct {
 	genericGate                constraint.BlueprintID
 	mulGate, addGate, boolGate constraint.BlueprintID
 
+	// records the outputs of pure operations when compiling with
+	// frontend.WithDeduplication, nil otherwise. See memoize(...)
+	memo *cs.Memo
+
+	// records the statistics of the scopes when compiling with
+	// frontend.WithScopeStats, nil otherwise. See PushScope(...)
+	scopes  *cs.Scopes
+	nbHints int
+
 	// used to avoid repeated allocations
 	bufL expr.LinearExpression
 	bufH []constraint.LinearExpression
@@ -87,6 +102,12 @@ func newBuilder(field *big.Int, config frontend.CompileConfig) *builder {
 		Store:            kvstore.New(),
 		bufL:             make(expr.LinearExpression, 20),
 	}
+	if config.Deduplicate {
+		b.memo = cs.NewMemo()
+	}
+	if config.ScopeStats != nil {
+		b.scopes = cs.NewScopes(config.ScopeStats, b.scopeTotals)
+	}
 	// init hint buffer.
 	_ = b.hintBuffer(256)
 
@@ -297,6 +318,15 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 		Int("nbConstraints", builder.cs.GetNbConstraints()).
 		Msg("building constraint builder")
 
+	if builder.memo != nil {
+		log.Info().
+			Int("nbDeduplicated", builder.memo.NbSaved()).
+			Msg("deduplicated constraints of repeated operations")
+	}
+	if builder.scopes != nil {
+		builder.scopes.Finish()
+	}
+
 	// ensure all inputs and hints are constrained
 	err := builder.cs.CheckUnconstrainedWires()
 	if err != nil {
@@ -309,6 +339,26 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 	return builder.cs, nil
 }
 
+// memoize returns the outputs of the operation identified by key if they were
+// already computed. Otherwise it computes them with compute and records them.
+// If deduplication is disabled, it only calls compute.
+//
+// Multiplications are not memoized here as they are already deduplicated by
+// mulConstraintExist(...).
+func (builder *builder) memoize(key func() cs.MemoKey, compute func() []frontend.Variable) []frontend.Variable {
+	if builder.memo == nil {
+		return compute()
+	}
+	k := key()
+	if outputs, ok := builder.memo.Get(k); ok {
+		return append([]frontend.Variable(nil), outputs...)
+	}
+	nbConstraints := builder.cs.GetNbConstraints()
+	outputs := compute()
+	builder.memo.Set(k, append([]frontend.Variable(nil), outputs...), builder.cs.GetNbConstraints()-nbConstraints)
+	return outputs
+}
+
 // ConstantValue returns the big.Int value of v.
 // Will panic if v.IsConstant() == false
 func (builder *builder) ConstantValue(v frontend.Variable) (*big.Int, bool) {
@@ -376,6 +426,7 @@ func (builder *builder) newHint(f solver.Hint, id solver.HintID, nbOutputs int,
 	if err != nil {
 		return nil, err
 	}
+	builder.nbHints++
 
 	// make the variables
 	res := make([]frontend.Variable, len(internalVariables))
@@ -667,9 +718,99 @@ func (builder *builder) newDebugInfo(errName string, in ...interface{}) constrai
 }
 
 func (builder *builder) Defer(cb func(frontend.API) error) {
+	if builder.scopes != nil {
+		cb = builder.scopes.Wrap(cb)
+	}
 	circuitdefer.Put(builder, cb)
 }
 
+// ReadConstraintSystem reads a constraint system of the type built by the
+// builder, see [frontend.WithCache].
+func (builder *builder) ReadConstraintSystem(r io.Reader) (constraint.ConstraintSystem, error) {
+	ccs := reflect.New(reflect.TypeOf(builder.cs).Elem()).Interface().(constraint.ConstraintSystem)
+	if _, err := ccs.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return ccs, nil
+}
+
+// SubCircuitConfig returns the constructor and the configuration of the
+// builders compiling the sub-circuits, see [frontend.SubCircuit].
+func (builder *builder) SubCircuitConfig() (frontend.NewBuilder, frontend.CompileConfig) {
+	return NewBuilder, builder.config
+}
+
+// InstantiateSubCircuit adds the constraints of the compiled sub-circuit on the
+// given inputs and returns its outputs, see [frontend.SubCircuit].
+func (builder *builder) InstantiateSubCircuit(c *frontend.CompiledSubCircuit, inputs []frontend.Variable) ([]frontend.Variable, error) {
+	wires := make([]int, len(inputs))
+	for i, in := range inputs {
+		wires[i] = builder.toWire(in)
+	}
+	linked, err := constraint.Link(builder.cs, c.System, wires)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]frontend.Variable, len(c.Outputs))
+	for i, o := range c.Outputs {
+		switch t := o.(type) {
+		case expr.Term:
+			outputs[i] = expr.NewTerm(linked[t.VID], t.Coeff)
+		case *lazySum:
+			// the sub-circuit outputs are materialized when compiled
+			return nil, fmt.Errorf("output %d of the sub-circuit is not a wire", i)
+		default:
+			// constant output
+			outputs[i] = o
+		}
+	}
+	return outputs, nil
+}
+
+// toWire returns the wire equal to the variable v, adding an equality
+// constraint if v isn't a single wire.
+func (builder *builder) toWire(v frontend.Variable) int {
+	t, ok := v.(expr.Term)
+	if ok && t.Coeff == builder.tOne {
+		return t.VID
+	}
+	res := builder.newInternalVariable()
+	c := constraint.SparseR1C{
+		XC: uint32(res.VID),
+		QO: constraint.CoeffIdMinusOne,
+	}
+	if ok {
+		c.XA, c.QL = uint32(t.VID), builder.cs.AddCoeff(t.Coeff)
+	} else {
+		c.QC = builder.cs.AddCoeff(builder.cs.FromInterface(v))
+	}
+	builder.cs.AddSparseR1C(c, builder.genericGate)
+	return res.VID
+}
+
+// PushScope opens the named scope, see [frontend.Scope].
+func (builder *builder) PushScope(name string) {
+	if builder.scopes != nil {
+		builder.scopes.Push(name)
+	}
+}
+
+// PopScope closes the scopes opened by the last call to PushScope.
+func (builder *builder) PopScope() {
+	if builder.scopes != nil {
+		builder.scopes.Pop()
+	}
+}
+
+func (builder *builder) scopeTotals() frontend.ScopeStats {
+	return frontend.ScopeStats{
+		NbConstraints: builder.cs.GetNbConstraints(),
+		NbWires:       builder.cs.GetNbInternalVariables(),
+		NbHints:       builder.nbHints,
+		NbCommitments: len(builder.cs.GetCommitments().CommitmentIndexes()),
+	}
+}
+
 // AddInstruction is used to add custom instructions to the constraint system.
 func (builder *builder) AddInstruction(bID constraint.BlueprintID, calldata []uint32) []uint32 {
 	return builder.cs.AddInstruction(bID, calldata)
@@ -0,0 +1,425 @@
+package scs
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/internal/expr"
+)
+
+// lazyBuilder wraps the builder to keep the sums of variables as linear
+// expressions of up to maxTerms terms, which are only materialized as wires
+// when consumed by an operation which can't absorb them. See
+// [frontend.WithLazyLinearExpressions].
+//
+// The operations which don't handle the linear expressions materialize their
+// inputs and are delegated to the builder, which never sees a lazySum.
+type lazyBuilder struct {
+	*builder
+	maxTerms int
+}
+
+// lazySum is the variable ∑qᵢ⋅xᵢ + k which is not materialized yet. It has at
+// least two terms, or one term and a non-zero constant.
+type lazySum struct {
+	terms expr.LinearExpression
+	k     constraint.Element
+
+	// wire is the materialized sum, once consumed by an eager operation
+	wire *expr.Term
+
+	// inlined is set once the terms are copied in another sum. The next sums
+	// use the materialized sum instead, so that the constraints of a reused
+	// sum are not duplicated.
+	inlined bool
+}
+
+// sum returns the terms and the constant of the sum of the variables in, with
+// the terms of the same wire merged.
+func (b *lazyBuilder) sum(in ...frontend.Variable) (expr.LinearExpression, constraint.Element) {
+	var (
+		terms expr.LinearExpression
+		k     constraint.Element
+	)
+	for _, v := range in {
+		if s, ok := v.(*lazySum); ok {
+			if !s.inlined && s.wire == nil {
+				s.inlined = true
+				terms = append(terms, s.terms...)
+				k = b.cs.Add(k, s.k)
+				continue
+			}
+			v = b.materialize(s)
+		}
+		t, c := b.builder.filterConstantSum([]frontend.Variable{v})
+		terms = append(terms, t...)
+		k = b.cs.Add(k, c)
+	}
+	terms = b.builder.reduce(terms)
+	res := terms[:0]
+	for _, t := range terms {
+		if !t.Coeff.IsZero() {
+			res = append(res, t)
+		}
+	}
+	return res, k
+}
+
+// newSum returns the variable ∑terms + k, which is lazy if it has at most
+// maxTerms terms.
+func (b *lazyBuilder) newSum(terms expr.LinearExpression, k constraint.Element) frontend.Variable {
+	switch {
+	case len(terms) == 0:
+		return b.cs.ToBigInt(k)
+	case len(terms) == 1 && k.IsZero():
+		return terms[0]
+	case len(terms) <= b.maxTerms:
+		return &lazySum{terms: terms, k: k}
+	}
+	return b.materializeSum(terms, k)
+}
+
+func (b *lazyBuilder) materializeSum(terms expr.LinearExpression, k constraint.Element) expr.Term {
+	if k.IsZero() {
+		return b.builder.splitSum(terms[0], terms[1:], nil)
+	}
+	return b.builder.splitSum(terms[0], terms[1:], &k)
+}
+
+// materialize returns v, as a wire if it is a linear expression.
+func (b *lazyBuilder) materialize(v frontend.Variable) frontend.Variable {
+	if s, ok := v.(*lazySum); ok {
+		if s.wire == nil {
+			t := b.materializeSum(s.terms, s.k)
+			s.wire = &t
+		}
+		return *s.wire
+	}
+	return v
+}
+
+// Materialize implements [frontend.VariableMaterializer].
+func (b *lazyBuilder) Materialize(v frontend.Variable) frontend.Variable {
+	return b.materialize(v)
+}
+
+func (b *lazyBuilder) materializeAll(in []frontend.Variable) []frontend.Variable {
+	res := make([]frontend.Variable, len(in))
+	for i := range in {
+		res[i] = b.materialize(in[i])
+	}
+	return res
+}
+
+func isLazy(in ...frontend.Variable) bool {
+	for _, v := range in {
+		if _, ok := v.(*lazySum); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// affine returns v as c⋅x + k if it has one term.
+func (b *lazyBuilder) affine(v frontend.Variable) (expr.Term, constraint.Element, bool) {
+	switch t := v.(type) {
+	case expr.Term:
+		return t, constraint.Element{}, true
+	case *lazySum:
+		if len(t.terms) == 1 {
+			return t.terms[0], t.k, true
+		}
+	}
+	return expr.Term{}, constraint.Element{}, false
+}
+
+// linearExpression returns the lazy sum as a constraint linear expression, for
+// the logs and the debug information.
+func (builder *builder) linearExpression(s *lazySum) constraint.LinearExpression {
+	res := make(constraint.LinearExpression, 0, len(s.terms)+1)
+	for _, t := range s.terms {
+		res = append(res, builder.cs.MakeTerm(t.Coeff, t.VID))
+	}
+	if !s.k.IsZero() {
+		t := builder.cs.MakeTerm(s.k, 0)
+		t.MarkConstant()
+		res = append(res, t)
+	}
+	return res
+}
+
+// constantValue returns the value of v if it is a constant.
+func (b *lazyBuilder) constantValue(v frontend.Variable) (constraint.Element, bool) {
+	if isLazy(v) {
+		return constraint.Element{}, false
+	}
+	return b.builder.constantValue(v)
+}
+
+func (b *lazyBuilder) debugArg(v frontend.Variable) any {
+	if s, ok := v.(*lazySum); ok {
+		return b.linearExpression(s)
+	}
+	return v
+}
+
+func (b *lazyBuilder) Add(i1, i2 frontend.Variable, in ...frontend.Variable) frontend.Variable {
+	return b.newSum(b.sum(append([]frontend.Variable{i1, i2}, in...)...))
+}
+
+func (b *lazyBuilder) Sub(i1, i2 frontend.Variable, in ...frontend.Variable) frontend.Variable {
+	return b.newSum(b.diff(i1, append([]frontend.Variable{i2}, in...)...))
+}
+
+// diff returns the terms and the constant of i1 minus the sum of in.
+func (b *lazyBuilder) diff(i1 frontend.Variable, in ...frontend.Variable) (expr.LinearExpression, constraint.Element) {
+	terms, k := b.sum(i1)
+	sub, kSub := b.sum(in...)
+	for _, t := range sub {
+		terms = append(terms, expr.NewTerm(t.VID, b.cs.Neg(t.Coeff)))
+	}
+	terms = b.builder.reduce(terms)
+	res := terms[:0]
+	for _, t := range terms {
+		if !t.Coeff.IsZero() {
+			res = append(res, t)
+		}
+	}
+	return res, b.cs.Sub(k, kSub)
+}
+
+func (b *lazyBuilder) Neg(i1 frontend.Variable) frontend.Variable {
+	s, ok := i1.(*lazySum)
+	if !ok {
+		return b.builder.Neg(i1)
+	}
+	return b.scale(s, b.cs.Neg(b.tOne))
+}
+
+// scale returns c⋅s.
+func (b *lazyBuilder) scale(s *lazySum, c constraint.Element) frontend.Variable {
+	if c.IsZero() {
+		return 0
+	}
+	if s.inlined || s.wire != nil {
+		return b.builder.Mul(b.materialize(s), b.cs.ToBigInt(c))
+	}
+	s.inlined = true
+	terms := make(expr.LinearExpression, len(s.terms))
+	for i, t := range s.terms {
+		terms[i] = expr.NewTerm(t.VID, b.cs.Mul(t.Coeff, c))
+	}
+	return &lazySum{terms: terms, k: b.cs.Mul(s.k, c)}
+}
+
+func (b *lazyBuilder) Mul(i1, i2 frontend.Variable, in ...frontend.Variable) frontend.Variable {
+	if !isLazy(i1, i2) && !isLazy(in...) {
+		return b.builder.Mul(i1, i2, in...)
+	}
+	res := b.mul(i1, i2)
+	for _, v := range in {
+		res = b.mul(res, v)
+	}
+	return res
+}
+
+// mul returns i1⋅i2, in a single constraint when both are of the form c⋅x+k.
+func (b *lazyBuilder) mul(i1, i2 frontend.Variable) frontend.Variable {
+	if c, ok := b.constantValue(i1); ok {
+		i1, i2 = i2, i1
+		if s, ok := i1.(*lazySum); ok {
+			return b.scale(s, c)
+		}
+	} else if c, ok := b.constantValue(i2); ok {
+		if s, ok := i1.(*lazySum); ok {
+			return b.scale(s, c)
+		}
+	}
+	if !isLazy(i1, i2) {
+		return b.builder.Mul(i1, i2)
+	}
+	x, kx, okX := b.affine(i1)
+	y, ky, okY := b.affine(i2)
+	if !okX || !okY {
+		return b.builder.Mul(b.materialize(i1), b.materialize(i2))
+	}
+	// (cx⋅x + kx)(cy⋅y + ky) = cx⋅cy⋅x⋅y + cx⋅ky⋅x + cy⋅kx⋅y + kx⋅ky
+	res := b.newInternalVariable()
+	b.addPlonkConstraint(sparseR1C{
+		xa: x.VID,
+		xb: y.VID,
+		xc: res.VID,
+		qL: b.cs.Mul(x.Coeff, ky),
+		qR: b.cs.Mul(y.Coeff, kx),
+		qO: b.cs.Neg(b.tOne),
+		qM: b.cs.Mul(x.Coeff, y.Coeff),
+		qC: b.cs.Mul(kx, ky),
+	})
+	return res
+}
+
+func (b *lazyBuilder) MulAcc(a, c, d frontend.Variable) frontend.Variable {
+	if !isLazy(a, c, d) {
+		return b.builder.MulAcc(a, c, d)
+	}
+	return b.Add(a, b.Mul(c, d))
+}
+
+func (b *lazyBuilder) AssertIsEqual(i1, i2 frontend.Variable) {
+	if !isLazy(i1, i2) {
+		b.builder.AssertIsEqual(i1, i2)
+		return
+	}
+	res, k := b.diff(i1, i2)
+	if len(res) == 0 {
+		b.builder.AssertIsEqual(b.cs.ToBigInt(k), 0)
+		return
+	}
+	if len(res) > 3 {
+		b.builder.AssertIsEqual(b.materialize(i1), b.materialize(i2))
+		return
+	}
+	// qL⋅xa + qR⋅xb + qO⋅xc + k == 0
+	var wires [3]int
+	var coeffs [3]constraint.Element
+	for i, t := range res {
+		wires[i], coeffs[i] = t.VID, t.Coeff
+	}
+	debugInfo := b.newDebugInfo("assertIsEqual", b.debugArg(i1), " == ", b.debugArg(i2))
+	b.addPlonkConstraint(sparseR1C{
+		xa: wires[0],
+		xb: wires[1],
+		xc: wires[2],
+		qL: coeffs[0],
+		qR: coeffs[1],
+		qO: coeffs[2],
+		qC: k,
+	}, debugInfo)
+}
+
+func (b *lazyBuilder) Println(a ...frontend.Variable) {
+	b.builder.println(2, a...)
+}
+
+func (b *lazyBuilder) Compiler() frontend.Compiler {
+	return b
+}
+
+func (b *lazyBuilder) IsBoolean(v frontend.Variable) bool {
+	if isLazy(v) {
+		return false
+	}
+	return b.builder.IsBoolean(v)
+}
+
+func (b *lazyBuilder) MarkBoolean(v frontend.Variable) {
+	b.builder.MarkBoolean(b.materialize(v))
+}
+
+func (b *lazyBuilder) ConstantValue(v frontend.Variable) (*big.Int, bool) {
+	if isLazy(v) {
+		return nil, false
+	}
+	return b.builder.ConstantValue(v)
+}
+
+// the other operations materialize their inputs
+
+func (b *lazyBuilder) DivUnchecked(i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.DivUnchecked(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) Div(i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.Div(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) Inverse(i1 frontend.Variable) frontend.Variable {
+	return b.builder.Inverse(b.materialize(i1))
+}
+
+func (b *lazyBuilder) ToBinary(i1 frontend.Variable, n ...int) []frontend.Variable {
+	return b.builder.ToBinary(b.materialize(i1), n...)
+}
+
+func (b *lazyBuilder) FromBinary(bits ...frontend.Variable) frontend.Variable {
+	return b.builder.FromBinary(b.materializeAll(bits)...)
+}
+
+func (b *lazyBuilder) Xor(i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.Xor(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) Or(i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.Or(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) And(i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.And(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) Select(s frontend.Variable, i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.Select(b.materialize(s), b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) Lookup2(b0, b1 frontend.Variable, i0, i1, i2, i3 frontend.Variable) frontend.Variable {
+	in := b.materializeAll([]frontend.Variable{b0, b1, i0, i1, i2, i3})
+	return b.builder.Lookup2(in[0], in[1], in[2], in[3], in[4], in[5])
+}
+
+func (b *lazyBuilder) IsZero(i1 frontend.Variable) frontend.Variable {
+	return b.builder.IsZero(b.materialize(i1))
+}
+
+func (b *lazyBuilder) Cmp(i1, i2 frontend.Variable) frontend.Variable {
+	return b.builder.Cmp(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) AssertIsDifferent(i1, i2 frontend.Variable) {
+	b.builder.AssertIsDifferent(b.materialize(i1), b.materialize(i2))
+}
+
+func (b *lazyBuilder) AssertIsBoolean(i1 frontend.Variable) {
+	b.builder.AssertIsBoolean(b.materialize(i1))
+}
+
+func (b *lazyBuilder) AssertIsCrumb(i1 frontend.Variable) {
+	b.builder.AssertIsCrumb(b.materialize(i1))
+}
+
+func (b *lazyBuilder) AssertIsLessOrEqual(v frontend.Variable, bound frontend.Variable) {
+	b.builder.AssertIsLessOrEqual(b.materialize(v), b.materialize(bound))
+}
+
+func (b *lazyBuilder) MustBeLessOrEqCst(aBits []frontend.Variable, bound *big.Int, aForDebug frontend.Variable) {
+	b.builder.MustBeLessOrEqCst(b.materializeAll(aBits), bound, b.materialize(aForDebug))
+}
+
+func (b *lazyBuilder) EvaluatePlonkExpression(i1, i2 frontend.Variable, qL, qR, qM, qC int) frontend.Variable {
+	return b.builder.EvaluatePlonkExpression(b.materialize(i1), b.materialize(i2), qL, qR, qM, qC)
+}
+
+func (b *lazyBuilder) AddPlonkConstraint(i1, i2, o frontend.Variable, qL, qR, qO, qM, qC int) {
+	b.builder.AddPlonkConstraint(b.materialize(i1), b.materialize(i2), b.materialize(o), qL, qR, qO, qM, qC)
+}
+
+func (b *lazyBuilder) NewHint(f solver.Hint, nbOutputs int, inputs ...frontend.Variable) ([]frontend.Variable, error) {
+	return b.builder.NewHint(f, nbOutputs, b.materializeAll(inputs)...)
+}
+
+func (b *lazyBuilder) Commit(v ...frontend.Variable) (frontend.Variable, error) {
+	return b.builder.Commit(b.materializeAll(v)...)
+}
+
+func (b *lazyBuilder) ToCanonicalVariable(v frontend.Variable) frontend.CanonicalVariable {
+	return b.builder.ToCanonicalVariable(b.materialize(v))
+}
+
+func (b *lazyBuilder) GetWireConstraints(wires []frontend.Variable, addMissing bool) ([][2]int, error) {
+	return b.builder.GetWireConstraints(b.materializeAll(wires), addMissing)
+}
+
+func (b *lazyBuilder) InstantiateSubCircuit(c *frontend.CompiledSubCircuit, inputs []frontend.Variable) ([]frontend.Variable, error) {
+	return b.builder.InstantiateSubCircuit(c, b.materializeAll(inputs))
+}
@@ -0,0 +1,87 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scoper is implemented by the builders which attribute the constraints to
+// named scopes. See [Scope].
+type Scoper interface {
+	// PushScope opens the named scope inside the current scope. The name may
+	// be a path ("verify_sig/msm") opening nested scopes.
+	PushScope(name string)
+
+	// PopScope closes the scopes opened by the last call to PushScope.
+	PopScope()
+}
+
+// Scope opens the named scope in api and returns the function closing it. The
+// constraints, wires, hints and commitments added while the scope is open are
+// attributed to it and reported after compilation when using
+// [WithScopeStats]:
+//
+//	defer frontend.Scope(api, "verify_sig/msm")()
+//
+// The callbacks registered with [Compiler.Defer] are run in the scope in
+// which they were registered. If api doesn't implement [Scoper] (for example
+// the test engine), this is a no-op.
+func Scope(api API, name string) func() {
+	s, ok := api.(Scoper)
+	if !ok {
+		return func() {}
+	}
+	s.PushScope(name)
+	return s.PopScope
+}
+
+// ScopeStats is the number of constraints, internal wires, hints and
+// commitments added in a scope (including its sub-scopes), see [Scope]. The
+// root scope is the whole circuit.
+type ScopeStats struct {
+	Name          string
+	NbConstraints int
+	NbWires       int
+	NbHints       int
+	NbCommitments int
+	Children      []*ScopeStats
+}
+
+// Child returns the sub-scope at path ("verify_sig/msm"), or nil if it doesn't
+// exist.
+func (s *ScopeStats) Child(path string) *ScopeStats {
+	for _, name := range strings.Split(path, "/") {
+		if s = s.child(name); s == nil {
+			return nil
+		}
+	}
+	return s
+}
+
+func (s *ScopeStats) child(name string) *ScopeStats {
+	for _, c := range s.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// String returns the tree of scopes, one scope per line.
+func (s *ScopeStats) String() string {
+	var sbb strings.Builder
+	var write func(s *ScopeStats, depth int)
+	write = func(s *ScopeStats, depth int) {
+		name := s.Name
+		if depth == 0 && name == "" {
+			name = "circuit"
+		}
+		fmt.Fprintf(&sbb, "%s%s: %d constraints, %d wires, %d hints, %d commitments\n",
+			strings.Repeat("  ", depth), name, s.NbConstraints, s.NbWires, s.NbHints, s.NbCommitments)
+		for _, c := range s.Children {
+			write(c, depth+1)
+		}
+	}
+	write(s, 0)
+	return sbb.String()
+}
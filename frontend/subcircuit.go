@@ -0,0 +1,188 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark/constraint"
+)
+
+// SubCircuitDefine defines the constraints of a sub-circuit on its inputs and
+// returns its outputs.
+type SubCircuitDefine func(api API, inputs []Variable) ([]Variable, error)
+
+// SubCircuit is a circuit which is compiled once as a standalone constraint
+// system and instantiated several times in a circuit, by relinking its wires
+// into the constraint system of the circuit (see [constraint.Link]). Compiling
+// a circuit made of many copies of the same gadget is then much faster, and the
+// independent sub-circuits can be compiled concurrently with
+// [CompileSubCircuits].
+//
+// As the sub-circuit is compiled independently of its inputs, the constant
+// inputs are not propagated, and each input which is not a single wire costs a
+// constraint. The sub-circuit must not use commitments (and thus range checks
+// or lookups relying on them) nor custom blueprints.
+//
+// A SubCircuit is safe for concurrent use.
+type SubCircuit struct {
+	nbInputs int
+	define   SubCircuitDefine
+
+	lock     sync.Mutex
+	compiled map[string]*compiledSubCircuit
+}
+
+type compiledSubCircuit struct {
+	once     sync.Once
+	compiled *CompiledSubCircuit
+	err      error
+}
+
+// CompiledSubCircuit is a sub-circuit compiled by a builder, see [SubCircuit].
+type CompiledSubCircuit struct {
+	// System is the constraint system of the sub-circuit, whose secret inputs
+	// are the inputs of the sub-circuit.
+	System constraint.ConstraintSystem
+
+	// Outputs are the outputs of the sub-circuit, as variables of the builder
+	// which compiled System.
+	Outputs []Variable
+}
+
+// SubCircuitBuilder is implemented by the builders which support the
+// instantiation of compiled sub-circuits, see [SubCircuit].
+type SubCircuitBuilder interface {
+	// SubCircuitConfig returns the constructor and the configuration of the
+	// builders compiling the sub-circuits.
+	SubCircuitConfig() (NewBuilder, CompileConfig)
+
+	// InstantiateSubCircuit adds the constraints of the compiled sub-circuit
+	// on the given inputs and returns its outputs.
+	InstantiateSubCircuit(c *CompiledSubCircuit, inputs []Variable) ([]Variable, error)
+}
+
+// VariableMaterializer is implemented by the builders whose variables may be
+// expressions which are not wires of the constraint system yet, see
+// [WithLazyLinearExpressions].
+type VariableMaterializer interface {
+	// Materialize returns v as a wire of the constraint system, or v if it is
+	// already a wire or a constant.
+	Materialize(v Variable) Variable
+}
+
+// NewSubCircuit returns a sub-circuit with nbInputs inputs defined by define.
+func NewSubCircuit(nbInputs int, define SubCircuitDefine) *SubCircuit {
+	return &SubCircuit{
+		nbInputs: nbInputs,
+		define:   define,
+		compiled: make(map[string]*compiledSubCircuit),
+	}
+}
+
+// Call instantiates the sub-circuit in api on the given inputs and returns its
+// outputs. The sub-circuit is compiled on the first call. If api doesn't
+// implement [SubCircuitBuilder] (for example the test engine), the sub-circuit
+// is defined directly in api.
+func (s *SubCircuit) Call(api API, inputs ...Variable) ([]Variable, error) {
+	if len(inputs) != s.nbInputs {
+		return nil, fmt.Errorf("expected %d inputs, got %d", s.nbInputs, len(inputs))
+	}
+	b, ok := api.(SubCircuitBuilder)
+	if !ok {
+		return s.define(api, inputs)
+	}
+	c, err := s.compile(b)
+	if err != nil {
+		return nil, err
+	}
+	return b.InstantiateSubCircuit(c, inputs)
+}
+
+// CompileSubCircuits compiles the sub-circuits concurrently for the builder
+// api, so that the following calls to [SubCircuit.Call] only instantiate them.
+// It is a no-op if api doesn't implement [SubCircuitBuilder].
+func CompileSubCircuits(api API, subCircuits ...*SubCircuit) error {
+	b, ok := api.(SubCircuitBuilder)
+	if !ok {
+		return nil
+	}
+	errs := make([]error, len(subCircuits))
+	var wg sync.WaitGroup
+	wg.Add(len(subCircuits))
+	for i := range subCircuits {
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = subCircuits[i].compile(b)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// compile returns the sub-circuit compiled by builders of the same type and
+// configuration as b, compiling it if needed.
+func (s *SubCircuit) compile(b SubCircuitBuilder) (*CompiledSubCircuit, error) {
+	newBuilder, config := b.SubCircuitConfig()
+	field := b.(API).Compiler().Field()
+	// only the options changing the constraints apply to the sub-circuit, its
+	// wires are checked once instantiated
+	config = CompileConfig{
+		CompressThreshold:         config.CompressThreshold,
+		Deduplicate:               config.Deduplicate,
+		LazyLinearExpressions:     config.LazyLinearExpressions,
+		IgnoreUnconstrainedInputs: true,
+	}
+	key := fmt.Sprintf("%s %s %d %t %d", runtime.FuncForPC(reflect.ValueOf(newBuilder).Pointer()).Name(), field, config.CompressThreshold, config.Deduplicate, config.LazyLinearExpressions)
+
+	s.lock.Lock()
+	c, ok := s.compiled[key]
+	if !ok {
+		c = new(compiledSubCircuit)
+		s.compiled[key] = c
+	}
+	s.lock.Unlock()
+
+	c.once.Do(func() {
+		var outputs []Variable
+		inputs := make([]Variable, s.nbInputs)
+		circuit := &subCircuitWrapper{
+			Inputs: inputs,
+			define: func(api API) error {
+				res, err := s.define(api, inputs)
+				if err != nil {
+					return err
+				}
+				// the outputs are relinked as wires of the sub-circuit
+				outputs = make([]Variable, len(res))
+				copy(outputs, res)
+				if m, ok := api.(VariableMaterializer); ok {
+					for i := range outputs {
+						outputs[i] = m.Materialize(outputs[i])
+					}
+				}
+				return nil
+			},
+		}
+		ccs, err := compile(field, newBuilder, circuit, config)
+		if err != nil {
+			c.err = fmt.Errorf("compile sub-circuit: %w", err)
+			return
+		}
+		c.compiled = &CompiledSubCircuit{System: ccs, Outputs: outputs}
+	})
+	return c.compiled, c.err
+}
+
+// subCircuitWrapper is the circuit compiling a sub-circuit, whose inputs are
+// secret.
+type subCircuitWrapper struct {
+	Inputs []Variable
+	define func(api API) error
+}
+
+func (c *subCircuitWrapper) Define(api API) error {
+	return c.define(api)
+}
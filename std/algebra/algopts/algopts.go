@@ -12,6 +12,8 @@ type algebraCfg struct {
 	FoldMulti          bool
 	CompleteArithmetic bool
 	ToBitsCanonical    bool
+	MSMWindowSize      int
+	MSMBuckets         bool
 }
 
 // AlgebraOption allows modifying algebraic operation behaviour.
@@ -77,6 +79,57 @@ func WithCanonicalBitRepresentation() AlgebraOption {
 	}
 }
 
+// WithWindowedMSM can be used when calling MultiScalarMul. By using this
+// option, the multi-scalar multiplication processes the scalars in windows of
+// windowSize bits (Straus' method). The doublings are shared between all the
+// points and the window multiples of every point are precomputed once and then
+// selected using lookup tables.
+//
+// The precomputation costs 2^(windowSize-1) additions per point and every
+// window costs windowSize doublings and one addition per point. The method
+// pays off for large number of points (16 or more) with windowSize of 4 or 5.
+// As the lookup tables are built on std/lookup/logderivlookup, the backend has
+// to support commitments. The option is used by the emulated curves and is
+// ignored by the native 2-chain curves.
+func WithWindowedMSM(windowSize int) AlgebraOption {
+	return func(ac *algebraCfg) error {
+		if ac.MSMWindowSize != 0 {
+			return fmt.Errorf("WithWindowedMSM already set")
+		}
+		if windowSize < 1 || windowSize > 16 {
+			return fmt.Errorf("window size must be between 1 and 16, got %d", windowSize)
+		}
+		ac.MSMWindowSize = windowSize
+		return nil
+	}
+}
+
+// WithBucketMSM can be used when calling MultiScalarMul. By using this option,
+// the multi-scalar multiplication uses the bucket method of Pippenger with
+// windows of windowSize bits. For every window, the points are added into the
+// buckets corresponding to their window digits, which are kept in a read-write
+// memory, and the buckets are then combined with running sums.
+//
+// Every window costs one addition per point and 2^windowSize additions for
+// combining the buckets, but there is no precomputation per point. The memory
+// accesses cost constraints per limb of the coordinates, so that
+// [WithWindowedMSM] is cheaper for a moderate number of points. As the memory
+// is built on std/memory, the backend has to support commitments. The option
+// is used by the emulated curves and is ignored by the native 2-chain curves.
+func WithBucketMSM(windowSize int) AlgebraOption {
+	return func(ac *algebraCfg) error {
+		if ac.MSMWindowSize != 0 {
+			return fmt.Errorf("MSM window size already set")
+		}
+		if windowSize < 1 || windowSize > 16 {
+			return fmt.Errorf("window size must be between 1 and 16, got %d", windowSize)
+		}
+		ac.MSMWindowSize = windowSize
+		ac.MSMBuckets = true
+		return nil
+	}
+}
+
 // NewConfig applies all given options and returns a configuration to be used.
 func NewConfig(opts ...AlgebraOption) (*algebraCfg, error) {
 	ret := new(algebraCfg)
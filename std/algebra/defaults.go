@@ -4,12 +4,15 @@ import (
 	"fmt"
 
 	"github.com/consensys/gnark/frontend"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6633"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/algebra/native/sw_grumpkin"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/math/emulated/emparams"
 )
@@ -33,12 +36,24 @@ func GetCurve[FR emulated.FieldParams, G1El G1ElementT](api frontend.API) (Curve
 			return ret, fmt.Errorf("new curve: %w", err)
 		}
 		*s = c
+	case *Curve[sw_bw6633.ScalarField, sw_bw6633.G1Affine]:
+		c, err := sw_emulated.New[emparams.BW6633Fp, emparams.BW6633Fr](api, sw_emulated.GetBW6633Params())
+		if err != nil {
+			return ret, fmt.Errorf("new curve: %w", err)
+		}
+		*s = c
 	case *Curve[sw_bls12381.ScalarField, sw_bls12381.G1Affine]:
 		c, err := sw_emulated.New[emparams.BLS12381Fp, emparams.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
 		if err != nil {
 			return ret, fmt.Errorf("new curve: %w", err)
 		}
 		*s = c
+	case *Curve[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine]:
+		c, err := sw_emulated.New[emparams.BLS12377Fp, emparams.BLS12377Fr](api, sw_emulated.GetBLS12377Params())
+		if err != nil {
+			return ret, fmt.Errorf("new curve: %w", err)
+		}
+		*s = c
 	case *Curve[sw_bls12377.ScalarField, sw_bls12377.G1Affine]:
 		c, err := sw_bls12377.NewCurve(api)
 		if err != nil {
@@ -51,6 +66,12 @@ func GetCurve[FR emulated.FieldParams, G1El G1ElementT](api frontend.API) (Curve
 			return ret, fmt.Errorf("new curve: %w", err)
 		}
 		*s = c
+	case *Curve[sw_grumpkin.ScalarField, sw_grumpkin.G1Affine]:
+		c, err := sw_grumpkin.NewCurve(api)
+		if err != nil {
+			return ret, fmt.Errorf("new curve: %w", err)
+		}
+		*s = c
 	case *Curve[emparams.Secp256k1Fr, sw_emulated.AffinePoint[emparams.Secp256k1Fp]]:
 		c, err := sw_emulated.New[emparams.Secp256k1Fp, emparams.Secp256k1Fr](api, sw_emulated.GetSecp256k1Params())
 		if err != nil {
@@ -81,12 +102,24 @@ func GetPairing[G1El G1ElementT, G2El G2ElementT, GtEl GtElementT](api frontend.
 			return ret, fmt.Errorf("new pairing: %w", err)
 		}
 		*s = p
+	case *Pairing[sw_bw6633.G1Affine, sw_bw6633.G2Affine, sw_bw6633.GTEl]:
+		p, err := sw_bw6633.NewPairing(api)
+		if err != nil {
+			return ret, fmt.Errorf("new pairing: %w", err)
+		}
+		*s = p
 	case *Pairing[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]:
 		p, err := sw_bls12381.NewPairing(api)
 		if err != nil {
 			return ret, fmt.Errorf("new pairing: %w", err)
 		}
 		*s = p
+	case *Pairing[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]:
+		p, err := emsw_bls12377.NewPairing(api)
+		if err != nil {
+			return ret, fmt.Errorf("new pairing: %w", err)
+		}
+		*s = p
 	case *Pairing[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]:
 		p := sw_bls12377.NewPairing(api)
 		*s = p
@@ -6,7 +6,8 @@
 //
 // These arithmetic operations are implemented
 //   - using native field via the 2-chains BLS12-377/BW6-761 and BLS24-315/BW-633
-//     (`native/`) or associated twisted Edwards (e.g. Jubjub/BLS12-381) and
+//     (`native/`), the BN254/Grumpkin cycle or associated twisted Edwards (e.g.
+//     Jubjub/BLS12-381) and
 //   - using nonnative field via field emulation (`emulated/`). This allows to
 //     use any curve over any (SNARK) field (e.g. secp256k1 curve arithmetic over
 //     BN254 SNARK field or BN254 pairing over BN254 SNARK field).  The drawback
@@ -0,0 +1,11 @@
+// Package fields_bls12377 implements the fields arithmetic of the Fp12 tower
+// used to compute the pairing over the BLS12-377 curve.
+//
+//	𝔽p²[u] = 𝔽p/u²+5
+//	𝔽p⁶[v] = 𝔽p²/v³-u
+//	𝔽p¹²[w] = 𝔽p⁶/w²-v
+//
+// Contrary to [github.com/consensys/gnark/std/algebra/native/fields_bls12377],
+// the arithmetic is carried over an emulated base field so that the pairing
+// can be verified in circuits defined over any native field (e.g. BN254).
+package fields_bls12377
@@ -0,0 +1,256 @@
+package fields_bls12377
+
+func (e Ext12) nSquareGS(z *E12, n int) *E12 {
+	for i := 0; i < n; i++ {
+		z = e.CyclotomicSquare(z)
+	}
+	return z
+}
+
+// ExpX0 sets z to x^x₀ in E12 and return z
+// where x₀ = 0x8508c00000000001 is the BLS12-377 seed.
+func (e Ext12) ExpX0(x *E12) *E12 {
+	z := e.nSquareGS(x, 5)
+	z = e.Mul(z, x)
+	x33 := z
+	z = e.nSquareGS(z, 7)
+	z = e.Mul(z, x33)
+	z = e.nSquareGS(z, 4)
+	z = e.Mul(z, x)
+	z = e.CyclotomicSquare(z)
+	z = e.Mul(z, x)
+	z = e.nSquareGS(z, 46)
+	z = e.Mul(z, x)
+
+	return z
+}
+
+// ExpX0Minus1Square sets z to x^((x₀-1)²) in E12 and return z
+// where x₀ = 0x8508c00000000001 is the BLS12-377 seed.
+func (e Ext12) ExpX0Minus1Square(x *E12) *E12 {
+	z := e.nSquareGS(x, 3)
+	t0 := e.CyclotomicSquare(z)
+	t2 := e.Mul(x, t0)
+	z = e.Mul(z, t2)
+	t0 = e.Mul(x, z)
+	t1 := e.CyclotomicSquare(t0)
+	t1 = e.Mul(t2, t1)
+	t3 := e.nSquareGS(t1, 7)
+	t2 = e.Mul(t2, t3)
+	t2 = e.nSquareGS(t2, 11)
+	t1 = e.Mul(t1, t2)
+	t0 = e.Mul(t0, t1)
+	t0 = e.nSquareGS(t0, 7)
+	z = e.Mul(z, t0)
+	z = e.nSquareGS(z, 3)
+	z = e.Mul(x, z)
+	z = e.nSquareGS(z, 92)
+
+	return z
+}
+
+// MulBy034 multiplies z by an E12 sparse element of the form
+//
+//	E12{
+//		C0: E6{B0: 1, B1: 0, B2: 0},
+//		C1: E6{B0: c3, B1: c4, B2: 0},
+//	}
+func (e *Ext12) MulBy034(z *E12, c3, c4 *E2) *E12 {
+
+	a := &z.C0
+	b := e.Ext6.MulBy01(&z.C1, c3, c4)
+
+	one := e.Ext2.One()
+	d0 := e.Ext2.Add(c3, one)
+	d := e.Ext6.Add(&z.C0, &z.C1)
+	d = e.Ext6.MulBy01(d, d0, c4)
+
+	zC1 := e.Ext6.Add(a, b)
+	zC1 = e.Ext6.Sub(d, zC1)
+	zC0 := e.Ext6.MulByNonResidue(b)
+	zC0 = e.Ext6.Add(zC0, a)
+
+	return &E12{
+		C0: *zC0,
+		C1: *zC1,
+	}
+}
+
+//	multiplies two E12 sparse element of the form:
+//
+//	E12{
+//		C0: E6{B0: 1, B1: 0, B2: 0},
+//		C1: E6{B0: c3, B1: c4, B2: 0},
+//	}
+//
+// and
+//
+//	E12{
+//		C0: E6{B0: 1, B1: 0, B2: 0},
+//		C1: E6{B0: d3, B1: d4, B2: 0},
+//	}
+func (e Ext12) Mul034By034(d3, d4, c3, c4 *E2) [5]*E2 {
+	x3 := e.Ext2.Mul(c3, d3)
+	x4 := e.Ext2.Mul(c4, d4)
+	x04 := e.Ext2.Add(c4, d4)
+	x03 := e.Ext2.Add(c3, d3)
+	tmp := e.Ext2.Add(c3, c4)
+	x34 := e.Ext2.Add(d3, d4)
+	x34 = e.Ext2.Mul(x34, tmp)
+	tmp = e.Ext2.Add(x3, x4)
+	x34 = e.Ext2.Sub(x34, tmp)
+
+	x00 := e.Ext2.MulByNonResidue(x4)
+	x00 = e.Ext2.Add(x00, e.Ext2.One())
+
+	return [5]*E2{x00, x3, x34, x03, x04}
+}
+
+// Mul01234By034 multiplies an E12 sparse element of the form
+//
+//	E12{
+//		C0: E6{B0: x0, B1: x1, B2: x2},
+//		C1: E6{B0: x3, B1: x4, B2: 0},
+//	}
+//
+// by an E12 sparse element of the form
+//
+//	E12{
+//		C0: E6{B0: 1, B1: 0, B2: 0},
+//		C1: E6{B0: z3, B1: z4, B2: 0},
+//	}
+func (e *Ext12) Mul01234By034(x [5]*E2, z3, z4 *E2) *E12 {
+	c0 := &E6{B0: *x[0], B1: *x[1], B2: *x[2]}
+	a0 := e.Ext2.Add(z3, e.Ext2.One())
+	b := &E6{
+		B0: *e.Ext2.Add(x[0], x[3]),
+		B1: *e.Ext2.Add(x[1], x[4]),
+		B2: *x[2],
+	}
+	b = e.Ext6.MulBy01(b, a0, z4)
+	c := e.Ext6.Mul01By01(z3, z4, x[3], x[4])
+	z1 := e.Ext6.Sub(b, c0)
+	z1 = e.Ext6.Sub(z1, c)
+	z0 := e.Ext6.MulByNonResidue(c)
+	z0 = e.Ext6.Add(z0, c0)
+	return &E12{
+		C0: *z0,
+		C1: *z1,
+	}
+}
+
+// MulBy01234 multiplies z by an E12 sparse element of the form
+//
+//	E12{
+//		C0: E6{B0: x0, B1: x1, B2: x2},
+//		C1: E6{B0: x3, B1: x4, B2: 0},
+//	}
+func (e *Ext12) MulBy01234(z *E12, x [5]*E2) *E12 {
+	c0 := &E6{B0: *x[0], B1: *x[1], B2: *x[2]}
+	a := e.Ext6.Add(&z.C0, &z.C1)
+	b := &E6{
+		B0: *e.Ext2.Add(x[0], x[3]),
+		B1: *e.Ext2.Add(x[1], x[4]),
+		B2: *x[2],
+	}
+	a = e.Ext6.Mul(a, b)
+	b = e.Ext6.Mul(&z.C0, c0)
+	c := e.Ext6.MulBy01(&z.C1, x[3], x[4])
+	z1 := e.Ext6.Sub(a, b)
+	z1 = e.Ext6.Sub(z1, c)
+	z0 := e.Ext6.MulByNonResidue(c)
+	z0 = e.Ext6.Add(z0, b)
+	return &E12{
+		C0: *z0,
+		C1: *z1,
+	}
+}
+
+// AssertFinalExponentiationIsOne checks that a Miller function output x lies in the
+// same equivalence class as the reduced pairing. This replaces the final
+// exponentiation step in-circuit.
+// The method follows Section 4 of [On Proving Pairings] paper by A. Novakovic and L. Eagen.
+//
+// [On Proving Pairings]: https://eprint.iacr.org/2024/640.pdf
+func (e Ext12) AssertFinalExponentiationIsOne(x *E12) {
+	res, err := e.fp.NewHint(finalExpHint, 18, &x.C0.B0.A0, &x.C0.B0.A1, &x.C0.B1.A0, &x.C0.B1.A1, &x.C0.B2.A0, &x.C0.B2.A1, &x.C1.B0.A0, &x.C1.B0.A1, &x.C1.B1.A0, &x.C1.B1.A1, &x.C1.B2.A0, &x.C1.B2.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+
+	residueWitness := E12{
+		C0: E6{
+			B0: E2{A0: *res[0], A1: *res[1]},
+			B1: E2{A0: *res[2], A1: *res[3]},
+			B2: E2{A0: *res[4], A1: *res[5]},
+		},
+		C1: E6{
+			B0: E2{A0: *res[6], A1: *res[7]},
+			B1: E2{A0: *res[8], A1: *res[9]},
+			B2: E2{A0: *res[10], A1: *res[11]},
+		},
+	}
+	// constrain cubicNonResiduePower to be in Fp6
+	scalingFactor := E12{
+		C0: E6{
+			B0: E2{A0: *res[12], A1: *res[13]},
+			B1: E2{A0: *res[14], A1: *res[15]},
+			B2: E2{A0: *res[16], A1: *res[17]},
+		},
+		C1: (*e.Ext6.Zero()),
+	}
+
+	// Check that  x * scalingFactor == residueWitness^(q-u)
+	// where u=0x8508c00000000001 is the BLS12-377 seed,
+	// and residueWitness, scalingFactor from the hint.
+	//
+	// We check instead residueWitness^q == x * scalingFactor * residueWitness^u
+	// to avoid an E12 division.
+	t0 := e.Frobenius(&residueWitness)
+	// exponentiation by u
+	t1 := e.ExpX0(&residueWitness)
+	t2 := e.Mul(x, &scalingFactor)
+	t1 = e.Mul(t1, t2)
+
+	e.AssertIsEqual(t0, t1)
+}
+
+func (e Ext12) Frobenius(x *E12) *E12 {
+	t0 := e.Ext2.Conjugate(&x.C0.B0)
+	t1 := e.Ext2.Conjugate(&x.C0.B1)
+	t2 := e.Ext2.Conjugate(&x.C0.B2)
+	t3 := e.Ext2.Conjugate(&x.C1.B0)
+	t4 := e.Ext2.Conjugate(&x.C1.B1)
+	t5 := e.Ext2.Conjugate(&x.C1.B2)
+	t1 = e.Ext2.MulByNonResidue1Power2(t1)
+	t2 = e.Ext2.MulByNonResidue1Power4(t2)
+	t3 = e.Ext2.MulByNonResidue1Power1(t3)
+	t4 = e.Ext2.MulByNonResidue1Power3(t4)
+	t5 = e.Ext2.MulByNonResidue1Power5(t5)
+	return &E12{
+		C0: E6{
+			B0: *t0,
+			B1: *t1,
+			B2: *t2,
+		},
+		C1: E6{
+			B0: *t3,
+			B1: *t4,
+			B2: *t5,
+		},
+	}
+}
+
+func (e Ext12) FrobeniusSquare(x *E12) *E12 {
+	z00 := &x.C0.B0
+	z01 := e.Ext2.MulByNonResidue2Power2(&x.C0.B1)
+	z02 := e.Ext2.MulByNonResidue2Power4(&x.C0.B2)
+	z10 := e.Ext2.MulByNonResidue2Power1(&x.C1.B0)
+	z11 := e.Ext2.MulByNonResidue2Power3(&x.C1.B1)
+	z12 := e.Ext2.MulByNonResidue2Power5(&x.C1.B2)
+	return &E12{
+		C0: E6{B0: *z00, B1: *z01, B2: *z02},
+		C1: E6{B0: *z10, B1: *z11, B2: *z12},
+	}
+}
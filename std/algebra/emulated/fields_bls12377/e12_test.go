@@ -0,0 +1,422 @@
+package fields_bls12377
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type e12Add struct {
+	A, B, C E12
+}
+
+func (circuit *e12Add) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Add(&circuit.A, &circuit.B)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestAddFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bls12377.E12
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Add(&a, &b)
+
+	witness := e12Add{
+		A: FromE12(&a),
+		B: FromE12(&b),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Add{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e12Sub struct {
+	A, B, C E12
+}
+
+func (circuit *e12Sub) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Sub(&circuit.A, &circuit.B)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestSubFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bls12377.E12
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Sub(&a, &b)
+
+	witness := e12Sub{
+		A: FromE12(&a),
+		B: FromE12(&b),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Sub{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e12Mul struct {
+	A, B, C E12
+}
+
+func (circuit *e12Mul) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Mul(&circuit.A, &circuit.B)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestMulFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bls12377.E12
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Mul(&a, &b)
+
+	witness := e12Mul{
+		A: FromE12(&a),
+		B: FromE12(&b),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Mul{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e12Div struct {
+	A, B, C E12
+}
+
+func (circuit *e12Div) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.DivUnchecked(&circuit.A, &circuit.B)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestDivFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bls12377.E12
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Div(&a, &b)
+
+	witness := e12Div{
+		A: FromE12(&a),
+		B: FromE12(&b),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Div{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e12Square struct {
+	A, C E12
+}
+
+func (circuit *e12Square) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Square(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestSquareFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bls12377.E12
+	_, _ = a.SetRandom()
+	c.Square(&a)
+
+	witness := e12Square{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Square{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e12Conjugate struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12Conjugate) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Conjugate(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+
+	return nil
+}
+
+func TestConjugateFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bls12377.E12
+	_, _ = a.SetRandom()
+	c.Conjugate(&a)
+
+	witness := e12Conjugate{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Conjugate{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12Inverse struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12Inverse) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Inverse(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+
+	return nil
+}
+
+func TestInverseFp12(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bls12377.E12
+	_, _ = a.SetRandom()
+	c.Inverse(&a)
+
+	witness := e12Inverse{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Inverse{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12ExpX0 struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12ExpX0) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.ExpX0(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+
+	return nil
+}
+
+func TestFp12ExpX0(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bls12377.E12
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	var tmp bls12377.E12
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.FrobeniusSquare(&tmp).Mul(&a, &tmp)
+
+	c.Expt(&a)
+	witness := e12ExpX0{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12ExpX0{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12CyclotomicSquare struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12CyclotomicSquare) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.CyclotomicSquare(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFp12CyclotomicSquare(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bls12377.E12
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	var tmp bls12377.E12
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.FrobeniusSquare(&tmp).Mul(&a, &tmp)
+
+	c.CyclotomicSquare(&a)
+	witness := e12CyclotomicSquare{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12CyclotomicSquare{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12Frobenius struct {
+	A          E12
+	C, CSquare E12 `gnark:",public"`
+}
+
+func (circuit *e12Frobenius) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Frobenius(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	expected = e.FrobeniusSquare(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.CSquare)
+	return nil
+}
+
+func TestFp12Frobenius(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c, cSquare bls12377.E12
+	_, _ = a.SetRandom()
+	c.Frobenius(&a)
+	cSquare.FrobeniusSquare(&a)
+
+	witness := e12Frobenius{
+		A:       FromE12(&a),
+		C:       FromE12(&c),
+		CSquare: FromE12(&cSquare),
+	}
+
+	err := test.IsSolved(&e12Frobenius{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12MulBy034 struct {
+	A    E12 `gnark:",public"`
+	W    E12
+	B, C E2
+}
+
+func (circuit *e12MulBy034) Define(api frontend.API) error {
+	e := NewExt12(api)
+	res := e.MulBy034(&circuit.A, &circuit.B, &circuit.C)
+	e.AssertIsEqual(res, &circuit.W)
+	return nil
+}
+
+func TestFp12MulBy034(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, w bls12377.E12
+	_, _ = a.SetRandom()
+	var one, b, c bls12377.E2
+	one.SetOne()
+	_, _ = b.SetRandom()
+	_, _ = c.SetRandom()
+	w.Set(&a)
+	w.MulBy034(&one, &b, &c)
+
+	witness := e12MulBy034{
+		A: FromE12(&a),
+		B: FromE2(&b),
+		C: FromE2(&c),
+		W: FromE12(&w),
+	}
+
+	err := test.IsSolved(&e12MulBy034{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e12Mul034By034 struct {
+	A, B, C, D E2
+	W          E12 `gnark:",public"`
+}
+
+func (circuit *e12Mul034By034) Define(api frontend.API) error {
+	e := NewExt12(api)
+	prod := e.Mul034By034(&circuit.A, &circuit.B, &circuit.C, &circuit.D)
+	res := &E12{
+		C0: E6{B0: *prod[0], B1: *prod[1], B2: *prod[2]},
+		C1: E6{B0: *prod[3], B1: *prod[4], B2: *e.Ext2.Zero()},
+	}
+	e.AssertIsEqual(res, &circuit.W)
+
+	// multiply the product of lines by a third line
+	res = e.Mul01234By034(prod, &circuit.A, &circuit.D)
+	w := e.MulBy034(&circuit.W, &circuit.A, &circuit.D)
+	e.AssertIsEqual(res, w)
+
+	// multiply a full element by the product of lines
+	res = e.MulBy01234(w, prod)
+	w = e.MulBy034(w, &circuit.A, &circuit.B)
+	w = e.MulBy034(w, &circuit.C, &circuit.D)
+	e.AssertIsEqual(res, w)
+	return nil
+}
+
+func TestFp12Mul034By034(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var w bls12377.E12
+	var one, a, b, c, d bls12377.E2
+	one.SetOne()
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	_, _ = c.SetRandom()
+	_, _ = d.SetRandom()
+	w.SetOne()
+	w.MulBy034(&one, &a, &b)
+	w.MulBy034(&one, &c, &d)
+
+	witness := e12Mul034By034{
+		A: FromE2(&a),
+		B: FromE2(&b),
+		C: FromE2(&c),
+		D: FromE2(&d),
+		W: FromE12(&w),
+	}
+
+	err := test.IsSolved(&e12Mul034By034{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
@@ -335,6 +335,26 @@ func (e Ext2) DivUnchecked(x, y *E2) *E2 {
 	return &div
 }
 
+// Sqrt returns a square root of x. The method fails if x is not a square.
+func (e Ext2) Sqrt(x *E2) *E2 {
+	res, err := e.fp.NewHint(sqrtE2Hint, 2, &x.A0, &x.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+
+	sqrt := E2{
+		A0: *res[0],
+		A1: *res[1],
+	}
+
+	// x == sqrt * sqrt
+	_x := e.Square(&sqrt)
+	e.AssertIsEqual(x, _x)
+
+	return &sqrt
+}
+
 func (e Ext2) Select(selector frontend.Variable, z1, z0 *E2) *E2 {
 	a0 := e.fp.Select(selector, &z1.A0, &z0.A0)
 	a1 := e.fp.Select(selector, &z1.A1, &z0.A1)
@@ -349,3 +349,42 @@ func TestInverseFp2(t *testing.T) {
 	err := test.IsSolved(&e2Inverse{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type e2Sqrt struct {
+	A E2
+	C E2 `gnark:",public"`
+}
+
+func (circuit *e2Sqrt) Define(api frontend.API) error {
+	e := NewExt2(api)
+	expected := e.Sqrt(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+
+	return nil
+}
+
+func TestSqrtFp2(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bls12381.E2
+	_, _ = b.SetRandom()
+	a.Square(&b)
+	c.Sqrt(&a)
+
+	witness := e2Sqrt{
+		A: FromE2(&a),
+		C: FromE2(&c),
+	}
+
+	err := test.IsSolved(&e2Sqrt{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// non-square input
+	for a.Legendre() != -1 {
+		_, _ = a.SetRandom()
+	}
+	witness.A = FromE2(&a)
+	err = test.IsSolved(&e2Sqrt{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -1,6 +1,7 @@
 package fields_bn254
 
 import (
+	"errors"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -19,6 +20,7 @@ func GetHints() []solver.Hint {
 		// E2
 		divE2Hint,
 		inverseE2Hint,
+		sqrtE2Hint,
 		// E6
 		divE6Hint,
 		inverseE6Hint,
@@ -48,6 +50,26 @@ func inverseE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) e
 		})
 }
 
+func sqrtE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var a, c bn254.E2
+
+			a.A0.SetBigInt(inputs[0])
+			a.A1.SetBigInt(inputs[1])
+
+			if a.Legendre() == -1 {
+				return errors.New("input is not a square")
+			}
+			c.Sqrt(&a)
+
+			c.A0.BigInt(outputs[0])
+			c.A1.BigInt(outputs[1])
+
+			return nil
+		})
+}
+
 func divE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
 	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
 		func(mod *big.Int, inputs, outputs []*big.Int) error {
@@ -0,0 +1,417 @@
+package fields_bw6633
+
+import (
+	"math/big"
+
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+type curveF = emulated.Field[emulated.BW6633Fp]
+type baseEl = emulated.Element[emulated.BW6633Fp]
+
+type E6 struct {
+	A0, A1, A2, A3, A4, A5 baseEl
+}
+
+type Ext6 struct {
+	api frontend.API
+	fp  *curveF
+}
+
+func NewExt6(api frontend.API) *Ext6 {
+	fp, err := emulated.NewField[emulated.BW6633Fp](api)
+	if err != nil {
+		panic(err)
+	}
+	return &Ext6{
+		api: api,
+		fp:  fp,
+	}
+}
+
+func (e Ext6) Reduce(x *E6) *E6 {
+	var z E6
+	z.A0 = *e.fp.Reduce(&x.A0)
+	z.A1 = *e.fp.Reduce(&x.A1)
+	z.A2 = *e.fp.Reduce(&x.A2)
+	z.A3 = *e.fp.Reduce(&x.A3)
+	z.A4 = *e.fp.Reduce(&x.A4)
+	z.A5 = *e.fp.Reduce(&x.A5)
+	return &z
+}
+
+func (e Ext6) Zero() *E6 {
+	zero := e.fp.Zero()
+	return &E6{
+		A0: *zero,
+		A1: *zero,
+		A2: *zero,
+		A3: *zero,
+		A4: *zero,
+		A5: *zero,
+	}
+}
+
+func (e Ext6) One() *E6 {
+	one := e.fp.One()
+	zero := e.fp.Zero()
+	return &E6{
+		A0: *one,
+		A1: *zero,
+		A2: *zero,
+		A3: *zero,
+		A4: *zero,
+		A5: *zero,
+	}
+}
+
+func (e Ext6) Neg(x *E6) *E6 {
+	a0 := e.fp.Neg(&x.A0)
+	a1 := e.fp.Neg(&x.A1)
+	a2 := e.fp.Neg(&x.A2)
+	a3 := e.fp.Neg(&x.A3)
+	a4 := e.fp.Neg(&x.A4)
+	a5 := e.fp.Neg(&x.A5)
+	return &E6{
+		A0: *a0,
+		A1: *a1,
+		A2: *a2,
+		A3: *a3,
+		A4: *a4,
+		A5: *a5,
+	}
+}
+
+func (e Ext6) Add(x, y *E6) *E6 {
+	a0 := e.fp.Add(&x.A0, &y.A0)
+	a1 := e.fp.Add(&x.A1, &y.A1)
+	a2 := e.fp.Add(&x.A2, &y.A2)
+	a3 := e.fp.Add(&x.A3, &y.A3)
+	a4 := e.fp.Add(&x.A4, &y.A4)
+	a5 := e.fp.Add(&x.A5, &y.A5)
+	return &E6{
+		A0: *a0,
+		A1: *a1,
+		A2: *a2,
+		A3: *a3,
+		A4: *a4,
+		A5: *a5,
+	}
+}
+
+func (e Ext6) Sub(x, y *E6) *E6 {
+	a0 := e.fp.Sub(&x.A0, &y.A0)
+	a1 := e.fp.Sub(&x.A1, &y.A1)
+	a2 := e.fp.Sub(&x.A2, &y.A2)
+	a3 := e.fp.Sub(&x.A3, &y.A3)
+	a4 := e.fp.Sub(&x.A4, &y.A4)
+	a5 := e.fp.Sub(&x.A5, &y.A5)
+	return &E6{
+		A0: *a0,
+		A1: *a1,
+		A2: *a2,
+		A3: *a3,
+		A4: *a4,
+		A5: *a5,
+	}
+}
+
+func (e Ext6) Double(x *E6) *E6 {
+	two := big.NewInt(2)
+	a0 := e.fp.MulConst(&x.A0, two)
+	a1 := e.fp.MulConst(&x.A1, two)
+	a2 := e.fp.MulConst(&x.A2, two)
+	a3 := e.fp.MulConst(&x.A3, two)
+	a4 := e.fp.MulConst(&x.A4, two)
+	a5 := e.fp.MulConst(&x.A5, two)
+	return &E6{
+		A0: *a0,
+		A1: *a1,
+		A2: *a2,
+		A3: *a3,
+		A4: *a4,
+		A5: *a5,
+	}
+}
+
+func (e Ext6) MulByElement(x *E6, y *baseEl) *E6 {
+	a0 := e.fp.Mul(&x.A0, y)
+	a1 := e.fp.Mul(&x.A1, y)
+	a2 := e.fp.Mul(&x.A2, y)
+	a3 := e.fp.Mul(&x.A3, y)
+	a4 := e.fp.Mul(&x.A4, y)
+	a5 := e.fp.Mul(&x.A5, y)
+	z := &E6{
+		A0: *a0,
+		A1: *a1,
+		A2: *a2,
+		A3: *a3,
+		A4: *a4,
+		A5: *a5,
+	}
+	return z
+}
+
+func (e Ext6) MulByConstElement(x *E6, y *big.Int) *E6 {
+	a0 := e.fp.MulConst(&x.A0, y)
+	a1 := e.fp.MulConst(&x.A1, y)
+	a2 := e.fp.MulConst(&x.A2, y)
+	a3 := e.fp.MulConst(&x.A3, y)
+	a4 := e.fp.MulConst(&x.A4, y)
+	a5 := e.fp.MulConst(&x.A5, y)
+	return &E6{
+		A0: *a0,
+		A1: *a1,
+		A2: *a2,
+		A3: *a3,
+		A4: *a4,
+		A5: *a5,
+	}
+}
+
+func (e Ext6) Conjugate(x *E6) *E6 {
+	return &E6{
+		A0: x.A0,
+		A1: *e.fp.Neg(&x.A1),
+		A2: x.A2,
+		A3: *e.fp.Neg(&x.A3),
+		A4: x.A4,
+		A5: *e.fp.Neg(&x.A5),
+	}
+}
+
+func (e Ext6) mulFpByNonResidue(fp *curveF, x *baseEl) *baseEl {
+	return fp.MulConst(x, big.NewInt(2))
+}
+
+// mulE3 multiplies two elements of the cubic extension 𝔽p³[u] = 𝔽p/u³-2
+// given by their coefficients, using Karatsuba.
+func (e Ext6) mulE3(x, y [3]*baseEl) [3]*baseEl {
+	t0 := e.fp.Mul(x[0], y[0])
+	t1 := e.fp.Mul(x[1], y[1])
+	t2 := e.fp.Mul(x[2], y[2])
+
+	c0 := e.fp.Mul(e.fp.Add(x[1], x[2]), e.fp.Add(y[1], y[2]))
+	c0 = e.fp.Sub(c0, e.fp.Add(t1, t2))
+	c0 = e.fp.Add(e.mulFpByNonResidue(e.fp, c0), t0)
+
+	c1 := e.fp.Mul(e.fp.Add(x[0], x[1]), e.fp.Add(y[0], y[1]))
+	c1 = e.fp.Sub(c1, e.fp.Add(t0, t1))
+	c1 = e.fp.Add(c1, e.mulFpByNonResidue(e.fp, t2))
+
+	c2 := e.fp.Mul(e.fp.Add(x[0], x[2]), e.fp.Add(y[0], y[2]))
+	c2 = e.fp.Sub(c2, e.fp.Add(t0, t2))
+	c2 = e.fp.Add(c2, t1)
+
+	return [3]*baseEl{c0, c1, c2}
+}
+
+// mulE3ByNonResidue multiplies an element of 𝔽p³ by u.
+func (e Ext6) mulE3ByNonResidue(x [3]*baseEl) [3]*baseEl {
+	return [3]*baseEl{e.mulFpByNonResidue(e.fp, x[2]), x[0], x[1]}
+}
+
+func (e Ext6) addE3(x, y [3]*baseEl) [3]*baseEl {
+	return [3]*baseEl{e.fp.Add(x[0], y[0]), e.fp.Add(x[1], y[1]), e.fp.Add(x[2], y[2])}
+}
+
+func (e Ext6) subE3(x, y [3]*baseEl) [3]*baseEl {
+	return [3]*baseEl{e.fp.Sub(x[0], y[0]), e.fp.Sub(x[1], y[1]), e.fp.Sub(x[2], y[2])}
+}
+
+// toE3 splits x into its two coefficients over 𝔽p³ in the quadratic over
+// cubic representation 𝔽p⁶ = 𝔽p³[v]/v²-u.
+func toE3(x *E6) (b0, b1 [3]*baseEl) {
+	return [3]*baseEl{&x.A0, &x.A2, &x.A4}, [3]*baseEl{&x.A1, &x.A3, &x.A5}
+}
+
+// fromE3 is the inverse of toE3.
+func fromE3(b0, b1 [3]*baseEl) *E6 {
+	return &E6{
+		A0: *b0[0],
+		A1: *b1[0],
+		A2: *b0[1],
+		A3: *b1[1],
+		A4: *b0[2],
+		A5: *b1[2],
+	}
+}
+
+func (e Ext6) Mul(x, y *E6) *E6 {
+	// We simulate a quadratic over cubic extension tower and use Karatsuba
+	// at both levels.
+	x = e.Reduce(x)
+	y = e.Reduce(y)
+	x0, x1 := toE3(x)
+	y0, y1 := toE3(y)
+
+	a := e.mulE3(x0, y0)
+	b := e.mulE3(x1, y1)
+	c := e.mulE3(e.addE3(x0, x1), e.addE3(y0, y1))
+	c = e.subE3(c, e.addE3(a, b))
+	z0 := e.addE3(a, e.mulE3ByNonResidue(b))
+
+	return fromE3(z0, c)
+}
+
+func (e Ext6) Square(x *E6) *E6 {
+	// Algorithm 22 from https://eprint.iacr.org/2010/354.pdf
+	x = e.Reduce(x)
+	x0, x1 := toE3(x)
+
+	c0 := e.subE3(x0, x1)
+	c3 := e.subE3(x0, e.mulE3ByNonResidue(x1))
+	c2 := e.mulE3(x0, x1)
+	c0 = e.addE3(e.mulE3(c0, c3), c2)
+	z1 := e.addE3(c2, c2)
+	z0 := e.addE3(c0, e.mulE3ByNonResidue(c2))
+
+	return fromE3(z0, z1)
+}
+
+// CyclotomicSquare squares an element in the cyclotomic subgroup.
+//
+// Granger--Scott's cyclotomic square
+// https://eprint.iacr.org/2009/565.pdf, 3.2
+func (e Ext6) CyclotomicSquare(x *E6) *E6 {
+	x = e.Reduce(x)
+	two := big.NewInt(2)
+
+	t0 := e.fp.Mul(&x.A3, &x.A3)
+	t1 := e.fp.Mul(&x.A0, &x.A0)
+	t6 := e.fp.Add(&x.A3, &x.A0)
+	t6 = e.fp.Mul(t6, t6)
+	t6 = e.fp.Sub(t6, e.fp.Add(t0, t1))
+	t2 := e.fp.Mul(&x.A4, &x.A4)
+	t3 := e.fp.Mul(&x.A1, &x.A1)
+	t7 := e.fp.Add(&x.A4, &x.A1)
+	t7 = e.fp.Mul(t7, t7)
+	t7 = e.fp.Sub(t7, e.fp.Add(t2, t3))
+	t4 := e.fp.Mul(&x.A5, &x.A5)
+	t5 := e.fp.Mul(&x.A2, &x.A2)
+	t8 := e.fp.Add(&x.A5, &x.A2)
+	t8 = e.fp.Mul(t8, t8)
+	t8 = e.fp.Sub(t8, e.fp.Add(t4, t5))
+	t8 = e.mulFpByNonResidue(e.fp, t8)
+
+	t0 = e.fp.Add(e.mulFpByNonResidue(e.fp, t0), t1)
+	t2 = e.fp.Add(e.mulFpByNonResidue(e.fp, t2), t3)
+	t4 = e.fp.Add(e.mulFpByNonResidue(e.fp, t4), t5)
+
+	z0 := e.fp.Add(e.fp.MulConst(e.fp.Sub(t0, &x.A0), two), t0)
+	z2 := e.fp.Add(e.fp.MulConst(e.fp.Sub(t2, &x.A2), two), t2)
+	z4 := e.fp.Add(e.fp.MulConst(e.fp.Sub(t4, &x.A4), two), t4)
+	z1 := e.fp.Add(e.fp.MulConst(e.fp.Add(t8, &x.A1), two), t8)
+	z3 := e.fp.Add(e.fp.MulConst(e.fp.Add(t6, &x.A3), two), t6)
+	z5 := e.fp.Add(e.fp.MulConst(e.fp.Add(t7, &x.A5), two), t7)
+
+	return &E6{
+		A0: *z0,
+		A1: *z1,
+		A2: *z2,
+		A3: *z3,
+		A4: *z4,
+		A5: *z5,
+	}
+}
+
+func (e Ext6) Inverse(x *E6) *E6 {
+	res, err := e.fp.NewHint(inverseE6Hint, 6, &x.A0, &x.A1, &x.A2, &x.A3, &x.A4, &x.A5)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+
+	inv := E6{A0: *res[0], A1: *res[1], A2: *res[2], A3: *res[3], A4: *res[4], A5: *res[5]}
+	one := e.One()
+
+	// 1 == inv * x
+	_one := e.Mul(&inv, x)
+	e.AssertIsEqual(one, _one)
+
+	return &inv
+
+}
+
+func (e Ext6) DivUnchecked(x, y *E6) *E6 {
+	res, err := e.fp.NewHint(divE6Hint, 12, &x.A0, &x.A1, &x.A2, &x.A3, &x.A4, &x.A5, &y.A0, &y.A1, &y.A2, &y.A3, &y.A4, &y.A5)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+
+	div := E6{A0: *res[0], A1: *res[1], A2: *res[2], A3: *res[3], A4: *res[4], A5: *res[5]}
+
+	// x = div * y
+	_x := e.Mul(&div, y)
+	e.AssertIsEqual(x, _x)
+
+	return &div
+
+}
+
+func (e Ext6) AssertIsEqual(a, b *E6) {
+	e.fp.AssertIsEqual(&a.A0, &b.A0)
+	e.fp.AssertIsEqual(&a.A1, &b.A1)
+	e.fp.AssertIsEqual(&a.A2, &b.A2)
+	e.fp.AssertIsEqual(&a.A3, &b.A3)
+	e.fp.AssertIsEqual(&a.A4, &b.A4)
+	e.fp.AssertIsEqual(&a.A5, &b.A5)
+
+}
+
+func (e Ext6) Copy(x *E6) *E6 {
+	return &E6{
+		A0: x.A0,
+		A1: x.A1,
+		A2: x.A2,
+		A3: x.A3,
+		A4: x.A4,
+		A5: x.A5,
+	}
+}
+
+func FromE6(a *bw6633.GT) E6 {
+	// gnark-crypto uses a quadratic over cubic sextic extension of Fp.
+	// The two towers are isomorphic and the coefficients are permuted as follows:
+	// 		a00 a01 a02 a10 a11 a12
+	// 		A0  A2  A4  A1  A3  A5
+	return E6{
+		A0: emulated.ValueOf[emulated.BW6633Fp](a.B0.A0),
+		A1: emulated.ValueOf[emulated.BW6633Fp](a.B1.A0),
+		A2: emulated.ValueOf[emulated.BW6633Fp](a.B0.A1),
+		A3: emulated.ValueOf[emulated.BW6633Fp](a.B1.A1),
+		A4: emulated.ValueOf[emulated.BW6633Fp](a.B0.A2),
+		A5: emulated.ValueOf[emulated.BW6633Fp](a.B1.A2),
+	}
+}
+
+func (e Ext6) Select(selector frontend.Variable, z1, z0 *E6) *E6 {
+	a0 := e.fp.Select(selector, &z1.A0, &z0.A0)
+	a1 := e.fp.Select(selector, &z1.A1, &z0.A1)
+	a2 := e.fp.Select(selector, &z1.A2, &z0.A2)
+	a3 := e.fp.Select(selector, &z1.A3, &z0.A3)
+	a4 := e.fp.Select(selector, &z1.A4, &z0.A4)
+	a5 := e.fp.Select(selector, &z1.A5, &z0.A5)
+
+	return &E6{A0: *a0, A1: *a1, A2: *a2, A3: *a3, A4: *a4, A5: *a5}
+}
+
+// Frobenius set z in E6 to Frobenius(x), return z
+func (e Ext6) Frobenius(x *E6) *E6 {
+	_frobA := emulated.ValueOf[emulated.BW6633Fp]("4098895725012429242072311240482566844345873033931481129362557724405008256668293241245050359832461015092695507587185678086043587575438449040313411246717257958467499181450742260777082884928318")
+	_frobB := emulated.ValueOf[emulated.BW6633Fp]("16395582919155345436741076146056394653323717886977296946166196826607008495049498537498484690527540372326881062657221127377212177459029992142458645083304465140194468247889229480289176510057678")
+	_frobC := emulated.ValueOf[emulated.BW6633Fp]("4098895725012429242072311240482566844345873033931481129362557724405008256668293241245050359832461015092695507587185678086043587575438449040313411246717257958467499181450742260777082884928319")
+	_frobBC := emulated.ValueOf[emulated.BW6633Fp]("16395582919155345436741076146056394653323717886977296946166196826607008495049498537498484690527540372326881062657221127377212177459029992142458645083304465140194468247889229480289176510057679")
+	var z E6
+	z.A0 = x.A0
+	z.A2 = *e.fp.Mul(&x.A2, &_frobA)
+	z.A4 = *e.fp.Mul(&x.A4, &_frobB)
+	z.A1 = *e.fp.Mul(&x.A1, &_frobC)
+	z.A3 = *e.fp.Neg(&x.A3)
+	z.A5 = *e.fp.Mul(&x.A5, &_frobBC)
+
+	return &z
+}
@@ -0,0 +1,247 @@
+package fields_bw6633
+
+import (
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func (e Ext6) nSquare(z *E6, n int) *E6 {
+	for i := 0; i < n; i++ {
+		z = e.CyclotomicSquare(z)
+	}
+	return z
+}
+
+// ExpX0 set z to z^x₀ in E6 and return z
+// x₀ = -3218079743 = -2³²+2³⁰+2²²-2²⁰+1
+func (e Ext6) ExpX0(z *E6) *E6 {
+	z = e.Reduce(z)
+	result := e.nSquare(z, 20)
+	x20 := e.Conjugate(result)
+	result = e.nSquare(result, 2)
+	x22 := e.Copy(result)
+	result = e.nSquare(result, 8)
+	x30 := e.Copy(result)
+	x32 := e.nSquare(x30, 2)
+	x32 = e.Conjugate(x32)
+
+	result = e.Mul(z, x20)
+	result = e.Mul(result, x22)
+	result = e.Mul(result, x30)
+	result = e.Mul(result, x32)
+
+	return result
+}
+
+// ExpX0Minus1 set z to z^(x₀-1) in E6 and return z
+// x₀-1 = -3218079744
+func (e Ext6) ExpX0Minus1(z *E6) *E6 {
+	result := e.ExpX0(z)
+	result = e.Mul(result, e.Conjugate(z))
+
+	return result
+}
+
+// ExpX0Plus1 set z to z^(x₀+1) in E6 and return z
+// x₀+1 = -3218079742
+func (e Ext6) ExpX0Plus1(z *E6) *E6 {
+	result := e.ExpX0(z)
+	result = e.Mul(result, z)
+
+	return result
+}
+
+// ExpX0Minus1Square set z to z^((x₀-1)²) in E6 and return z
+// (x₀-1)² = 10356037238743105536
+func (e Ext6) ExpX0Minus1Square(z *E6) *E6 {
+	z = e.Reduce(z)
+	result := e.nSquare(z, 2)
+	t1 := e.Mul(z, result)
+	result = e.Mul(result, t1)
+	t0 := e.CyclotomicSquare(result)
+	t0 = e.Mul(t1, t0)
+	t2 := e.CyclotomicSquare(t0)
+	t2 = e.Mul(t0, t2)
+	t2 = e.CyclotomicSquare(t2)
+	t1 = e.Mul(t1, t2)
+	t1 = e.nSquare(t1, 5)
+	t0 = e.Mul(t0, t1)
+	t0 = e.nSquare(t0, 11)
+	result = e.Mul(result, t0)
+	result = e.nSquare(result, 40)
+
+	return result
+}
+
+// ExpX0SquarePlus1 set z to z^(x₀²+1) in E6 and return z
+// x₀²+1 = 10356037232306946050
+func (e Ext6) ExpX0SquarePlus1(z *E6) *E6 {
+	z = e.Reduce(z)
+	t0 := e.CyclotomicSquare(z)
+	result := e.Mul(z, t0)
+	t0 = e.Mul(t0, result)
+	t1 := e.Mul(z, t0)
+	t0 = e.Mul(t0, t1)
+	t0 = e.CyclotomicSquare(t0)
+	t2 := e.Mul(z, t0)
+	t0 = e.Mul(t1, t2)
+	t1 = e.Mul(t1, t0)
+	t1 = e.nSquare(t1, 2)
+	t1 = e.Mul(result, t1)
+	t3 := e.nSquare(t1, 5)
+	t2 = e.Mul(t2, t3)
+	t2 = e.nSquare(t2, 15)
+	t1 = e.Mul(t1, t2)
+	t1 = e.nSquare(t1, 5)
+	t0 = e.Mul(t0, t1)
+	t0 = e.nSquare(t0, 10)
+	result = e.Mul(result, t0)
+	result = e.nSquare(result, 20)
+	result = e.Mul(z, result)
+	result = e.CyclotomicSquare(result)
+
+	return result
+}
+
+// ExptMinus1Div3 set z to z^((x₀-1)/3) in E6 and return z
+// (x₀-1)/3 = -1072693248
+func (e Ext6) ExptMinus1Div3(z *E6) *E6 {
+	z = e.Reduce(z)
+	result := e.CyclotomicSquare(z)
+	result = e.Mul(z, result)
+	t0 := e.nSquare(result, 2)
+	t0 = e.Mul(result, t0)
+	t1 := e.nSquare(t0, 4)
+	t0 = e.Mul(t0, t1)
+	t0 = e.nSquare(t0, 2)
+	result = e.Mul(result, t0)
+	result = e.nSquare(result, 20)
+	result = e.Conjugate(result)
+
+	return result
+}
+
+// ExpC1 set z to z^C1 in E6 and return z
+// ht, hy = -7, -1
+// C1 = (ht-hy)/2 = -3
+func (e Ext6) ExpC1(z *E6) *E6 {
+	z = e.Reduce(z)
+	result := e.CyclotomicSquare(z)
+	result = e.Mul(z, result)
+	result = e.Conjugate(result)
+
+	return result
+}
+
+// ExpC2 set z to z^C2 in E6 and return z
+// ht, hy = -7, -1
+// C2 = (ht**2+3*hy**2)/4 = 13
+func (e Ext6) ExpC2(z *E6) *E6 {
+	z = e.Reduce(z)
+	result := e.CyclotomicSquare(z)
+	result = e.Mul(z, result)
+	result = e.nSquare(result, 2)
+	result = e.Mul(z, result)
+
+	return result
+}
+
+// mulE3By01 multiplies an element of 𝔽p³ by the sparse element c0+c1*u.
+func (e Ext6) mulE3By01(x [3]*baseEl, c0, c1 *baseEl) [3]*baseEl {
+	a := e.fp.Mul(x[0], c0)
+	b := e.fp.Mul(x[1], c1)
+
+	t0 := e.fp.Mul(c1, e.fp.Add(x[1], x[2]))
+	t0 = e.fp.Sub(t0, b)
+	t0 = e.fp.Add(e.mulFpByNonResidue(e.fp, t0), a)
+
+	t2 := e.fp.Mul(c0, e.fp.Add(x[0], x[2]))
+	t2 = e.fp.Sub(t2, a)
+	t2 = e.fp.Add(t2, b)
+
+	t1 := e.fp.Mul(e.fp.Add(c0, c1), e.fp.Add(x[0], x[1]))
+	t1 = e.fp.Sub(t1, e.fp.Add(a, b))
+
+	return [3]*baseEl{t0, t1, t2}
+}
+
+// mulE3By12 multiplies an element of 𝔽p³ by the sparse element b1*u+b2*u².
+func (e Ext6) mulE3By12(x [3]*baseEl, b1, b2 *baseEl) [3]*baseEl {
+	t1 := e.fp.Mul(x[1], b1)
+	t2 := e.fp.Mul(x[2], b2)
+
+	c0 := e.fp.Mul(e.fp.Add(x[1], x[2]), e.fp.Add(b1, b2))
+	c0 = e.fp.Sub(c0, e.fp.Add(t1, t2))
+	c0 = e.mulFpByNonResidue(e.fp, c0)
+
+	c1 := e.fp.Mul(e.fp.Add(x[0], x[1]), b1)
+	c1 = e.fp.Sub(c1, t1)
+	c1 = e.fp.Add(c1, e.mulFpByNonResidue(e.fp, t2))
+
+	c2 := e.fp.Mul(e.fp.Add(x[0], x[2]), b2)
+	c2 = e.fp.Sub(c2, t2)
+	c2 = e.fp.Add(c2, t1)
+
+	return [3]*baseEl{c0, c1, c2}
+}
+
+// MulBy023 multiplies z by an E6 sparse element of the form
+//
+//	E6{A0: c0, A1: 0, A2: c1, A3: 1,  A4: 0,  A5: 0}
+func (e *Ext6) MulBy023(z *E6, c0, c1 *baseEl) *E6 {
+	z = e.Reduce(z)
+	z0, z1 := toE3(z)
+
+	a := e.mulE3By01(z0, c0, c1)
+	b := e.mulE3ByNonResidue(z1)
+	d := e.fp.Add(c1, e.fp.One())
+	c := e.mulE3By01(e.addE3(z0, z1), c0, d)
+	c = e.subE3(c, e.addE3(a, b))
+	a = e.addE3(a, e.mulE3ByNonResidue(b))
+
+	return fromE3(a, c)
+}
+
+//	Mul023By023 multiplies two E6 sparse element of the form:
+//
+//	E6{A0: c0, A1: 0, A2: c1, A3: 1,  A4: 0,  A5: 0}
+//
+// and
+//
+//	E6{A0: d0, A1: 0, A2: d1, A3: 1,  A4: 0,  A5: 0}
+//
+// and returns the coefficients [A0, A2, A3, A4, A5] of the product.
+func (e Ext6) Mul023By023(d0, d1, c0, c1 *baseEl) [5]*baseEl {
+	x0 := e.fp.Mul(c0, d0)
+	x1 := e.fp.Mul(c1, d1)
+	x04 := e.fp.Add(c0, d0)
+	tmp := e.fp.Add(c0, c1)
+	x01 := e.fp.Add(d0, d1)
+	x01 = e.fp.Mul(x01, tmp)
+	tmp = e.fp.Add(x0, x1)
+	x01 = e.fp.Sub(x01, tmp)
+	x14 := e.fp.Add(c1, d1)
+
+	two := emulated.ValueOf[emulated.BW6633Fp](2)
+	zC0B0 := e.fp.Add(x0, &two)
+
+	return [5]*baseEl{zC0B0, x01, x04, x1, x14}
+}
+
+// MulBy02345 multiplies z by an E6 sparse element of the form
+//
+//	E6{A0: y0, A1: 0, A2: y1, A3: y2, A4: y3, A5: y4},
+func (e *Ext6) MulBy02345(z *E6, x [5]*baseEl) *E6 {
+	z = e.Reduce(z)
+	z0, z1 := toE3(z)
+	y0 := [3]*baseEl{x[0], x[1], x[3]}
+	zero := e.fp.Zero()
+	y1 := [3]*baseEl{zero, x[2], x[4]}
+
+	a := e.mulE3(z0, y0)
+	b := e.mulE3By12(z1, x[2], x[4])
+	c := e.mulE3(e.addE3(z0, z1), e.addE3(y0, y1))
+	c = e.subE3(c, e.addE3(a, b))
+	a = e.addE3(a, e.mulE3ByNonResidue(b))
+
+	return fromE3(a, c)
+}
@@ -0,0 +1,468 @@
+package fields_bw6633
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type e6Add struct {
+	A, B, C E6
+}
+
+func (circuit *e6Add) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Add(&circuit.A, &circuit.B)
+	e.AssertIsEqual(&expected, &circuit.C)
+	return nil
+}
+
+func TestAddFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bw6633.GT
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Add(&a, &b)
+
+	witness := e6Add{
+		A: FromE6(&a),
+		B: FromE6(&b),
+		C: FromE6(&c),
+	}
+
+	err := test.IsSolved(&e6Add{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Sub struct {
+	A, B, C E6
+}
+
+func (circuit *e6Sub) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Sub(&circuit.A, &circuit.B)
+	e.AssertIsEqual(&expected, &circuit.C)
+	return nil
+}
+
+func TestSubFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bw6633.GT
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Sub(&a, &b)
+
+	witness := e6Sub{
+		A: FromE6(&a),
+		B: FromE6(&b),
+		C: FromE6(&c),
+	}
+
+	err := test.IsSolved(&e6Sub{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Double struct {
+	A, B E6
+}
+
+func (circuit *e6Double) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Double(&circuit.A)
+	e.AssertIsEqual(&expected, &circuit.B)
+	return nil
+}
+
+func TestDoubleFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+	b.Double(&a)
+
+	witness := e6Double{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6Double{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Mul struct {
+	A, B, C E6
+}
+
+func (circuit *e6Mul) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Mul(&circuit.A, &circuit.B)
+	e.AssertIsEqual(&expected, &circuit.C)
+	return nil
+}
+
+func TestMulFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bw6633.GT
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Mul(&a, &b)
+
+	witness := e6Mul{
+		A: FromE6(&a),
+		B: FromE6(&b),
+		C: FromE6(&c),
+	}
+
+	err := test.IsSolved(&e6Mul{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Square struct {
+	A, B E6
+}
+
+func (circuit *e6Square) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Square(&circuit.A)
+	e.AssertIsEqual(&expected, &circuit.B)
+	return nil
+}
+
+func TestSquareFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+	b.Square(&a)
+
+	witness := e6Square{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6Square{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Inverse struct {
+	A, B E6
+}
+
+func (circuit *e6Inverse) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Inverse(&circuit.A)
+	e.AssertIsEqual(&expected, &circuit.B)
+	return nil
+}
+
+func TestInverseFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+	b.Inverse(&a)
+
+	witness := e6Inverse{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6Inverse{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Div struct {
+	A, B, C E6
+}
+
+func (circuit *e6Div) Define(api frontend.API) error {
+	e := NewExt6(api)
+	expected := e.DivUnchecked(&circuit.A, &circuit.B)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestDivFp6(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b, c bw6633.GT
+	_, _ = a.SetRandom()
+	_, _ = b.SetRandom()
+	c.Inverse(&b)
+	c.Mul(&a, &c)
+
+	witness := e6Div{
+		A: FromE6(&a),
+		B: FromE6(&b),
+		C: FromE6(&c),
+	}
+
+	err := test.IsSolved(&e6Div{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+}
+
+type e6Conjugate struct {
+	A, B E6
+}
+
+func (circuit *e6Conjugate) Define(api frontend.API) error {
+	var expected E6
+	e := NewExt6(api)
+	expected = *e.Conjugate(&circuit.A)
+	e.AssertIsEqual(&expected, &circuit.B)
+	return nil
+}
+
+func TestConjugateFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+	b.Conjugate(&a)
+
+	witness := e6Conjugate{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6Conjugate{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Expt struct {
+	A, B E6
+}
+
+func (circuit *e6Expt) Define(api frontend.API) error {
+	e := NewExt6(api)
+	expected := e.ExpX0(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.B)
+	return nil
+}
+
+func TestExptFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	var tmp bw6633.GT
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.Frobenius(&tmp).Mul(&a, &tmp)
+
+	b.Expt(&a)
+
+	witness := e6Expt{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6Expt{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6MulBy023 struct {
+	A    E6 `gnark:",public"`
+	W    E6
+	B, C baseEl
+}
+
+func (circuit *e6MulBy023) Define(api frontend.API) error {
+	e := NewExt6(api)
+	res := e.MulBy023(&circuit.A, &circuit.B, &circuit.C)
+	e.AssertIsEqual(res, &circuit.W)
+	return nil
+}
+
+func TestFp6MulBy023(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, w bw6633.GT
+	_, _ = a.SetRandom()
+	var b, c fp.Element
+	_, _ = b.SetRandom()
+	_, _ = c.SetRandom()
+	w.Set(&a)
+	w.MulBy01(&b, &c)
+
+	witness := e6MulBy023{
+		A: FromE6(&a),
+		B: emulated.ValueOf[emulated.BW6633Fp](&b),
+		C: emulated.ValueOf[emulated.BW6633Fp](&c),
+		W: FromE6(&w),
+	}
+
+	err := test.IsSolved(&e6MulBy023{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6CyclotomicSquare struct {
+	A, B E6
+}
+
+func (circuit *e6CyclotomicSquare) Define(api frontend.API) error {
+	e := NewExt6(api)
+	expected := e.CyclotomicSquare(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.B)
+	return nil
+}
+
+func TestCyclotomicSquareFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	var tmp bw6633.GT
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.Frobenius(&tmp).Mul(&a, &tmp)
+
+	b.CyclotomicSquare(&a)
+
+	witness := e6CyclotomicSquare{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6CyclotomicSquare{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Frobenius struct {
+	A, B E6
+}
+
+func (circuit *e6Frobenius) Define(api frontend.API) error {
+	e := NewExt6(api)
+	expected := e.Frobenius(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.B)
+	return nil
+}
+
+func TestFrobeniusFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, b bw6633.GT
+	_, _ = a.SetRandom()
+	b.Frobenius(&a)
+
+	witness := e6Frobenius{
+		A: FromE6(&a),
+		B: FromE6(&b),
+	}
+
+	err := test.IsSolved(&e6Frobenius{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6ExpChains struct {
+	A                          E6
+	M1, P1, M1Sq, SqP1, M1Div3 E6
+}
+
+func (circuit *e6ExpChains) Define(api frontend.API) error {
+	e := NewExt6(api)
+	e.AssertIsEqual(e.ExpX0Minus1(&circuit.A), &circuit.M1)
+	e.AssertIsEqual(e.ExpX0Plus1(&circuit.A), &circuit.P1)
+	e.AssertIsEqual(e.ExpX0Minus1Square(&circuit.A), &circuit.M1Sq)
+	e.AssertIsEqual(e.ExpX0SquarePlus1(&circuit.A), &circuit.SqP1)
+	e.AssertIsEqual(e.ExptMinus1Div3(&circuit.A), &circuit.M1Div3)
+	return nil
+}
+
+func TestExpChainsFp6(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a bw6633.GT
+	_, _ = a.SetRandom()
+
+	// put a in the cyclotomic subgroup
+	var tmp bw6633.GT
+	tmp.Conjugate(&a)
+	a.Inverse(&a)
+	tmp.Mul(&tmp, &a)
+	a.Frobenius(&tmp).Mul(&a, &tmp)
+
+	var m1, p1, m1sq, sqp1, m1div3 bw6633.GT
+	m1.ExptMinus1(&a)
+	p1.ExptPlus1(&a)
+	m1sq.ExptMinus1Squared(&a)
+	sqp1.ExptSquarePlus1(&a)
+	m1div3.ExptMinus1Div3(&a)
+
+	witness := e6ExpChains{
+		A:      FromE6(&a),
+		M1:     FromE6(&m1),
+		P1:     FromE6(&p1),
+		M1Sq:   FromE6(&m1sq),
+		SqP1:   FromE6(&sqp1),
+		M1Div3: FromE6(&m1div3),
+	}
+
+	err := test.IsSolved(&e6ExpChains{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e6Mul023By023 struct {
+	A          E6 `gnark:",public"`
+	W          E6
+	B, C, D, F baseEl
+}
+
+func (circuit *e6Mul023By023) Define(api frontend.API) error {
+	e := NewExt6(api)
+	prod := e.Mul023By023(&circuit.B, &circuit.C, &circuit.D, &circuit.F)
+	res := e.MulBy02345(&circuit.A, prod)
+	e.AssertIsEqual(res, &circuit.W)
+	return nil
+}
+
+func TestFp6Mul023By023(t *testing.T) {
+	assert := test.NewAssert(t)
+	// witness values
+	var a, w bw6633.GT
+	_, _ = a.SetRandom()
+	var b, c, d, f fp.Element
+	_, _ = b.SetRandom()
+	_, _ = c.SetRandom()
+	_, _ = d.SetRandom()
+	_, _ = f.SetRandom()
+	w.Set(&a)
+	w.MulBy01(&b, &c)
+	w.MulBy01(&d, &f)
+
+	witness := e6Mul023By023{
+		A: FromE6(&a),
+		B: emulated.ValueOf[emulated.BW6633Fp](&b),
+		C: emulated.ValueOf[emulated.BW6633Fp](&c),
+		D: emulated.ValueOf[emulated.BW6633Fp](&d),
+		F: emulated.ValueOf[emulated.BW6633Fp](&f),
+		W: FromE6(&w),
+	}
+
+	err := test.IsSolved(&e6Mul023By023{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
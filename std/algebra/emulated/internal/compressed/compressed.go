@@ -0,0 +1,68 @@
+// Package compressed implements the decoding of the compressed point encodings
+// of gnark-crypto, shared by the emulated curves.
+package compressed
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Metadata decodes the metadata stored in the most significant bits of the
+// first byte b of the encoding, where nbSpareBits is the number of unused bits
+// in the encoding of a coordinate. When there are at least three spare bits,
+// the bits are (compressed, infinity, largest), i.e. 0b100 for the smallest y,
+// 0b101 for the largest y and 0b110 for the point at infinity. Otherwise they
+// are (largest or infinity, compressed or infinity), i.e. 0b10 for the
+// smallest y, 0b11 for the largest y and 0b01 for the point at infinity.
+//
+// It asserts that the metadata is valid and returns the remaining bits of b in
+// little-endian order.
+func Metadata(api frontend.API, b uints.U8, nbSpareBits int) (isInfinity, isLargest frontend.Variable, low []frontend.Variable) {
+	msb := bits.ToBinary(api, b.Val, bits.WithNbDigits(8))
+	if nbSpareBits >= 3 {
+		api.AssertIsEqual(msb[7], 1)
+		isInfinity, isLargest = msb[6], msb[5]
+		api.AssertIsEqual(api.And(isInfinity, isLargest), 0)
+		return isInfinity, isLargest, msb[:5]
+	}
+	api.AssertIsEqual(api.Or(msb[7], msb[6]), 1)
+	isInfinity = api.Sub(1, msb[7])
+	isLargest = api.And(msb[7], msb[6])
+	return isInfinity, isLargest, msb[:6]
+}
+
+// Element returns the element whose big-endian encoding is data, where the
+// most significant bits are given by msb in little-endian order. It asserts
+// that the element is reduced.
+func Element[T emulated.FieldParams](api frontend.API, f *emulated.Field[T], msb []frontend.Variable, data []uints.U8) *emulated.Element[T] {
+	bs := make([]frontend.Variable, 0, 8*len(data)+len(msb))
+	for i := len(data) - 1; i >= 0; i-- {
+		bs = append(bs, bits.ToBinary(api, data[i].Val, bits.WithNbDigits(8))...)
+	}
+	bs = append(bs, msb...)
+	x := f.FromBits(bs...)
+	f.AssertIsInRange(x)
+	return x
+}
+
+// IsLexicographicallyLargest returns 1 if y > (p-1)/2 and 0 otherwise. For
+// y ∈ [0, p) this is the case if and only if 2y mod p is odd.
+func IsLexicographicallyLargest[T emulated.FieldParams](f *emulated.Field[T], y *emulated.Element[T]) frontend.Variable {
+	return f.ToBitsCanonical(f.Add(y, y))[0]
+}
+
+// NegateE2Root returns 1 if the root y = y0 + y1⋅u of the quadratic extension
+// must be negated so that it is lexicographically largest if and only if
+// isLargest is set, and 0 otherwise. y is lexicographically largest if y1 is,
+// or if y1 is zero and y0 is. As -0 = 0, it asserts that isLargest is not set
+// when y is zero.
+func NegateE2Root[T emulated.FieldParams](api frontend.API, f *emulated.Field[T], y0, y1 *emulated.Element[T], isLargest frontend.Variable) frontend.Variable {
+	isLargestY0 := IsLexicographicallyLargest(f, y0)
+	isLargestY1 := IsLexicographicallyLargest(f, y1)
+	y1IsZero := f.IsZero(y1)
+	yIsLargest := api.Select(y1IsZero, isLargestY0, isLargestY1)
+	api.AssertIsEqual(api.And(isLargest, api.And(f.IsZero(y0), y1IsZero)), 0)
+	return api.Xor(yIsLargest, isLargest)
+}
@@ -0,0 +1,12 @@
+// Package sw_bls12377 implements G1 and G2 arithmetics and pairing computation
+// over BLS12-377 curve using field emulation.
+//
+// Contrary to [github.com/consensys/gnark/std/algebra/native/sw_bls12377],
+// which is efficient only when the native field is the BW6-761 scalar field,
+// this package allows to compute BLS12-377 pairings in circuits defined over
+// any native field (e.g. BN254).
+//
+// The implementation follows [Housni22]: "Pairings in Rank-1 Constraint Systems".
+//
+// [Housni22]: https://eprint.iacr.org/2022/1162
+package sw_bls12377
@@ -0,0 +1,454 @@
+package sw_bls12377
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12377"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+type Pairing struct {
+	api frontend.API
+	*fields_bls12377.Ext12
+	curveF *emulated.Field[BaseField]
+	curve  *sw_emulated.Curve[BaseField, ScalarField]
+	g2     *G2
+	g1     *G1
+	bTwist *fields_bls12377.E2
+}
+
+type GTEl = fields_bls12377.E12
+
+func NewGTEl(v bls12377.GT) GTEl {
+	return GTEl{
+		C0: fields_bls12377.E6{
+			B0: fields_bls12377.E2{
+				A0: emulated.ValueOf[BaseField](v.C0.B0.A0),
+				A1: emulated.ValueOf[BaseField](v.C0.B0.A1),
+			},
+			B1: fields_bls12377.E2{
+				A0: emulated.ValueOf[BaseField](v.C0.B1.A0),
+				A1: emulated.ValueOf[BaseField](v.C0.B1.A1),
+			},
+			B2: fields_bls12377.E2{
+				A0: emulated.ValueOf[BaseField](v.C0.B2.A0),
+				A1: emulated.ValueOf[BaseField](v.C0.B2.A1),
+			},
+		},
+		C1: fields_bls12377.E6{
+			B0: fields_bls12377.E2{
+				A0: emulated.ValueOf[BaseField](v.C1.B0.A0),
+				A1: emulated.ValueOf[BaseField](v.C1.B0.A1),
+			},
+			B1: fields_bls12377.E2{
+				A0: emulated.ValueOf[BaseField](v.C1.B1.A0),
+				A1: emulated.ValueOf[BaseField](v.C1.B1.A1),
+			},
+			B2: fields_bls12377.E2{
+				A0: emulated.ValueOf[BaseField](v.C1.B2.A0),
+				A1: emulated.ValueOf[BaseField](v.C1.B2.A1),
+			},
+		},
+	}
+}
+
+func NewPairing(api frontend.API) (*Pairing, error) {
+	ba, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	curve, err := sw_emulated.New[BaseField, ScalarField](api, sw_emulated.GetBLS12377Params())
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	// b' = 1/u
+	bTwist := fields_bls12377.E2{
+		A0: emulated.ValueOf[BaseField]("0"),
+		A1: emulated.ValueOf[BaseField]("155198655607781456406391640216936120121836107652948796323930557600032281009004493664981332883744016074664192874906"),
+	}
+	g1, err := NewG1(api)
+	if err != nil {
+		return nil, fmt.Errorf("new G1 struct: %w", err)
+	}
+	return &Pairing{
+		api:    api,
+		Ext12:  fields_bls12377.NewExt12(api),
+		curveF: ba,
+		curve:  curve,
+		g1:     g1,
+		g2:     NewG2(api),
+		bTwist: &bTwist,
+	}, nil
+}
+
+// FinalExponentiation computes the exponentiation (∏ᵢ zᵢ)ᵈ where
+//
+//	d = (p¹²-1)/r = (p¹²-1)/Φ₁₂(p) ⋅ Φ₁₂(p)/r = (p⁶-1)(p²+1)(p⁴ - p² +1)/r
+//
+// we use instead
+//
+//	d=s ⋅ (p⁶-1)(p²+1)(p⁴ - p² +1)/r
+//
+// where s is the cofactor 3 (Hayashida et al.).
+func (pr Pairing) FinalExponentiation(e *GTEl) *GTEl {
+
+	// 1. Easy part
+	// (p⁶-1)(p²+1)
+	t0 := pr.Ext12.Conjugate(e)
+	t0 = pr.Ext12.DivUnchecked(t0, e)
+	result := pr.FrobeniusSquare(t0)
+	result = pr.Ext12.Mul(result, t0)
+
+	// 2. Hard part (up to permutation)
+	// Daiki Hayashida and Kenichiro Hayasaka
+	// and Tadanori Teruya
+	// https://eprint.iacr.org/2020/875.pdf
+	t0 = pr.ExpX0Minus1Square(result)
+	t1 := pr.ExpX0(t0)
+	t2 := pr.Frobenius(t0)
+	t0 = pr.Ext12.Mul(t1, t2)
+	t1 = pr.ExpX0(t0)
+	t1 = pr.ExpX0(t1)
+	t2 = pr.FrobeniusSquare(t0)
+	t3 := pr.Ext12.Conjugate(t0)
+	t1 = pr.Ext12.Mul(t1, t2)
+	t1 = pr.Ext12.Mul(t1, t3)
+	t0 = pr.CyclotomicSquare(result)
+	t0 = pr.Ext12.Mul(t0, result)
+
+	return pr.Ext12.Mul(t1, t0)
+}
+
+// Pair calculates the reduced pairing for a set of points
+// ∏ᵢ e(Pᵢ, Qᵢ).
+//
+// This function doesn't check that the inputs are in the correct subgroups. See AssertIsOnG1 and AssertIsOnG2.
+func (pr Pairing) Pair(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
+	res, err := pr.MillerLoop(P, Q)
+	if err != nil {
+		return nil, fmt.Errorf("miller loop: %w", err)
+	}
+	res = pr.FinalExponentiation(res)
+	return res, nil
+}
+
+// PairingCheck calculates the reduced pairing for a set of points and asserts if the result is One
+// ∏ᵢ e(Pᵢ, Qᵢ) =? 1
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheck(P []*G1Affine, Q []*G2Affine) error {
+	f, err := pr.MillerLoop(P, Q)
+	if err != nil {
+		return err
+
+	}
+	// We perform the easy part of the final exp to push f to the cyclotomic
+	// subgroup so that AssertFinalExponentiationIsOne is carried with optimized
+	// cyclotomic squaring.
+	//
+	// f = f^(p⁶-1)(p²+1)
+	buf := pr.Conjugate(f)
+	buf = pr.DivUnchecked(buf, f)
+	f = pr.FrobeniusSquare(buf)
+	f = pr.Mul(f, buf)
+
+	pr.AssertFinalExponentiationIsOne(f)
+
+	return nil
+}
+
+func (pr Pairing) AssertIsEqual(x, y *GTEl) {
+	pr.Ext12.AssertIsEqual(x, y)
+}
+
+func (pr Pairing) AssertIsOnCurve(P *G1Affine) {
+	pr.curve.AssertIsOnCurve(P)
+}
+
+func (pr Pairing) AssertIsOnTwist(Q *G2Affine) {
+	// Twist: Y² == X³ + aX + b, where a=0 and b=1/u
+	// (X,Y) ∈ {Y² == X³ + aX + b} U (0,0)
+
+	// if Q=(0,0) we assign b=0 otherwise 1/u, and continue
+	selector := pr.api.And(pr.Ext2.IsZero(&Q.P.X), pr.Ext2.IsZero(&Q.P.Y))
+	b := pr.Ext2.Select(selector, pr.Ext2.Zero(), pr.bTwist)
+
+	left := pr.Ext2.Square(&Q.P.Y)
+	right := pr.Ext2.Square(&Q.P.X)
+	right = pr.Ext2.Mul(right, &Q.P.X)
+	right = pr.Ext2.Add(right, b)
+	pr.Ext2.AssertIsEqual(left, right)
+}
+
+func (pr Pairing) AssertIsOnG1(P *G1Affine) {
+	// 1- Check P is on the curve
+	pr.AssertIsOnCurve(P)
+
+	// 2- Check P has the right subgroup order
+	// [x²]ϕ(P)
+	phiP := pr.g1.phi(P)
+	_P := pr.g1.scalarMulBySeed(phiP)
+	_P = pr.g1.scalarMulBySeed(_P)
+	_P = pr.curve.Neg(_P)
+
+	// [r]Q == 0 <==>  P = -[x²]ϕ(P)
+	pr.curve.AssertIsEqual(_P, P)
+}
+
+func (pr Pairing) AssertIsOnG2(Q *G2Affine) {
+	// 1- Check Q is on the curve
+	pr.AssertIsOnTwist(Q)
+
+	// 2- Check Q has the right subgroup order
+	// [x₀]Q
+	xQ := pr.g2.scalarMulBySeed(Q)
+	// ψ(Q)
+	psiQ := pr.g2.psi(Q)
+
+	// [r]Q == 0 <==>  ψ(Q) == [x₀]Q
+	pr.g2.AssertIsEqual(xQ, psiQ)
+}
+
+// loopCounter = seed in binary
+//
+//	seed=9586122913090633729
+var loopCounter = bls12377.LoopCounter
+
+// MillerLoop computes the multi-Miller loop
+// ∏ᵢ { fᵢ_{u,Q}(P) }
+func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
+
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(Q) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	lines := make([]lineEvaluations, len(Q))
+	for i := range Q {
+		if Q[i].Lines == nil {
+			Qlines := pr.computeLines(&Q[i].P)
+			Q[i].Lines = &Qlines
+		}
+		lines[i] = *Q[i].Lines
+	}
+	return pr.millerLoopLines(P, lines)
+
+}
+
+// millerLoopLines computes the multi-Miller loop from points in G1 and precomputed lines in G2
+func (pr Pairing) millerLoopLines(P []*G1Affine, lines []lineEvaluations) (*GTEl, error) {
+
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(lines) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+
+	// precomputations
+	yInv := make([]*emulated.Element[BaseField], n)
+	xNegOverY := make([]*emulated.Element[BaseField], n)
+
+	for k := 0; k < n; k++ {
+		// P are supposed to be on G1 respectively of prime order r.
+		// The point (x,0) is of order 2. But this function does not check
+		// subgroup membership.
+		yInv[k] = pr.curveF.Inverse(&P[k].Y)
+		xNegOverY[k] = pr.curveF.MulMod(&P[k].X, yInv[k])
+		xNegOverY[k] = pr.curveF.Neg(xNegOverY[k])
+	}
+
+	res := pr.Ext12.One()
+
+	// Compute ∏ᵢ { fᵢ_{x₀,Q}(P) }
+
+	// i = 62, separately to avoid an E12 Square
+	// (Square(res) = 1² = 1)
+	// k = 0, separately to avoid MulBy034 (res × ℓ)
+	// (assign line to res)
+	res.C1.B0 = *pr.MulByElement(&lines[0][0][62].R0, xNegOverY[0])
+	res.C1.B1 = *pr.MulByElement(&lines[0][0][62].R1, yInv[0])
+
+	if n >= 2 {
+		// k = 1, separately to avoid MulBy034 (res × ℓ)
+		// (res is also a line at this point, so we use Mul034By034 ℓ × ℓ)
+		prodLines := pr.Mul034By034(
+			pr.MulByElement(&lines[1][0][62].R0, xNegOverY[1]),
+			pr.MulByElement(&lines[1][0][62].R1, yInv[1]),
+			&res.C1.B0,
+			&res.C1.B1,
+		)
+		res = &fields_bls12377.E12{
+			C0: fields_bls12377.E6{
+				B0: *prodLines[0],
+				B1: *prodLines[1],
+				B2: *prodLines[2],
+			},
+			C1: fields_bls12377.E6{
+				B0: *prodLines[3],
+				B1: *prodLines[4],
+				B2: *pr.Ext2.Zero(),
+			},
+		}
+	}
+
+	for k := 2; k < n; k++ {
+		res = pr.MulBy034(res,
+			pr.MulByElement(&lines[k][0][62].R0, xNegOverY[k]),
+			pr.MulByElement(&lines[k][0][62].R1, yInv[k]),
+		)
+	}
+
+	for i := 61; i >= 0; i-- {
+		// mutualize the square among n Miller loops
+		// (∏ᵢfᵢ)²
+		res = pr.Square(res)
+
+		for k := 0; k < n; k++ {
+			if loopCounter[i] == 0 {
+				res = pr.MulBy034(res,
+					pr.MulByElement(&lines[k][0][i].R0, xNegOverY[k]),
+					pr.MulByElement(&lines[k][0][i].R1, yInv[k]),
+				)
+			} else {
+				// ℓ × ℓ
+				prodLines := pr.Mul034By034(
+					pr.MulByElement(&lines[k][0][i].R0, xNegOverY[k]),
+					pr.MulByElement(&lines[k][0][i].R1, yInv[k]),
+					pr.MulByElement(&lines[k][1][i].R0, xNegOverY[k]),
+					pr.MulByElement(&lines[k][1][i].R1, yInv[k]),
+				)
+				// (ℓ × ℓ) × res
+				res = pr.MulBy01234(res, prodLines)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// doubleAndAddStep doubles p1 and adds p2 to the result in affine coordinates.
+// Then evaluates the lines going through p1 and p2 (line1) and p1 and p1+p2 (line2).
+// https://eprint.iacr.org/2022/1162 (Section 6.1)
+func (pr Pairing) doubleAndAddStep(p1, p2 *g2AffP) (*g2AffP, *lineEvaluation, *lineEvaluation) {
+
+	var line1, line2 lineEvaluation
+	var p g2AffP
+
+	// compute λ1 = (y2-y1)/(x2-x1)
+	n := pr.Ext2.Sub(&p1.Y, &p2.Y)
+	d := pr.Ext2.Sub(&p1.X, &p2.X)
+	λ1 := pr.Ext2.DivUnchecked(n, d)
+
+	// compute x3 =λ1²-x1-x2
+	x3 := pr.Ext2.Square(λ1)
+	x3 = pr.Ext2.Sub(x3, pr.Ext2.Add(&p1.X, &p2.X))
+
+	// omit y3 computation
+
+	// compute line1
+	line1.R0 = *λ1
+	line1.R1 = *pr.Ext2.Mul(λ1, &p1.X)
+	line1.R1 = *pr.Ext2.Sub(&line1.R1, &p1.Y)
+
+	// compute λ2 = -λ1-2y1/(x3-x1)
+	n = pr.Ext2.Double(&p1.Y)
+	d = pr.Ext2.Sub(x3, &p1.X)
+	λ2 := pr.Ext2.DivUnchecked(n, d)
+	λ2 = pr.Ext2.Add(λ2, λ1)
+	λ2 = pr.Ext2.Neg(λ2)
+
+	// compute x4 = λ2²-x1-x3
+	x4 := pr.Ext2.Square(λ2)
+	x4 = pr.Ext2.Sub(x4, pr.Ext2.Add(&p1.X, x3))
+
+	// compute y4 = λ2(x1 - x4)-y1
+	y4 := pr.Ext2.Sub(&p1.X, x4)
+	y4 = pr.Ext2.Mul(λ2, y4)
+	y4 = pr.Ext2.Sub(y4, &p1.Y)
+
+	p.X = *x4
+	p.Y = *y4
+
+	// compute line2
+	line2.R0 = *λ2
+	line2.R1 = *pr.Ext2.Mul(λ2, &p1.X)
+	line2.R1 = *pr.Ext2.Sub(&line2.R1, &p1.Y)
+
+	return &p, &line1, &line2
+}
+
+// doubleStep doubles p1 in affine coordinates, and evaluates the tangent line to p1.
+// https://eprint.iacr.org/2022/1162 (Section 6.1)
+func (pr Pairing) doubleStep(p1 *g2AffP) (*g2AffP, *lineEvaluation) {
+
+	var p g2AffP
+	var line lineEvaluation
+
+	// λ = 3x²/2y
+	n := pr.Ext2.Square(&p1.X)
+	three := big.NewInt(3)
+	n = pr.Ext2.MulByConstElement(n, three)
+	d := pr.Ext2.Double(&p1.Y)
+	λ := pr.Ext2.DivUnchecked(n, d)
+
+	// xr = λ²-2x
+	xr := pr.Ext2.Square(λ)
+	xr = pr.Ext2.Sub(xr, pr.Ext2.MulByConstElement(&p1.X, big.NewInt(2)))
+
+	// yr = λ(x-xr)-y
+	yr := pr.Ext2.Sub(&p1.X, xr)
+	yr = pr.Ext2.Mul(λ, yr)
+	yr = pr.Ext2.Sub(yr, &p1.Y)
+
+	p.X = *xr
+	p.Y = *yr
+
+	line.R0 = *λ
+	line.R1 = *pr.Ext2.Mul(λ, &p1.X)
+	line.R1 = *pr.Ext2.Sub(&line.R1, &p1.Y)
+
+	return &p, &line
+
+}
+
+// linesCompute computes the lines going through p1 and p2, and p1 and p1+p2
+// but does not compute [2]p1+p2.
+func (pr Pairing) linesCompute(p1, p2 *g2AffP) (*lineEvaluation, *lineEvaluation) {
+
+	var line1, line2 lineEvaluation
+
+	// compute λ1 = (y2-y1)/(x2-x1)
+	n := pr.Ext2.Sub(&p1.Y, &p2.Y)
+	d := pr.Ext2.Sub(&p1.X, &p2.X)
+	λ1 := pr.Ext2.DivUnchecked(n, d)
+
+	// compute x3 =λ1²-x1-x2
+	x3 := pr.Ext2.Square(λ1)
+	x3 = pr.Ext2.Sub(x3, pr.Ext2.Add(&p1.X, &p2.X))
+
+	// omit y3 computation
+
+	// compute line1
+	line1.R0 = *λ1
+	line1.R1 = *pr.Ext2.Mul(λ1, &p1.X)
+	line1.R1 = *pr.Ext2.Sub(&line1.R1, &p1.Y)
+
+	// compute λ2 = -λ1-2y1/(x3-x1)
+	n = pr.Ext2.Double(&p1.Y)
+	d = pr.Ext2.Sub(x3, &p1.X)
+	λ2 := pr.Ext2.DivUnchecked(n, d)
+	λ2 = pr.Ext2.Add(λ2, λ1)
+	λ2 = pr.Ext2.Neg(λ2)
+
+	// compute line2
+	line2.R0 = *λ2
+	line2.R1 = *pr.Ext2.Mul(λ2, &p1.X)
+	line2.R1 = *pr.Ext2.Sub(&line2.R1, &p1.Y)
+
+	return &line1, &line2
+}
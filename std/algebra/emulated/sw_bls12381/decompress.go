@@ -0,0 +1,58 @@
+package sw_bls12381
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/internal/compressed"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// UnmarshalCompressedG2 decodes the compressed encoding of a G2 point as
+// returned by the Bytes method of [bls12381.G2Affine], which matches the ZCash
+// serialization format. The first half of the input is the big-endian encoding
+// of X.A1 and the second half of X.A0. It returns an error if the input length
+// is not bls12381.SizeOfG2AffineCompressed.
+//
+// The metadata is stored in the most significant bits of the first byte, the bits
+// (compressed, infinity, largest) being 0b100 for the smallest Y, 0b101 for
+// the largest Y and 0b110 for the point at infinity.
+// The point at infinity is returned as (0,0).
+//
+// The method asserts that the metadata is valid, that the coordinates of X are
+// reduced and that X is the abscissa of a point on the twist. It doesn't check
+// that the point is in G2, use [Pairing.AssertIsOnG2] for that.
+//
+// For decoding compressed G1 points, use [sw_emulated.Curve.UnmarshalCompressed].
+func (pr Pairing) UnmarshalCompressedG2(data []uints.U8) (*G2Affine, error) {
+	if len(data) != bls12381.SizeOfG2AffineCompressed {
+		return nil, fmt.Errorf("expected %d bytes, got %d", bls12381.SizeOfG2AffineCompressed, len(data))
+	}
+	var fp BaseField
+	n := bls12381.SizeOfG2AffineCompressed / 2
+	isInfinity, isLargest, msb := compressed.Metadata(pr.api, data[0], 8*n-fp.Modulus().BitLen())
+	x := &fields_bls12381.E2{
+		A0: *compressed.Element(pr.api, pr.curveF, nil, data[n:]),
+		A1: *compressed.Element(pr.api, pr.curveF, msb, data[1:n]),
+	}
+
+	// the point at infinity is encoded with X=0. We decompress the generator
+	// instead so that the square root exists and then select (0,0).
+	pr.api.AssertIsEqual(pr.api.Mul(isInfinity, pr.api.Sub(1, pr.Ext2.IsZero(x))), 0)
+	_, _, _, g2gen := bls12381.Generators()
+	gx := fields_bls12381.FromE2(&g2gen.X)
+	x = pr.Ext2.Select(isInfinity, &gx, x)
+
+	// Y² = X³ + b'
+	y := pr.Ext2.Sqrt(pr.Ext2.Add(pr.Ext2.Mul(pr.Ext2.Square(x), x), pr.bTwist))
+	y = pr.Ext2.Select(compressed.NegateE2Root(pr.api, pr.curveF, &y.A0, &y.A1, isLargest), pr.Ext2.Neg(y), y)
+
+	zero := pr.Ext2.Zero()
+	return &G2Affine{
+		P: g2AffP{
+			X: *pr.Ext2.Select(isInfinity, zero, x),
+			Y: *pr.Ext2.Select(isInfinity, zero, y),
+		},
+	}, nil
+}
@@ -2,5 +2,8 @@
 //
 // The implementation follows [Housni22]: "Pairings in Rank-1 Constraint Systems".
 //
+// The package also implements hashing to G2 as defined in RFC 9380 for the
+// BLS12381G2_XMD:SHA-256_SSWU suites, see [G2.HashToG2] and [G2.EncodeToG2].
+//
 // [Housni22]: https://eprint.iacr.org/2022/1162
 package sw_bls12381
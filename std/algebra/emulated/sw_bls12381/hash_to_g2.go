@@ -0,0 +1,240 @@
+package sw_bls12381
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// The constants of the BLS12381G2_XMD:SHA-256_SSWU suites of [RFC 9380
+// Section 8.8.2]. The simplified SWU map is defined on the curve y² = x³ +
+// A'x + B' which is 3-isogenous to the twist. The coefficients of the isogeny
+// are given in [RFC 9380 Appendix E.3] in the order of increasing degree, the
+// denominators are monic.
+//
+// [RFC 9380 Section 8.8.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-8.8.2
+// [RFC 9380 Appendix E.3]: https://www.rfc-editor.org/rfc/rfc9380.html#appendix-E.3
+var (
+	sswuIsoA = [2]string{"0", "240"}
+	sswuIsoB = [2]string{"1012", "1012"}
+	sswuZ    = [2]string{
+		"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559785",
+		"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786",
+	}
+	isoXNum = [][2]string{
+		{"889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542"},
+		{"0", "2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706522"},
+		{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706526", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853261"},
+		{"3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033", "0"},
+	}
+	isoXDen = [][2]string{
+		{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559715"},
+		{"12", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559775"},
+	}
+	isoYNum = [][2]string{
+		{"3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558", "3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558"},
+		{"0", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235518"},
+		{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706524", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853263"},
+		{"2816510427748580758331037284777117739799287910327449993381818688383577828123182200904113516794492504322962636245776", "0"},
+	}
+	isoYDen = [][2]string{
+		{"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355"},
+		{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559571"},
+		{"18", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559769"},
+	}
+)
+
+// EncodeToG2 hashes the message msg to a point in G2 using the domain
+// separation tag dst as defined in [RFC 9380 Section 3] for the suite
+// BLS12381G2_XMD:SHA-256_SSWU_NU_. The output distribution is not uniform, use
+// [G2.HashToG2] when a random oracle is required.
+//
+// [RFC 9380 Section 3]: https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func (g2 *G2) EncodeToG2(msg []uints.U8, dst []byte) (*G2Affine, error) {
+	u, err := g2.hashToField(msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return g2.MapToG2(u[0]), nil
+}
+
+// HashToG2 hashes the message msg to a point in G2 using the domain separation
+// tag dst as defined in [RFC 9380 Section 3] for the suite
+// BLS12381G2_XMD:SHA-256_SSWU_RO_.
+//
+// [RFC 9380 Section 3]: https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func (g2 *G2) HashToG2(msg []uints.U8, dst []byte) (*G2Affine, error) {
+	u, err := g2.hashToField(msg, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	q0 := g2.mapToCurve(u[0])
+	q1 := g2.mapToCurve(u[1])
+	return g2.ClearCofactor(g2.add(q0, q1)), nil
+}
+
+// MapToG2 maps the element u of the quadratic extension to a point in G2. It
+// applies the simplified SWU map on the isogenous curve, the 3-isogeny to the
+// twist and clears the cofactor.
+func (g2 *G2) MapToG2(u *fields_bls12381.E2) *G2Affine {
+	return g2.ClearCofactor(g2.mapToCurve(u))
+}
+
+// ClearCofactor maps the point q on the twist to G2 by multiplying it with the
+// effective cofactor of [RFC 9380 Section 8.8.2]. Following [BP17], it
+// computes
+//
+//	[x²-x-1]q + [x-1]ψ(q) + ψ²(2q)
+//
+// where x is the seed of the curve. It uses incomplete formulas, so the result
+// is undefined if q has small order, which happens with negligible probability
+// for hashed points.
+//
+// [RFC 9380 Section 8.8.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-8.8.2
+// [BP17]: https://eprint.iacr.org/2017/419
+func (g2 *G2) ClearCofactor(q *G2Affine) *G2Affine {
+	xq := g2.scalarMulBySeed(q)
+	xxq := g2.scalarMulBySeed(xq)
+	res := g2.sub(g2.sub(xxq, xq), q)
+	res = g2.add(res, g2.psi(g2.sub(xq, q)))
+	return g2.add(res, g2.psi(g2.psi(g2.double(q))))
+}
+
+// hashToField hashes the message msg into count elements of the quadratic
+// extension as defined in [RFC 9380 Section 5.2].
+//
+// [RFC 9380 Section 5.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2
+func (g2 *G2) hashToField(msg []uints.U8, dst []byte, count int) ([]*fields_bls12381.E2, error) {
+	els, err := sw_emulated.HashToField[BaseField](g2.api, msg, dst, 2*count)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	res := make([]*fields_bls12381.E2, count)
+	for i := range res {
+		res[i] = &fields_bls12381.E2{A0: *els[2*i], A1: *els[2*i+1]}
+	}
+	return res, nil
+}
+
+// mapToCurve maps the element u to a point on the twist, which is not
+// necessarily in G2.
+func (g2 *G2) mapToCurve(u *fields_bls12381.E2) *G2Affine {
+	return g2.isogeny(g2.mapSSWU(u))
+}
+
+// mapSSWU implements the simplified SWU method as defined in [RFC 9380 Section
+// 6.6.2]. The result is on the curve isogenous to the twist.
+//
+// [RFC 9380 Section 6.6.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.2
+func (g2 *G2) mapSSWU(u *fields_bls12381.E2) *G2Affine {
+	a, b, z := e2FromStrings(sswuIsoA), e2FromStrings(sswuIsoB), e2FromStrings(sswuZ)
+	// -B/A and B/(Z*A) are constants
+	var negBDivA, bDivZA bls12381.E2
+	negBDivA.Inverse(&a).Mul(&negBDivA, &b).Neg(&negBDivA)
+	bDivZA.Mul(&z, &a).Inverse(&bDivZA).Mul(&bDivZA, &b)
+	ze := fields_bls12381.FromE2(&z)
+
+	// tv1 = Z*u²
+	tv1 := g2.Ext2.Mul(&ze, g2.Ext2.Square(u))
+	// den = Z²u⁴ + Zu²
+	den := g2.Ext2.Add(g2.Ext2.Square(tv1), tv1)
+	denIsZero := g2.Ext2.IsZero(den)
+	one := g2.Ext2.One()
+	inv := g2.Ext2.Inverse(g2.Ext2.Select(denIsZero, one, den))
+	// x1 = (-B/A) * (1 + 1/den) or B/(Z*A) if den = 0
+	c1, c2 := fields_bls12381.FromE2(&bDivZA), fields_bls12381.FromE2(&negBDivA)
+	x1 := g2.Ext2.Select(denIsZero, &c1, g2.Ext2.Mul(g2.Ext2.Add(one, inv), &c2))
+	gx1 := g2.evalIsoCurve(x1, &a, &b)
+	// x2 = Z*u²*x1
+	x2 := g2.Ext2.Mul(tv1, x1)
+
+	// if gx1 is not a square, then Z*gx1 is. In that case g(x2) = (Zu²)³gx1
+	// and we obtain its root as Z*u³*sqrt(Z*gx1).
+	isSquare, s := g2.sqrtOrNonResidue(gx1, &ze)
+	x := g2.Ext2.Select(isSquare, x1, x2)
+	y := g2.Ext2.Select(isSquare, s, g2.Ext2.Mul(g2.Ext2.Mul(tv1, u), s))
+	// fix the sign of y to match the sign of u
+	y = g2.Ext2.Select(g2.api.Xor(g2.sgn0(u), g2.sgn0(y)), g2.Ext2.Neg(y), y)
+	return &G2Affine{P: g2AffP{X: *x, Y: *y}}
+}
+
+// isogeny maps the point p on the isogenous curve to the twist.
+func (g2 *G2) isogeny(p *G2Affine) *G2Affine {
+	xNum := g2.evalPoly(&p.P.X, isoXNum, false)
+	xDen := g2.evalPoly(&p.P.X, isoXDen, true)
+	yNum := g2.evalPoly(&p.P.X, isoYNum, false)
+	yDen := g2.evalPoly(&p.P.X, isoYDen, true)
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.DivUnchecked(xNum, xDen),
+			Y: *g2.Ext2.Mul(&p.P.Y, g2.Ext2.DivUnchecked(yNum, yDen)),
+		},
+	}
+}
+
+// evalPoly evaluates the polynomial with coefficients coeffs (in the order of
+// increasing degree) at x. If monic is set, then the polynomial has additional
+// leading coefficient 1.
+func (g2 *G2) evalPoly(x *fields_bls12381.E2, coeffs [][2]string, monic bool) *fields_bls12381.E2 {
+	coeff := func(i int) *fields_bls12381.E2 {
+		c := e2FromStrings(coeffs[i])
+		res := fields_bls12381.FromE2(&c)
+		return &res
+	}
+	start := len(coeffs) - 1
+	res := coeff(start)
+	if monic {
+		res = g2.Ext2.Add(x, res)
+	}
+	for i := start - 1; i >= 0; i-- {
+		res = g2.Ext2.Add(g2.Ext2.Mul(res, x), coeff(i))
+	}
+	return res
+}
+
+// evalIsoCurve returns x³ + a*x + b.
+func (g2 *G2) evalIsoCurve(x *fields_bls12381.E2, a, b *bls12381.E2) *fields_bls12381.E2 {
+	ae, be := fields_bls12381.FromE2(a), fields_bls12381.FromE2(b)
+	res := g2.Ext2.Mul(g2.Ext2.Square(x), x)
+	res = g2.Ext2.Add(res, g2.Ext2.Mul(&ae, x))
+	return g2.Ext2.Add(res, &be)
+}
+
+// sqrtOrNonResidue returns 1 and a square root of x if x is a square.
+// Otherwise, it returns 0 and a square root of z*x, where z must be a
+// quadratic non-residue. If x is zero, then the returned flag is not unique.
+func (g2 *G2) sqrtOrNonResidue(x, z *fields_bls12381.E2) (isSquare frontend.Variable, root *fields_bls12381.E2) {
+	res, err := g2.fp.NewHintWithNativeOutput(isSquareE2Hint, 1, &x.A0, &x.A1)
+	if err != nil {
+		panic(fmt.Sprintf("is square hint: %v", err))
+	}
+	g2.api.AssertIsBoolean(res[0])
+	root = g2.Ext2.Sqrt(g2.Ext2.Select(res[0], x, g2.Ext2.Mul(z, x)))
+	return res[0], root
+}
+
+// sgn0 returns the sign of x as defined in [RFC 9380 Section 4.1] for
+// quadratic extensions.
+//
+// [RFC 9380 Section 4.1]: https://www.rfc-editor.org/rfc/rfc9380.html#section-4.1
+func (g2 *G2) sgn0(x *fields_bls12381.E2) frontend.Variable {
+	sign0 := g2.fp.ToBitsCanonical(&x.A0)[0]
+	zero0 := g2.fp.IsZero(&x.A0)
+	sign1 := g2.fp.ToBitsCanonical(&x.A1)[0]
+	return g2.api.Or(sign0, g2.api.And(zero0, sign1))
+}
+
+func e2FromStrings(v [2]string) bls12381.E2 {
+	var res bls12381.E2
+	if _, err := res.A0.SetString(v[0]); err != nil {
+		panic(err)
+	}
+	if _, err := res.A1.SetString(v[1]); err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,70 @@
+package sw_bls12381
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type hashToG2Circuit struct {
+	Msg      []uints.U8
+	Expected G2Affine
+	dst      []byte
+	encode   bool
+}
+
+func (c *hashToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	var res *G2Affine
+	var err error
+	if c.encode {
+		res, err = g2.EncodeToG2(c.Msg, c.dst)
+	} else {
+		res, err = g2.HashToG2(c.Msg, c.dst)
+	}
+	if err != nil {
+		return err
+	}
+	g2.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestHashToG2(t *testing.T) {
+	assert := test.NewAssert(t)
+	// test vector from RFC 9380 Appendix J.10.1
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	var expected bls12381.G2Affine
+	expected.X.A0.SetString("0x02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6")
+	expected.X.A1.SetString("0x139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8")
+	expected.Y.A0.SetString("0x1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48")
+	expected.Y.A1.SetString("0x00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16")
+	msg := []byte("abc")
+	circuit := hashToG2Circuit{Msg: make([]uints.U8, len(msg)), dst: dst}
+	witness := hashToG2Circuit{Msg: uints.NewU8Array(msg), Expected: NewG2Affine(expected)}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the empty message
+	p, err := bls12381.HashToG2(nil, dst)
+	assert.NoError(err)
+	circuit = hashToG2Circuit{Msg: []uints.U8{}, dst: dst}
+	witness = hashToG2Circuit{Msg: []uints.U8{}, Expected: NewG2Affine(p)}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestEncodeToG2(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_NU_")
+	msg := []byte("abcdef0123456789")
+	p, err := bls12381.EncodeToG2(msg, dst)
+	assert.NoError(err)
+	circuit := hashToG2Circuit{Msg: make([]uints.U8, len(msg)), dst: dst, encode: true}
+	witness := hashToG2Circuit{Msg: uints.NewU8Array(msg), Expected: NewG2Affine(p)}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,43 @@
+package sw_bls12381
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		isSquareE2Hint,
+	}
+}
+
+// isSquareE2Hint returns 1 if the element of the quadratic extension given by
+// its two coordinates is a square and 0 otherwise. The element a0+a1*u is a
+// square if and only if its norm a0²+a1² is a square in the base field.
+func isSquareE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			if len(inputs) != 2 {
+				return fmt.Errorf("expecting two inputs")
+			}
+			if len(outputs) != 1 {
+				return fmt.Errorf("expecting one output")
+			}
+			norm := new(big.Int).Mul(inputs[0], inputs[0])
+			norm.Add(norm, new(big.Int).Mul(inputs[1], inputs[1]))
+			norm.Mod(norm, mod)
+			outputs[0].SetUint64(0)
+			if big.Jacobi(norm, mod) >= 0 {
+				outputs[0].SetUint64(1)
+			}
+			return nil
+		})
+}
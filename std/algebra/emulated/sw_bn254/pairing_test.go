@@ -12,6 +12,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/math/uints"
 	"github.com/consensys/gnark/test"
 )
 
@@ -478,6 +479,56 @@ func TestIsMillerLoopAndFinalExpCircuitTestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type UnmarshalCompressedG2Circuit struct {
+	In       []uints.U8
+	Expected G2Affine
+}
+
+func (c *UnmarshalCompressedG2Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.UnmarshalCompressedG2(c.In)
+	if err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+	pairing.Ext2.AssertIsEqual(&res.P.X, &c.Expected.P.X)
+	pairing.Ext2.AssertIsEqual(&res.P.Y, &c.Expected.P.Y)
+	return nil
+}
+
+func TestUnmarshalCompressedG2Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit := UnmarshalCompressedG2Circuit{In: make([]uints.U8, bn254.SizeOfG2AffineCompressed)}
+	for i := 0; i < 2; i++ {
+		_, q := randomG1G2Affines()
+		in := q.Bytes()
+		witness := UnmarshalCompressedG2Circuit{
+			In:       uints.NewU8Array(in[:]),
+			Expected: NewG2Affine(q),
+		}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+
+		// the opposite point must not be accepted
+		q.Neg(&q)
+		witness.Expected = NewG2Affine(q)
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}
+
+	// point at infinity
+	var inf bn254.G2Affine
+	in := inf.Bytes()
+	witness := UnmarshalCompressedG2Circuit{
+		In:       uints.NewU8Array(in[:]),
+		Expected: NewG2Affine(inf),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 // bench
 func BenchmarkPairing(b *testing.B) {
 	// e(a,2b) * e(-2a,b) == 1
@@ -0,0 +1,8 @@
+// Package sw_bw6633 implements G1 and G2 arithmetics and pairing computation over BW6-633 curve.
+//
+// The implementation follows [Housni22]: "Pairings in Rank-1 Constraint Systems".
+// Contrary to BW6-761, the base field is 633 bits and the scalar field is
+// the base field of BLS24-315.
+//
+// [Housni22]: https://eprint.iacr.org/2022/1162
+package sw_bw6633
@@ -0,0 +1,64 @@
+package sw_bw6633
+
+import (
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// lineEvaluation represents a sparse Fp6 Elmt (result of the line evaluation)
+// line: 1 + R0(x/y) + R1(1/y) = 0 instead of R0'*y + R1'*x + R2' = 0 This
+// makes the multiplication by lines (MulBy014)
+type lineEvaluation struct {
+	R0, R1 emulated.Element[BaseField]
+}
+type lineEvaluations [2][len(bw6633.LoopCounter) - 1]*lineEvaluation
+
+func precomputeLines(Q bw6633.G2Affine) lineEvaluations {
+	var cLines lineEvaluations
+	nLines := bw6633.PrecomputeLines(Q)
+	for j := range cLines[0] {
+		cLines[0][j] = &lineEvaluation{
+			R0: emulated.ValueOf[BaseField](nLines[0][j].R0),
+			R1: emulated.ValueOf[BaseField](nLines[0][j].R1),
+		}
+		cLines[1][j] = &lineEvaluation{
+			R0: emulated.ValueOf[BaseField](nLines[1][j].R0),
+			R1: emulated.ValueOf[BaseField](nLines[1][j].R1),
+		}
+	}
+	return cLines
+}
+
+func (p *Pairing) computeLines(Q *g2AffP) lineEvaluations {
+	var cLines lineEvaluations
+	imQ := &g2AffP{
+		X: *p.curveF.Mul(&Q.X, &thirdRootOne),
+		Y: *p.curveF.Neg(&Q.Y),
+	}
+	accQ := &g2AffP{
+		X: Q.X,
+		Y: Q.Y,
+	}
+	for i := len(loopCounter) - 2; i > 0; i-- {
+		switch loopCounter[i]*3 + loopCounter1[i] {
+		// cases -4, -2, 2, 4 do not occur, given the static LoopCounters
+		case -3:
+			accQ, cLines[0][i], cLines[1][i] = p.doubleAndAddStep(accQ, imQ, true)
+		case -1:
+			accQ, cLines[0][i], cLines[1][i] = p.doubleAndAddStep(accQ, Q, true)
+		case 0:
+			accQ, cLines[0][i] = p.doubleStep(accQ)
+		case 1:
+			accQ, cLines[0][i], cLines[1][i] = p.doubleAndAddStep(accQ, Q, false)
+		case 3:
+			accQ, cLines[0][i], cLines[1][i] = p.doubleAndAddStep(accQ, imQ, false)
+		default:
+			panic("unknown case for loopCounter")
+		}
+	}
+	// i = 0, j = 1: [x₀+1+λ(x₀⁵-x₀⁴-x₀)]Q is the point at infinity, so the
+	// line through 2accQ and Q is vertical and vanishes in the final
+	// exponentiation.
+	cLines[0][0] = p.tangentCompute(accQ)
+	return cLines
+}
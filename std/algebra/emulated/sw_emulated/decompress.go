@@ -0,0 +1,100 @@
+package sw_emulated
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/internal/compressed"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Decompress returns the point (x, y) on the curve where y is the square root
+// of x³ + ax + b whose least significant bit is isOdd, as in the SEC1
+// compressed encoding. The selector isOdd must be boolean. It doesn't modify x.
+//
+// The method fails if x is not the abscissa of a point on the curve. It
+// doesn't check that the point is in the prime order subgroup, use
+// [Curve.AssertIsInSubgroup] for that.
+func (c *Curve[B, S]) Decompress(x *emulated.Element[B], isOdd frontend.Variable) *AffinePoint[B] {
+	c.api.AssertIsBoolean(isOdd)
+	y := c.baseApi.Sqrt(c.evalCurve(x, c.params.A, c.params.B))
+	yIsOdd := c.baseApi.ToBitsCanonical(y)[0]
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *c.fixSignOfY(y, yIsOdd, isOdd),
+	}
+}
+
+// DecompressLexicographic returns the point (x, y) on the curve where y is the
+// square root of x³ + ax + b such that y > (p-1)/2 if and only if isLargest is
+// set, as in the ZCash and gnark-crypto compressed encodings. The selector
+// isLargest must be boolean. It doesn't modify x.
+//
+// The method fails if x is not the abscissa of a point on the curve. It
+// doesn't check that the point is in the prime order subgroup, use
+// [Curve.AssertIsInSubgroup] for that.
+func (c *Curve[B, S]) DecompressLexicographic(x *emulated.Element[B], isLargest frontend.Variable) *AffinePoint[B] {
+	c.api.AssertIsBoolean(isLargest)
+	y := c.baseApi.Sqrt(c.evalCurve(x, c.params.A, c.params.B))
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *c.fixSignOfY(y, compressed.IsLexicographicallyLargest(c.baseApi, y), isLargest),
+	}
+}
+
+// DecompressSEC1 decodes the compressed SEC1 encoding 0x02||x or 0x03||x of a
+// point, where x is the big-endian encoding of the abscissa. It returns an
+// error if the input length does not match the size of the base field.
+//
+// The method asserts that the prefix is valid, that x is reduced modulo the
+// base field and that x is the abscissa of a point on the curve.
+func (c *Curve[B, S]) DecompressSEC1(data []uints.U8) (*AffinePoint[B], error) {
+	nbBytes := (c.baseModulus().BitLen() + 7) / 8
+	if len(data) != nbBytes+1 {
+		return nil, fmt.Errorf("expected %d bytes, got %d", nbBytes+1, len(data))
+	}
+	// prefix is 0x02 for even y and 0x03 for odd y
+	isOdd := c.api.Sub(data[0].Val, 2)
+	c.api.AssertIsBoolean(isOdd)
+	x := bytesToElement(c.api, c.baseApi, data[1:])
+	c.baseApi.AssertIsInRange(x)
+	return c.Decompress(x, isOdd), nil
+}
+
+// UnmarshalCompressed decodes the compressed encoding of a point as returned
+// by the Bytes method of gnark-crypto G1 points. For BLS12-381 this matches the
+// ZCash serialization format. It returns an error if the input length does not
+// match the size of the base field.
+//
+// The metadata is stored in the most significant bits of the first byte. When
+// the size of the base field leaves at least three spare bits, the bits are
+// (compressed, infinity, largest), otherwise (largest or infinity, compressed
+// or infinity), i.e. 0b10 for smallest y, 0b11 for largest y and 0b01 for the
+// point at infinity. The point at infinity is returned as (0,0).
+//
+// The method asserts that the metadata is valid, that x is reduced modulo the
+// base field and that x is the abscissa of a point on the curve.
+func (c *Curve[B, S]) UnmarshalCompressed(data []uints.U8) (*AffinePoint[B], error) {
+	nbBits := c.baseModulus().BitLen()
+	nbBytes := (nbBits + 7) / 8
+	if len(data) != nbBytes {
+		return nil, fmt.Errorf("expected %d bytes, got %d", nbBytes, len(data))
+	}
+	isInfinity, isLargest, msb := compressed.Metadata(c.api, data[0], 8*nbBytes-nbBits)
+	x := compressed.Element(c.api, c.baseApi, msb, data[1:])
+
+	// the point at infinity is encoded with x=0. We decompress the generator
+	// instead so that the square root exists and then select (0,0).
+	c.api.AssertIsEqual(c.api.Mul(isInfinity, c.api.Sub(1, c.baseApi.IsZero(x))), 0)
+	p := c.DecompressLexicographic(c.baseApi.Select(isInfinity, &c.g.X, x), isLargest)
+	return c.Select(isInfinity, &AffinePoint[B]{X: *c.baseApi.Zero(), Y: *c.baseApi.Zero()}, p), nil
+}
+
+// fixSignOfY returns y or -y such that the sign of the result, as computed by
+// sign(y)=ySign, matches want. As -0 = 0, it asserts that want is not set when y
+// is zero.
+func (c *Curve[B, S]) fixSignOfY(y *emulated.Element[B], ySign, want frontend.Variable) *emulated.Element[B] {
+	c.api.AssertIsEqual(c.api.And(want, c.baseApi.IsZero(y)), 0)
+	return c.baseApi.Select(c.api.Xor(ySign, want), c.baseApi.Neg(y), y)
+}
@@ -0,0 +1,145 @@
+package sw_emulated
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	fr_secp "github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type decompressCircuit[B, S emulated.FieldParams] struct {
+	In       []uints.U8
+	Expected AffinePoint[B]
+	sec1     bool
+}
+
+func (c *decompressCircuit[B, S]) Define(api frontend.API) error {
+	cr, err := New[B, S](api, GetCurveParams[B]())
+	if err != nil {
+		return err
+	}
+	var res *AffinePoint[B]
+	if c.sec1 {
+		res, err = cr.DecompressSEC1(c.In)
+	} else {
+		res, err = cr.UnmarshalCompressed(c.In)
+	}
+	if err != nil {
+		return err
+	}
+	cr.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func testDecompress[B, S emulated.FieldParams](assert *test.Assert, in []byte, sec1 bool, x, y *big.Int) {
+	circuit := decompressCircuit[B, S]{In: make([]uints.U8, len(in)), sec1: sec1}
+	witness := decompressCircuit[B, S]{
+		In:       uints.NewU8Array(in),
+		Expected: AffinePoint[B]{X: emulated.ValueOf[B](x), Y: emulated.ValueOf[B](y)},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// the opposite point must not be accepted
+	var fp B
+	witness.Expected.Y = emulated.ValueOf[B](new(big.Int).Sub(fp.Modulus(), y))
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+func TestDecompressSEC1Secp256k1(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	for i := 0; i < 2; i++ {
+		var r fr_secp.Element
+		_, _ = r.SetRandom()
+		var p secp256k1.G1Affine
+		p.ScalarMultiplication(&g, r.BigInt(new(big.Int)))
+		x, y := p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int))
+		in := make([]byte, 33)
+		in[0] = byte(2 + y.Bit(0))
+		x.FillBytes(in[1:])
+		testDecompress[emulated.Secp256k1Fp, emulated.Secp256k1Fr](assert, in, true, x, y)
+	}
+}
+
+func TestDecompressSEC1P256(t *testing.T) {
+	assert := test.NewAssert(t)
+	for i := 0; i < 2; i++ {
+		sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(err)
+		in := elliptic.MarshalCompressed(elliptic.P256(), sk.X, sk.Y)
+		testDecompress[emulated.P256Fp, emulated.P256Fr](assert, in, true, sk.X, sk.Y)
+	}
+}
+
+func TestDecompressSEC1InvalidPrefix(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	x, y := g.X.BigInt(new(big.Int)), g.Y.BigInt(new(big.Int))
+	in := make([]byte, 33)
+	x.FillBytes(in[1:])
+	in[0] = 4
+	circuit := decompressCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{In: make([]uints.U8, len(in)), sec1: true}
+	witness := decompressCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		In:       uints.NewU8Array(in),
+		Expected: AffinePoint[emulated.Secp256k1Fp]{X: emulated.ValueOf[emulated.Secp256k1Fp](x), Y: emulated.ValueOf[emulated.Secp256k1Fp](y)},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+func TestUnmarshalCompressedBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bn254.Generators()
+	for i := 0; i < 2; i++ {
+		s, err := rand.Int(rand.Reader, bn254.ID.ScalarField())
+		assert.NoError(err)
+		var p bn254.G1Affine
+		p.ScalarMultiplication(&g, s)
+		in := p.Bytes()
+		testDecompress[emulated.BN254Fp, emulated.BN254Fr](assert, in[:], false, p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int)))
+	}
+}
+
+func TestUnmarshalCompressedBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bls12381.Generators()
+	for i := 0; i < 2; i++ {
+		s, err := rand.Int(rand.Reader, bls12381.ID.ScalarField())
+		assert.NoError(err)
+		var p bls12381.G1Affine
+		p.ScalarMultiplication(&g, s)
+		in := p.Bytes()
+		testDecompress[emulated.BLS12381Fp, emulated.BLS12381Fr](assert, in[:], false, p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int)))
+	}
+}
+
+func TestUnmarshalCompressedInfinity(t *testing.T) {
+	assert := test.NewAssert(t)
+	var p bls12381.G1Affine
+	in := p.Bytes()
+	circuit := decompressCircuit[emulated.BLS12381Fp, emulated.BLS12381Fr]{In: make([]uints.U8, len(in))}
+	witness := decompressCircuit[emulated.BLS12381Fp, emulated.BLS12381Fr]{
+		In:       uints.NewU8Array(in[:]),
+		Expected: AffinePoint[emulated.BLS12381Fp]{X: emulated.ValueOf[emulated.BLS12381Fp](0), Y: emulated.ValueOf[emulated.BLS12381Fp](0)},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// infinity flag with nonzero x
+	in[len(in)-1] = 1
+	witness.In = uints.NewU8Array(in[:])
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
@@ -25,6 +25,14 @@ a particular curve parameter depending on the type parameter defining the base
 field. For now, we only have a single curve defined on every base field, but
 this may change in the future with the addition of additional curves.
 
+The package implements hashing to curves as defined in RFC 9380, see
+[Curve.HashToCurve] and [Curve.EncodeToCurve]. The mapping methods are defined
+by [MapToCurveParams]. Hashing is currently supported for secp256k1, P-256,
+BN254 and BLS12-381 G1, following the suites of RFC 9380. The points of
+BLS12-381 G2 are defined over an extension of the base field, see
+[github.com/consensys/gnark/std/algebra/emulated/sw_bls12381.G2.HashToG2] for
+hashing to G2.
+
 This package uses field emulation (unlike packages
 [github.com/consensys/gnark/std/algebra/native/sw_bls12377] and
 [github.com/consensys/gnark/std/algebra/native/sw_bls24315], which use 2-chains). This
@@ -0,0 +1,333 @@
+package sw_emulated
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/expand"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// secLevel is the target security level k in bits for hashing to field.
+const secLevel = 128
+
+// HashToField hashes the message msg into count elements of the field T as
+// defined in [RFC 9380 Section 5.2]. The message is expanded using
+// expand_message_xmd with SHA-256 and the domain separation tag dst.
+//
+// [RFC 9380 Section 5.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2
+func HashToField[T emulated.FieldParams](api frontend.API, msg []uints.U8, dst []byte, count int) ([]*emulated.Element[T], error) {
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return nil, fmt.Errorf("new field: %w", err)
+	}
+	var fp T
+	modBits := fp.Modulus().BitLen()
+	// L = ceil((ceil(log2(p)) + k) / 8)
+	L := (modBits + secLevel + 7) / 8
+	bts, err := expand.ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, fmt.Errorf("expand message: %w", err)
+	}
+	// we interpret the bytes as big-endian integer in chunks which fit into the
+	// field without reduction.
+	chunkLen := (modBits - 1) / 8
+	shift := f.NewElement(new(big.Int).Lsh(big.NewInt(1), uint(8*chunkLen)))
+	ret := make([]*emulated.Element[T], count)
+	for i := range ret {
+		in := bts[i*L : (i+1)*L]
+		// the first chunk is shorter so that the rest are aligned
+		end := L % chunkLen
+		if end == 0 {
+			end = chunkLen
+		}
+		acc := bytesToElement(api, f, in[:end])
+		for start := end; start < L; start += chunkLen {
+			chunk := bytesToElement(api, f, in[start:start+chunkLen])
+			acc = f.Add(f.Mul(acc, shift), chunk)
+		}
+		ret[i] = f.Reduce(acc)
+	}
+	return ret, nil
+}
+
+// bytesToElement returns the element corresponding to the big-endian bytes.
+func bytesToElement[T emulated.FieldParams](api frontend.API, f *emulated.Field[T], in []uints.U8) *emulated.Element[T] {
+	bs := make([]frontend.Variable, 0, 8*len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		bs = append(bs, bits.ToBinary(api, in[i].Val, bits.WithNbDigits(8))...)
+	}
+	return f.FromBits(bs...)
+}
+
+// EncodeToCurve hashes the message msg to a point on the curve using the
+// domain separation tag dst as defined in [RFC 9380 Section 3]. The output
+// distribution is not uniform, use [Curve.HashToCurve] when a random oracle is
+// required. The method returns an error if hashing to the curve is not
+// supported for the curve.
+//
+// [RFC 9380 Section 3]: https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func (c *Curve[B, S]) EncodeToCurve(msg []uints.U8, dst []byte) (*AffinePoint[B], error) {
+	if c.params.MapToCurve == nil {
+		return nil, fmt.Errorf("hash to curve not supported")
+	}
+	u, err := HashToField[B](c.api, msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return c.ClearCofactor(c.MapToCurve(u[0])), nil
+}
+
+// HashToCurve hashes the message msg to a point on the curve using the domain
+// separation tag dst as defined in [RFC 9380 Section 3]. The method returns an
+// error if hashing to the curve is not supported for the curve.
+//
+// [RFC 9380 Section 3]: https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func (c *Curve[B, S]) HashToCurve(msg []uints.U8, dst []byte) (*AffinePoint[B], error) {
+	if c.params.MapToCurve == nil {
+		return nil, fmt.Errorf("hash to curve not supported")
+	}
+	u, err := HashToField[B](c.api, msg, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	q0 := c.MapToCurve(u[0])
+	q1 := c.MapToCurve(u[1])
+	return c.ClearCofactor(c.AddUnified(q0, q1)), nil
+}
+
+// MapToCurve maps the field element u to a point on the curve using the method
+// defined in the curve parameters. If the map is defined on an isogenous curve,
+// then the isogeny is applied. The result is not necessarily in the prime order
+// subgroup, see [Curve.ClearCofactor].
+//
+// The method panics if hashing to the curve is not supported for the curve.
+func (c *Curve[B, S]) MapToCurve(u *emulated.Element[B]) *AffinePoint[B] {
+	mp := c.params.MapToCurve
+	if mp == nil {
+		panic("hash to curve not supported")
+	}
+	switch mp.Method {
+	case SSWU:
+		p := c.mapSSWU(u)
+		if mp.IsoA != nil {
+			p = c.isogeny(p)
+		}
+		return p
+	case SVDW:
+		return c.mapSVDW(u)
+	default:
+		panic("unknown map to curve method")
+	}
+}
+
+// ClearCofactor maps the point p to the prime order subgroup. It uses
+// incomplete formulas, so the result is undefined if p has small order, which
+// happens with negligible probability for hashed points.
+func (c *Curve[B, S]) ClearCofactor(p *AffinePoint[B]) *AffinePoint[B] {
+	h := c.params.MapToCurve.Cofactor
+	if h == nil || h.Cmp(big.NewInt(1)) == 0 {
+		return p
+	}
+	res := p
+	for i := h.BitLen() - 2; i >= 0; i-- {
+		res = c.double(res)
+		if h.Bit(i) == 1 {
+			res = c.add(res, p)
+		}
+	}
+	return res
+}
+
+// mapSSWU implements the simplified SWU method as defined in [RFC 9380 Section
+// 6.6.2]. The result is on the isogenous curve if the isogeny is defined.
+//
+// [RFC 9380 Section 6.6.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.2
+func (c *Curve[B, S]) mapSSWU(u *emulated.Element[B]) *AffinePoint[B] {
+	mp := c.params.MapToCurve
+	f := c.baseApi
+	a, b := c.params.A, c.params.B
+	if mp.IsoA != nil {
+		a, b = mp.IsoA, mp.IsoB
+	}
+	p := c.baseModulus()
+	// -B/A and B/(Z*A) are constants
+	negBDivA := new(big.Int).ModInverse(a, p)
+	negBDivA.Mul(negBDivA, b).Neg(negBDivA).Mod(negBDivA, p)
+	bDivZA := new(big.Int).Mul(mp.Z, a)
+	bDivZA.ModInverse(bDivZA, p).Mul(bDivZA, b).Mod(bDivZA, p)
+	z := f.NewElement(mp.Z)
+
+	// tv1 = Z*u²
+	tv1 := f.Mul(z, f.Mul(u, u))
+	// den = Z²u⁴ + Zu²
+	den := f.Add(f.Mul(tv1, tv1), tv1)
+	denIsZero := f.IsZero(den)
+	inv := f.Inverse(f.Select(denIsZero, f.One(), den))
+	// x1 = (-B/A) * (1 + 1/den) or B/(Z*A) if den = 0
+	x1 := f.Select(denIsZero, f.NewElement(bDivZA), c.mulConst(f.Add(f.One(), inv), negBDivA))
+	gx1 := c.evalCurve(x1, a, b)
+	// x2 = Z*u²*x1
+	x2 := f.Mul(tv1, x1)
+
+	// if gx1 is not a square, then Z*gx1 is. In that case g(x2) = (Zu²)³gx1
+	// and we obtain its root as Z*u³*sqrt(Z*gx1).
+	isSquare, s := c.sqrtOrNonResidue(gx1, mp.Z)
+	x := f.Select(isSquare, x1, x2)
+	y := f.Select(isSquare, s, f.Mul(f.Mul(tv1, u), s))
+	return &AffinePoint[B]{X: *x, Y: *c.fixSign(u, y)}
+}
+
+// mapSVDW implements the Shallue-van de Woestijne method as defined in [RFC
+// 9380 Section 6.6.1].
+//
+// [RFC 9380 Section 6.6.1]: https://www.rfc-editor.org/rfc/rfc9380.html#section-6.6.1
+func (c *Curve[B, S]) mapSVDW(u *emulated.Element[B]) *AffinePoint[B] {
+	mp := c.params.MapToCurve
+	f := c.baseApi
+	a, b := c.params.A, c.params.B
+	p := c.baseModulus()
+	c1, c2, c3, c4 := svdwConstants(p, a, b, mp.Z)
+
+	// tv1 = u²*c1, tv2 = 1 + tv1, tv1 = 1 - tv1
+	tv1 := c.mulConst(f.Mul(u, u), c1)
+	tv2 := f.Add(f.One(), tv1)
+	tv1 = f.Sub(f.One(), tv1)
+	// tv3 = inv0(tv1*tv2)
+	tv3 := f.Mul(tv1, tv2)
+	tv3IsZero := f.IsZero(tv3)
+	tv3 = f.Select(tv3IsZero, f.Zero(), f.Inverse(f.Select(tv3IsZero, f.One(), tv3)))
+	// tv4 = u*tv1*tv3*c3
+	tv4 := c.mulConst(f.Mul(f.Mul(u, tv1), tv3), c3)
+	x1 := f.Sub(f.NewElement(c2), tv4)
+	x2 := f.Add(f.NewElement(c2), tv4)
+	// x3 = (tv2²*tv3)²*c4 + Z
+	x3 := f.Mul(f.Mul(tv2, tv2), tv3)
+	x3 = f.Add(c.mulConst(f.Mul(x3, x3), c4), f.NewElement(mp.Z))
+
+	n := nonResidue(p)
+	e1, _ := c.sqrtOrNonResidue(c.evalCurve(x1, a, b), n)
+	e2, _ := c.sqrtOrNonResidue(c.evalCurve(x2, a, b), n)
+	x := f.Select(e1, x1, f.Select(e2, x2, x3))
+	y := f.Sqrt(c.evalCurve(x, a, b))
+	return &AffinePoint[B]{X: *x, Y: *c.fixSign(u, y)}
+}
+
+// isogeny maps the point p on the isogenous curve to the curve.
+func (c *Curve[B, S]) isogeny(p *AffinePoint[B]) *AffinePoint[B] {
+	mp := c.params.MapToCurve
+	f := c.baseApi
+	xNum := c.evalPoly(&p.X, mp.XNum, false)
+	xDen := c.evalPoly(&p.X, mp.XDen, true)
+	yNum := c.evalPoly(&p.X, mp.YNum, false)
+	yDen := c.evalPoly(&p.X, mp.YDen, true)
+	return &AffinePoint[B]{
+		X: *f.Div(xNum, xDen),
+		Y: *f.Mul(&p.Y, f.Div(yNum, yDen)),
+	}
+}
+
+// evalPoly evaluates the polynomial with coefficients coeffs (in the order of
+// increasing degree) at x. If monic is set, then the polynomial has additional
+// leading coefficient 1.
+func (c *Curve[B, S]) evalPoly(x *emulated.Element[B], coeffs []*big.Int, monic bool) *emulated.Element[B] {
+	f := c.baseApi
+	var res *emulated.Element[B]
+	start := len(coeffs) - 1
+	if monic {
+		res = f.Add(x, f.NewElement(coeffs[start]))
+	} else {
+		res = f.NewElement(coeffs[start])
+	}
+	for i := start - 1; i >= 0; i-- {
+		res = f.Add(f.Mul(res, x), f.NewElement(coeffs[i]))
+	}
+	return res
+}
+
+// evalCurve returns x³ + a*x + b.
+func (c *Curve[B, S]) evalCurve(x *emulated.Element[B], a, b *big.Int) *emulated.Element[B] {
+	f := c.baseApi
+	res := f.Mul(f.Mul(x, x), x)
+	if a.Sign() != 0 {
+		res = f.Add(res, c.mulConst(x, a))
+	}
+	return f.Add(res, f.NewElement(b))
+}
+
+// mulConst returns x*k. It uses [emulated.Field.MulConst] only for small
+// constants as it increases the overflow of the limbs by the length of k.
+func (c *Curve[B, S]) mulConst(x *emulated.Element[B], k *big.Int) *emulated.Element[B] {
+	if k.Sign() >= 0 && k.BitLen() <= 16 {
+		return c.baseApi.MulConst(x, k)
+	}
+	return c.baseApi.Mul(x, c.baseApi.NewElement(k))
+}
+
+// sqrtOrNonResidue returns 1 and a square root of x if x is a square.
+// Otherwise, it returns 0 and a square root of n*x, where n must be a quadratic
+// non-residue. If x is zero, then the returned flag is not unique.
+func (c *Curve[B, S]) sqrtOrNonResidue(x *emulated.Element[B], n *big.Int) (isSquare frontend.Variable, root *emulated.Element[B]) {
+	f := c.baseApi
+	res, err := f.NewHintWithNativeOutput(isSquareHint, 1, x)
+	if err != nil {
+		panic(fmt.Sprintf("is square hint: %v", err))
+	}
+	c.api.AssertIsBoolean(res[0])
+	root = f.Sqrt(f.Select(res[0], x, c.mulConst(x, n)))
+	return res[0], root
+}
+
+// fixSign returns y or -y such that its sign matches the sign of u, as defined
+// by the sgn0 function of RFC 9380.
+func (c *Curve[B, S]) fixSign(u, y *emulated.Element[B]) *emulated.Element[B] {
+	f := c.baseApi
+	su := f.ToBitsCanonical(u)[0]
+	sy := f.ToBitsCanonical(y)[0]
+	return f.Select(c.api.Xor(su, sy), f.Neg(y), y)
+}
+
+func (c *Curve[B, S]) baseModulus() *big.Int {
+	var fp B
+	return fp.Modulus()
+}
+
+// svdwConstants returns the constants of the SVDW method:
+//
+//	c1 = g(Z)
+//	c2 = -Z / 2
+//	c3 = sqrt(-g(Z) * (3 * Z² + 4 * A)) with sgn0(c3) = 0
+//	c4 = -4 * g(Z) / (3 * Z² + 4 * A)
+func svdwConstants(p, a, b, z *big.Int) (c1, c2, c3, c4 *big.Int) {
+	// g(Z) = Z³ + A*Z + B
+	c1 = new(big.Int).Exp(z, big.NewInt(3), p)
+	c1.Add(c1, new(big.Int).Mul(a, z)).Add(c1, b).Mod(c1, p)
+	c2 = new(big.Int).ModInverse(big.NewInt(2), p)
+	c2.Mul(c2, z).Neg(c2).Mod(c2, p)
+	// t = 3 * Z² + 4 * A
+	t := new(big.Int).Mul(z, z)
+	t.Mul(t, big.NewInt(3)).Add(t, new(big.Int).Lsh(a, 2)).Mod(t, p)
+	c3 = new(big.Int).Mul(c1, t)
+	c3.Neg(c3).Mod(c3, p)
+	if c3.ModSqrt(c3, p) == nil {
+		panic("invalid SVDW constant z")
+	}
+	if c3.Bit(0) == 1 {
+		c3.Sub(p, c3)
+	}
+	c4 = new(big.Int).ModInverse(t, p)
+	c4.Mul(c4, c1).Mul(c4, big.NewInt(-4)).Mod(c4, p)
+	return c1, c2, c3, c4
+}
+
+// nonResidue returns the smallest quadratic non-residue modulo p.
+func nonResidue(p *big.Int) *big.Int {
+	n := big.NewInt(2)
+	for big.Jacobi(n, p) != -1 {
+		n.Add(n, big.NewInt(1))
+	}
+	return n
+}
@@ -0,0 +1,92 @@
+package sw_emulated
+
+import (
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type hashToCurveCircuit[B, S emulated.FieldParams] struct {
+	Msg      []uints.U8
+	Expected AffinePoint[B]
+	dst      []byte
+	encode   bool
+}
+
+func (c *hashToCurveCircuit[B, S]) Define(api frontend.API) error {
+	cr, err := New[B, S](api, GetCurveParams[B]())
+	if err != nil {
+		return err
+	}
+	var res *AffinePoint[B]
+	if c.encode {
+		res, err = cr.EncodeToCurve(c.Msg, c.dst)
+	} else {
+		res, err = cr.HashToCurve(c.Msg, c.dst)
+	}
+	if err != nil {
+		return err
+	}
+	cr.AssertIsOnCurve(res)
+	cr.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func testHashToCurve[B, S emulated.FieldParams](assert *test.Assert, msg, dst []byte, encode bool, x, y *big.Int) {
+	circuit := hashToCurveCircuit[B, S]{Msg: make([]uints.U8, len(msg)), dst: dst, encode: encode}
+	witness := hashToCurveCircuit[B, S]{
+		Msg:      uints.NewU8Array(msg),
+		Expected: AffinePoint[B]{X: emulated.ValueOf[B](x), Y: emulated.ValueOf[B](y)},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+func TestHashToCurveBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_")
+	for _, msg := range []string{"", "abc"} {
+		p, err := bn254.HashToG1([]byte(msg), dst)
+		assert.NoError(err)
+		testHashToCurve[emulated.BN254Fp, emulated.BN254Fr](assert, []byte(msg), dst, false, p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int)))
+	}
+	p, err := bn254.EncodeToG1([]byte("abc"), dst)
+	assert.NoError(err)
+	testHashToCurve[emulated.BN254Fp, emulated.BN254Fr](assert, []byte("abc"), dst, true, p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int)))
+}
+
+func TestHashToCurveSecp256k1(t *testing.T) {
+	assert := test.NewAssert(t)
+	// test vectors from RFC 9380 Appendix J.8
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	x, _ := new(big.Int).SetString("3377e01eab42db296b512293120c6cee72b6ecf9f9205760bd9ff11fb3cb2c4b", 16)
+	y, _ := new(big.Int).SetString("7f95890f33efebd1044d382a01b1bee0900fb6116f94688d487c6c7b9c8371f6", 16)
+	testHashToCurve[emulated.Secp256k1Fp, emulated.Secp256k1Fr](assert, []byte("abc"), dst, false, x, y)
+	dst = []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_NU_")
+	x, _ = new(big.Int).SetString("3f3b5842033fff837d504bb4ce2a372bfeadbdbd84a1d2b678b6e1d7ee426b9d", 16)
+	y, _ = new(big.Int).SetString("902910d1fef15d8ae2006fc84f2a5a7bda0e0407dc913062c3a493c4f5d876a5", 16)
+	testHashToCurve[emulated.Secp256k1Fp, emulated.Secp256k1Fr](assert, []byte("abc"), dst, true, x, y)
+}
+
+func TestHashToCurveBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	p, err := bls12381.HashToG1([]byte("abc"), dst)
+	assert.NoError(err)
+	testHashToCurve[emulated.BLS12381Fp, emulated.BLS12381Fr](assert, []byte("abc"), dst, false, p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int)))
+}
+
+func TestHashToCurveP256(t *testing.T) {
+	assert := test.NewAssert(t)
+	// test vector from RFC 9380 Appendix J.1.1
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+	x, _ := new(big.Int).SetString("2c15230b26dbc6fc9a37051158c95b79656e17a1a920b11394ca91c44247d3e4", 16)
+	y, _ := new(big.Int).SetString("8a7a74985cc5c776cdfe4b1f19884970453912e9d31528c060be9ab5c43e8415", 16)
+	testHashToCurve[emulated.P256Fp, emulated.P256Fr](assert, nil, dst, false, x, y)
+}
@@ -14,7 +14,7 @@ func init() {
 }
 
 func GetHints() []solver.Hint {
-	return []solver.Hint{decomposeScalarG1, decomposeScalarG1Signs, decomposeScalarG1Subscalars}
+	return []solver.Hint{decomposeScalarG1, decomposeScalarG1Signs, decomposeScalarG1Subscalars, isSquareHint}
 }
 
 func decomposeScalarG1Subscalars(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
@@ -109,3 +109,19 @@ func decomposeScalarG1(mod *big.Int, inputs []*big.Int, outputs []*big.Int) erro
 		return nil
 	})
 }
+
+func isSquareHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(inputs, outputs, func(field *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 {
+			return fmt.Errorf("expecting one input")
+		}
+		if len(outputs) != 1 {
+			return fmt.Errorf("expecting one output")
+		}
+		outputs[0].SetUint64(0)
+		if big.Jacobi(inputs[0], field) >= 0 {
+			outputs[0].SetUint64(1)
+		}
+		return nil
+	})
+}
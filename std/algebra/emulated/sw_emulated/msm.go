@@ -0,0 +1,321 @@
+package sw_emulated
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// multiScalarMulWindowed computes ∑ᵢ [sᵢ]pᵢ and returns it. It doesn't modify
+// the inputs. The scalars are processed in windows of windowSize bits from the
+// most significant window, so that the doublings are shared between all the
+// points. The window multiples of every point are precomputed once and stored
+// in lookup tables, so that selecting a multiple costs a constant number of
+// constraints independently of windowSize.
+//
+// To avoid the point at infinity in the tables and the accumulator, we use the
+// regular signed recoding with odd digits. For an odd scalar s of m bits and
+// t = (s+2ᵐ-1)/2 with bits tᵢ we have s = ∑ᵢ (2tᵢ-1)2ⁱ, so that the digit of
+// every window is odd and lies in [-(2ʷ-1), 2ʷ-1]. Even scalars are incremented
+// by one and the corresponding point is subtracted at the end.
+//
+// nbScalarBits limits the number of processed scalar bits when positive. When
+// complete is set, then all the additions use [Curve.AddUnified] and the
+// inputs may be (0,0) or zero.
+//
+// ⚠️  When complete is not set, then the scalars must be nonzero and the points
+// different from (0,0). Additionally, the intermediate sums must not collide,
+// which holds with overwhelming probability for independent inputs.
+func (c *Curve[B, S]) multiScalarMulWindowed(p []*AffinePoint[B], s []*emulated.Element[S], windowSize, nbScalarBits int, complete bool) *AffinePoint[B] {
+	var st S
+	nbBits := st.Modulus().BitLen()
+	if nbScalarBits > 0 && nbScalarBits < nbBits {
+		nbBits = nbScalarBits
+	}
+	nbWindows := (nbBits + windowSize - 1) / windowSize
+	nbEntries := 1 << windowSize
+
+	add, double := c.add, c.double
+	doubleAndAdd := c.doubleAndAdd
+	if complete {
+		add = c.AddUnified
+		double = func(q *AffinePoint[B]) *AffinePoint[B] { return c.AddUnified(q, q) }
+		doubleAndAdd = func(q, r *AffinePoint[B]) *AffinePoint[B] { return c.AddUnified(c.AddUnified(q, q), r) }
+	}
+
+	// precompute the table of odd multiples [-(2ʷ-1)]pᵢ, ..., [-1]pᵢ, [1]pᵢ,
+	// ..., [2ʷ-1]pᵢ. The entry k of the table of pᵢ is at index i*2ʷ+k.
+	tableX, tableY := c.baseApi.NewTable(), c.baseApi.NewTable()
+	odd := make([]*AffinePoint[B], nbEntries/2)
+	for i := range p {
+		odd[0] = p[i]
+		if len(odd) > 1 {
+			p2 := double(p[i])
+			for k := 1; k < len(odd); k++ {
+				odd[k] = add(odd[k-1], p2)
+			}
+		}
+		for k := 0; k < nbEntries; k++ {
+			// digit 2k-2ʷ+1
+			var e *AffinePoint[B]
+			if k < nbEntries/2 {
+				e = c.Neg(odd[nbEntries/2-1-k])
+			} else {
+				e = odd[k-nbEntries/2]
+			}
+			tableX.Insert(&e.X)
+			tableY.Insert(&e.Y)
+		}
+	}
+
+	sLsb, tBits := c.oddRecoding(s, nbBits, nbWindows*windowSize)
+	indices := make([][]frontend.Variable, nbWindows)
+	for j := range indices {
+		indices[j] = make([]frontend.Variable, len(s))
+		for i := range s {
+			idx := frontend.Variable(i * nbEntries)
+			for k := 0; k < windowSize; k++ {
+				idx = c.api.Add(idx, c.api.Mul(tBits[i][j*windowSize+k], 1<<k))
+			}
+			indices[j][i] = idx
+		}
+	}
+
+	lookup := func(j int) []*AffinePoint[B] {
+		xs := tableX.Lookup(indices[j]...)
+		ys := tableY.Lookup(indices[j]...)
+		res := make([]*AffinePoint[B], len(xs))
+		for i := range res {
+			res[i] = &AffinePoint[B]{X: *xs[i], Y: *ys[i]}
+		}
+		return res
+	}
+
+	// most significant window
+	q := lookup(nbWindows - 1)
+	acc := q[0]
+	for i := 1; i < len(q); i++ {
+		acc = add(acc, q[i])
+	}
+	for j := nbWindows - 2; j >= 0; j-- {
+		q = lookup(j)
+		for k := 0; k < windowSize-1; k++ {
+			acc = double(acc)
+		}
+		acc = doubleAndAdd(acc, q[0])
+		for i := 1; i < len(q); i++ {
+			acc = add(acc, q[i])
+		}
+	}
+
+	// subtract the points whose scalars were made odd
+	for i := range p {
+		acc = c.Select(sLsb[i], acc, add(acc, c.Neg(p[i])))
+	}
+	return acc
+}
+
+// oddRecoding returns the least significant bits of the scalars s and the m
+// bits of t = (s'+2ᵐ-1)/2 for every scalar, where s' is s made odd and m is
+// nbDigits. Only the nbBits least significant bits of the scalars are used, so
+// the bits of t are s₁, s₂, ..., sₙ₋₁ followed by zeros and a final 1, where n
+// is nbBits.
+func (c *Curve[B, S]) oddRecoding(s []*emulated.Element[S], nbBits, nbDigits int) (sLsb []frontend.Variable, tBits [][]frontend.Variable) {
+	sLsb = make([]frontend.Variable, len(s))
+	tBits = make([][]frontend.Variable, len(s))
+	for i := range s {
+		sBits := c.scalarApi.ToBits(c.scalarApi.Reduce(s[i]))
+		sLsb[i] = sBits[0]
+		tBits[i] = make([]frontend.Variable, nbDigits)
+		for k := range tBits[i] {
+			if k+1 < nbBits {
+				tBits[i][k] = sBits[k+1]
+			} else {
+				tBits[i][k] = 0
+			}
+		}
+		tBits[i][nbDigits-1] = 1
+	}
+	return sLsb, tBits
+}
+
+// multiScalarMulBuckets computes ∑ᵢ [sᵢ]pᵢ and returns it using the bucket
+// method of Pippenger. It doesn't modify the inputs. The scalars are recoded
+// into odd signed digits as in [Curve.multiScalarMulWindowed]. For every
+// window, the points are accumulated into 2ʷ⁻¹ buckets indexed by the absolute
+// value of their digit, and the window sum ∑ₖ [2k+1]Bₖ is obtained with the
+// running sums Tₖ = Bₖ + ... + B_{2ʷ⁻¹-1} as 2∑ₖTₖ - T₀. The buckets are kept
+// in a read-write memory (see [emulated.Memory]), so that adding a point to a
+// bucket costs a single point addition independently of windowSize. Differently
+// from the windowed method, there is no precomputation per point, so larger
+// windows can be used.
+//
+// To avoid the point at infinity, the buckets are initialized with multiples of
+// a fixed point R whose discrete logarithm is not related to the inputs, see
+// [Curve.bucketOffsets]. The accumulated offset is subtracted at the end.
+//
+// nbScalarBits limits the number of processed scalar bits when positive. When
+// complete is set, then all the additions use [Curve.AddUnified] and the
+// inputs may be (0,0) or zero.
+//
+// ⚠️  When complete is not set, then the scalars must be nonzero and the points
+// different from (0,0). Additionally, the intermediate sums must not collide,
+// which holds with overwhelming probability for independent inputs.
+func (c *Curve[B, S]) multiScalarMulBuckets(p []*AffinePoint[B], s []*emulated.Element[S], windowSize, nbScalarBits int, complete bool) *AffinePoint[B] {
+	var st S
+	nbBits := st.Modulus().BitLen()
+	if nbScalarBits > 0 && nbScalarBits < nbBits {
+		nbBits = nbScalarBits
+	}
+	nbWindows := (nbBits + windowSize - 1) / windowSize
+	nbBuckets := 1 << (windowSize - 1)
+
+	add, double := c.add, c.double
+	if complete {
+		add = c.AddUnified
+		double = func(q *AffinePoint[B]) *AffinePoint[B] { return c.AddUnified(q, q) }
+	}
+
+	// the digit of window j of the scalar i is 2v-2ʷ+1 where v is the value of
+	// the window bits of t. The most significant bit of the window gives the
+	// sign of the digit and the bucket index is (|digit|-1)/2.
+	sLsb, tBits := c.oddRecoding(s, nbBits, nbWindows*windowSize)
+	negP := make([]*emulated.Element[B], len(p))
+	for i := range p {
+		negP[i] = c.baseApi.Neg(&p[i].Y)
+	}
+
+	// the initial bucket values and the accumulated offset
+	init, offset := c.bucketOffsets(nbWindows, windowSize)
+
+	var acc *AffinePoint[B]
+	for j := nbWindows - 1; j >= 0; j-- {
+		buckets := c.baseApi.NewMemory(init)
+		read := func(idx frontend.Variable) *AffinePoint[B] {
+			xy := buckets.Read(idx)
+			return &AffinePoint[B]{X: *xy[0], Y: *xy[1]}
+		}
+		for i := range p {
+			sign := tBits[i][(j+1)*windowSize-1]
+			var low frontend.Variable = 0
+			for k := 0; k < windowSize-1; k++ {
+				low = c.api.Add(low, c.api.Mul(tBits[i][j*windowSize+k], 1<<k))
+			}
+			// idx = low if sign = 1 and 2ʷ⁻¹-1-low otherwise
+			idx := c.api.Add(c.api.Mul(c.api.Sub(c.api.Mul(sign, 2), 1), low), c.api.Mul(c.api.Sub(1, sign), nbBuckets-1))
+			q := &AffinePoint[B]{X: p[i].X, Y: *c.baseApi.Select(sign, &p[i].Y, negP[i])}
+			bucket := add(read(idx), q)
+			buckets.Write(idx, []*emulated.Element[B]{&bucket.X, &bucket.Y})
+		}
+		// window sum ∑ₖ [2k+1]Bₖ
+		sum := read(nbBuckets - 1)
+		running := sum
+		for k := nbBuckets - 2; k >= 0; k-- {
+			sum = add(sum, read(k))
+			running = add(running, sum)
+		}
+		w := sum
+		if nbBuckets > 1 {
+			w = add(double(running), c.Neg(sum))
+		}
+		if acc == nil {
+			acc = w
+			continue
+		}
+		for k := 0; k < windowSize; k++ {
+			acc = double(acc)
+		}
+		acc = add(acc, w)
+	}
+	acc = add(acc, offset)
+
+	// subtract the points whose scalars were made odd
+	for i := range p {
+		acc = c.Select(sLsb[i], acc, add(acc, c.Neg(p[i])))
+	}
+	return acc
+}
+
+// bucketOffsets returns the initial values of the buckets in
+// [Curve.multiScalarMulBuckets] and the negation of the accumulated offset. The
+// bucket k is initialized with [2ᵏ]R, where R is the multiple of the generator
+// by a scalar derived from a fixed string, so that the empty buckets don't
+// collide when combining them. Every window sum is then offset by [∑ₖ
+// (2k+1)2ᵏ]R. The points are computed out of circuit.
+func (c *Curve[B, S]) bucketOffsets(nbWindows, windowSize int) (init [][]*emulated.Element[B], offset *AffinePoint[B]) {
+	var fr S
+	h := sha256.Sum256([]byte("gnark msm bucket offset"))
+	r := new(big.Int).SetBytes(h[:])
+	r.Mod(r, fr.Modulus())
+	nbBuckets := 1 << (windowSize - 1)
+	init = make([][]*emulated.Element[B], nbBuckets)
+	// ∑ⱼ 2ʷʲ ∑ₖ (2k+1)2ᵏ
+	windowOff := new(big.Int)
+	for k := range init {
+		rk := new(big.Int).Lsh(r, uint(k))
+		x, y := c.scalarMulGeneratorConstant(rk.Mod(rk, fr.Modulus()))
+		if x == nil {
+			panic("bucket offset is the point at infinity")
+		}
+		init[k] = []*emulated.Element[B]{c.baseApi.NewElement(x), c.baseApi.NewElement(y)}
+		windowOff.Add(windowOff, new(big.Int).Lsh(big.NewInt(int64(2*k+1)), uint(k)))
+	}
+	off := new(big.Int)
+	for j := 0; j < nbWindows; j++ {
+		off.Add(off, new(big.Int).Lsh(windowOff, uint(windowSize*j)))
+	}
+	off.Mul(off, r).Neg(off).Mod(off, fr.Modulus())
+	x, y := c.scalarMulGeneratorConstant(off)
+	if x == nil {
+		panic("bucket offset is the point at infinity")
+	}
+	return init, &AffinePoint[B]{X: *c.baseApi.NewElement(x), Y: *c.baseApi.NewElement(y)}
+}
+
+// scalarMulGeneratorConstant returns the affine coordinates of [k]G computed
+// out of circuit, where G is the generator of the curve. It returns nil
+// coordinates for the point at infinity.
+func (c *Curve[B, S]) scalarMulGeneratorConstant(k *big.Int) (x, y *big.Int) {
+	p := c.baseModulus()
+	a := c.params.A
+	// addConstant returns (x1,y1)+(x2,y2) where nil represents the infinity.
+	addConstant := func(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+		if x1 == nil {
+			return x2, y2
+		}
+		if x2 == nil {
+			return x1, y1
+		}
+		var l *big.Int
+		if x1.Cmp(x2) == 0 {
+			if new(big.Int).Add(y1, y2).Mod(new(big.Int).Add(y1, y2), p).Sign() == 0 {
+				return nil, nil
+			}
+			// λ = (3x²+a)/2y
+			l = new(big.Int).Mul(x1, x1)
+			l.Mul(l, big.NewInt(3)).Add(l, a)
+			den := new(big.Int).Lsh(y1, 1)
+			l.Mul(l, den.ModInverse(den.Mod(den, p), p))
+		} else {
+			// λ = (y₂-y₁)/(x₂-x₁)
+			l = new(big.Int).Sub(y2, y1)
+			den := new(big.Int).Sub(x2, x1)
+			l.Mul(l, den.ModInverse(den.Mod(den, p), p))
+		}
+		l.Mod(l, p)
+		x3 := new(big.Int).Mul(l, l)
+		x3.Sub(x3, x1).Sub(x3, x2).Mod(x3, p)
+		y3 := new(big.Int).Sub(x1, x3)
+		y3.Mul(y3, l).Sub(y3, y1).Mod(y3, p)
+		return x3, y3
+	}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		x, y = addConstant(x, y, x, y)
+		if k.Bit(i) == 1 {
+			x, y = addConstant(x, y, c.params.Gx, c.params.Gy)
+		}
+	}
+	return x, y
+}
@@ -4,8 +4,10 @@ import (
 	"crypto/elliptic"
 	"math/big"
 
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
 	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1"
 	"github.com/consensys/gnark/std/math/emulated"
@@ -25,6 +27,9 @@ type CurveParams struct {
 	Gm           [][2]*big.Int // m*base point coords
 	Eigenvalue   *big.Int      // endomorphism eigenvalue
 	ThirdRootOne *big.Int      // endomorphism image scaler
+	// MapToCurve are the parameters for hashing to the curve. It is nil if
+	// hashing to the curve is not supported.
+	MapToCurve *MapToCurveParams
 }
 
 // GetSecp256k1Params returns curve parameters for the curve secp256k1. When
@@ -42,6 +47,7 @@ func GetSecp256k1Params() CurveParams {
 		Gm:           computeSecp256k1Table(),
 		Eigenvalue:   lambda,
 		ThirdRootOne: omega,
+		MapToCurve:   getSecp256k1MapParams(emulated.Secp256k1Fp{}.Modulus()),
 	}
 }
 
@@ -60,6 +66,7 @@ func GetBN254Params() CurveParams {
 		Gm:           computeBN254Table(),
 		Eigenvalue:   lambda,
 		ThirdRootOne: omega,
+		MapToCurve:   getBN254MapParams(),
 	}
 }
 
@@ -78,6 +85,25 @@ func GetBLS12381Params() CurveParams {
 		Gm:           computeBLS12381Table(),
 		Eigenvalue:   lambda,
 		ThirdRootOne: omega,
+		MapToCurve:   getBLS12381MapParams(),
+	}
+}
+
+// GetBLS12377Params returns the curve parameters for the curve BLS12-377.
+// When initialising new curve, use the base field [emulated.BLS12377Fp] and scalar
+// field [emulated.BLS12377Fr].
+func GetBLS12377Params() CurveParams {
+	_, _, g1aff, _ := bls12377.Generators()
+	lambda, _ := new(big.Int).SetString("91893752504881257701523279626832445440", 10)
+	omega, _ := new(big.Int).SetString("80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945", 10)
+	return CurveParams{
+		A:            big.NewInt(0),
+		B:            big.NewInt(1),
+		Gx:           g1aff.X.BigInt(new(big.Int)),
+		Gy:           g1aff.Y.BigInt(new(big.Int)),
+		Gm:           computeBLS12377Table(),
+		Eigenvalue:   lambda,
+		ThirdRootOne: omega,
 	}
 }
 
@@ -95,6 +121,7 @@ func GetP256Params() CurveParams {
 		Gm:           computeP256Table(),
 		Eigenvalue:   nil,
 		ThirdRootOne: nil,
+		MapToCurve:   getP256MapParams(pr.P),
 	}
 }
 
@@ -133,6 +160,24 @@ func GetBW6761Params() CurveParams {
 	}
 }
 
+// GetBW6633Params returns the curve parameters for the curve BW6-633.
+// When initialising new curve, use the base field [emulated.BW6633Fp] and scalar
+// field [emulated.BW6633Fr].
+func GetBW6633Params() CurveParams {
+	_, _, g1aff, _ := bw6633.Generators()
+	lambda, _ := new(big.Int).SetString("39705142672498995661671850106945620852186608752525090699191017895721506694646055668218723303426", 10)
+	omega, _ := new(big.Int).SetString("4098895725012429242072311240482566844345873033931481129362557724405008256668293241245050359832461015092695507587185678086043587575438449040313411246717257958467499181450742260777082884928318", 10)
+	return CurveParams{
+		A:            big.NewInt(0),
+		B:            big.NewInt(4),
+		Gx:           g1aff.X.BigInt(new(big.Int)),
+		Gy:           g1aff.Y.BigInt(new(big.Int)),
+		Gm:           computeBW6633Table(),
+		Eigenvalue:   lambda,
+		ThirdRootOne: omega,
+	}
+}
+
 // GetCurveParams returns suitable curve parameters given the parametric type
 // Base as base field. It caches the parameters and modifying the values in the
 // parameters struct leads to undefined behaviour.
@@ -145,12 +190,16 @@ func GetCurveParams[Base emulated.FieldParams]() CurveParams {
 		return bn254Params
 	case emulated.BLS12381Fp{}.Modulus().String():
 		return bls12381Params
+	case emulated.BLS12377Fp{}.Modulus().String():
+		return bls12377Params
 	case emulated.P256Fp{}.Modulus().String():
 		return p256Params
 	case emulated.P384Fp{}.Modulus().String():
 		return p384Params
 	case emulated.BW6761Fp{}.Modulus().String():
 		return bw6761Params
+	case emulated.BW6633Fp{}.Modulus().String():
+		return bw6633Params
 	default:
 		panic("no stored parameters")
 	}
@@ -160,16 +209,20 @@ var (
 	secp256k1Params CurveParams
 	bn254Params     CurveParams
 	bls12381Params  CurveParams
+	bls12377Params  CurveParams
 	p256Params      CurveParams
 	p384Params      CurveParams
 	bw6761Params    CurveParams
+	bw6633Params    CurveParams
 )
 
 func init() {
 	secp256k1Params = GetSecp256k1Params()
 	bn254Params = GetBN254Params()
 	bls12381Params = GetBLS12381Params()
+	bls12377Params = GetBLS12377Params()
 	p256Params = GetP256Params()
 	p384Params = GetP384Params()
 	bw6761Params = GetBW6761Params()
+	bw6633Params = GetBW6633Params()
 }
@@ -1206,6 +1206,10 @@ func (c *Curve[B, S]) JointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Ele
 // scalars s. It returns an error if the length of the slices mismatch. If the
 // input slices are empty, then returns point at infinity.
 //
+// For large number of points, use [algopts.WithWindowedMSM] option to share
+// the doublings between all the points and select the window multiples from
+// lookup tables, or [algopts.WithBucketMSM] for the bucket method.
+//
 // ⚠️  Points and scalars must be nonzero.
 func (c *Curve[B, S]) MultiScalarMul(p []*AffinePoint[B], s []*emulated.Element[S], opts ...algopts.AlgebraOption) (*AffinePoint[B], error) {
 
@@ -1223,6 +1227,27 @@ func (c *Curve[B, S]) MultiScalarMul(p []*AffinePoint[B], s []*emulated.Element[
 	if cfg.CompleteArithmetic {
 		addFn = c.AddUnified
 	}
+	if cfg.MSMWindowSize > 0 {
+		if cfg.FoldMulti {
+			// scalars are powers, we expand them as 1, gamma, gamma^2, ...
+			if len(s) == 0 {
+				return nil, fmt.Errorf("need scalar for folding")
+			}
+			gamma := s[0]
+			s = make([]*emulated.Element[S], len(p))
+			s[0] = c.scalarApi.One()
+			for i := 1; i < len(p); i++ {
+				s[i] = c.scalarApi.Mul(s[i-1], gamma)
+			}
+		}
+		if len(p) != len(s) {
+			return nil, fmt.Errorf("mismatching points and scalars slice lengths")
+		}
+		if cfg.MSMBuckets {
+			return c.multiScalarMulBuckets(p, s, cfg.MSMWindowSize, cfg.NbScalarBits, cfg.CompleteArithmetic), nil
+		}
+		return c.multiScalarMulWindowed(p, s, cfg.MSMWindowSize, cfg.NbScalarBits, cfg.CompleteArithmetic), nil
+	}
 	if !cfg.FoldMulti {
 		// the scalars are unique
 		if len(p) != len(s) {
@@ -11,6 +11,8 @@ import (
 	fr_bls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	fr_bn "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	fr_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
 	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
 	fp_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fp"
 	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
@@ -18,6 +20,7 @@ import (
 	fp_secp "github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
 	fr_secp "github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/std/algebra/algopts"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/math/emulated/emparams"
@@ -619,6 +622,28 @@ func TestScalarMulBase6(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestScalarMulBase7(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bw6633.Generators()
+	var r fr_bw6633.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var S bw6633.G1Affine
+	S.ScalarMultiplication(&g, s)
+
+	circuit := ScalarMulBaseTest[emulated.BW6633Fp, emulated.BW6633Fr]{}
+	witness := ScalarMulBaseTest[emulated.BW6633Fp, emulated.BW6633Fr]{
+		S: emulated.ValueOf[emulated.BW6633Fr](s),
+		Q: AffinePoint[emulated.BW6633Fp]{
+			X: emulated.ValueOf[emulated.BW6633Fp](S.X),
+			Y: emulated.ValueOf[emulated.BW6633Fp](S.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
 type ScalarMulTest[T, S emulated.FieldParams] struct {
 	P, Q AffinePoint[T]
 	S    emulated.Element[S]
@@ -784,6 +809,32 @@ func TestScalarMul6(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestScalarMul7(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_bw6633.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var res bw6633.G1Affine
+	_, _, gen, _ := bw6633.Generators()
+	res.ScalarMultiplication(&gen, s)
+
+	circuit := ScalarMulTest[emulated.BW6633Fp, emulated.BW6633Fr]{}
+	witness := ScalarMulTest[emulated.BW6633Fp, emulated.BW6633Fr]{
+		S: emulated.ValueOf[emulated.BW6633Fr](s),
+		P: AffinePoint[emulated.BW6633Fp]{
+			X: emulated.ValueOf[emulated.BW6633Fp](gen.X),
+			Y: emulated.ValueOf[emulated.BW6633Fp](gen.Y),
+		},
+		Q: AffinePoint[emulated.BW6633Fp]{
+			X: emulated.ValueOf[emulated.BW6633Fp](res.X),
+			Y: emulated.ValueOf[emulated.BW6633Fp](res.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
 type ScalarMulEdgeCasesTest[T, S emulated.FieldParams] struct {
 	P, R AffinePoint[T]
 	S    emulated.Element[S]
@@ -1227,6 +1278,244 @@ func TestMultiScalarMul(t *testing.T) {
 	assert.NoError(err)
 }
 
+type MultiScalarMulWindowedTest[T, S emulated.FieldParams] struct {
+	Points       []AffinePoint[T]
+	Scalars      []emulated.Element[S]
+	Res          AffinePoint[T]
+	windowSize   int
+	buckets      bool
+	complete     bool
+	fold         bool
+	nbScalarBits int
+}
+
+func (c *MultiScalarMulWindowedTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	ps := make([]*AffinePoint[T], len(c.Points))
+	for i := range c.Points {
+		ps[i] = &c.Points[i]
+	}
+	ss := make([]*emulated.Element[S], len(c.Scalars))
+	for i := range c.Scalars {
+		ss[i] = &c.Scalars[i]
+	}
+	var opts []algopts.AlgebraOption
+	if c.windowSize > 0 && c.buckets {
+		opts = append(opts, algopts.WithBucketMSM(c.windowSize))
+	} else if c.windowSize > 0 {
+		opts = append(opts, algopts.WithWindowedMSM(c.windowSize))
+	}
+	if c.complete {
+		opts = append(opts, algopts.WithCompleteArithmetic())
+	}
+	if c.fold {
+		opts = append(opts, algopts.WithFoldingScalarMul())
+	}
+	if c.nbScalarBits > 0 {
+		opts = append(opts, algopts.WithNbScalarBits(c.nbScalarBits))
+	}
+	res, err := cr.MultiScalarMul(ps, ss, opts...)
+	if err != nil {
+		return err
+	}
+	cr.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMultiScalarMulWindowed(t *testing.T) {
+	assert := test.NewAssert(t)
+	nbLen := 5
+	P := make([]bn254.G1Affine, nbLen)
+	S := make([]fr_bn.Element, nbLen)
+	for i := 0; i < nbLen; i++ {
+		S[i].SetRandom()
+		P[i].ScalarMultiplicationBase(S[i].BigInt(new(big.Int)))
+	}
+	// make one of the scalars even and one odd to cover both recodings
+	S[0].SetUint64(1234)
+	S[1].SetUint64(4321)
+	var res bn254.G1Affine
+	_, err := res.MultiExp(P, S, ecc.MultiExpConfig{})
+	assert.NoError(err)
+
+	cP := make([]AffinePoint[emulated.BN254Fp], len(P))
+	for i := range cP {
+		cP[i] = AffinePoint[emulated.BN254Fp]{
+			X: emulated.ValueOf[emulated.BN254Fp](P[i].X),
+			Y: emulated.ValueOf[emulated.BN254Fp](P[i].Y),
+		}
+	}
+	cS := make([]emulated.Element[emulated.BN254Fr], len(S))
+	for i := range cS {
+		cS[i] = emulated.ValueOf[emulated.BN254Fr](S[i])
+	}
+	for _, buckets := range []bool{false, true} {
+		for _, windowSize := range []int{1, 3, 4} {
+			for _, complete := range []bool{false, true} {
+				assignment := MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+					Points:  cP,
+					Scalars: cS,
+					Res: AffinePoint[emulated.BN254Fp]{
+						X: emulated.ValueOf[emulated.BN254Fp](res.X),
+						Y: emulated.ValueOf[emulated.BN254Fp](res.Y),
+					},
+				}
+				err = test.IsSolved(&MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+					Points:     make([]AffinePoint[emulated.BN254Fp], nbLen),
+					Scalars:    make([]emulated.Element[emulated.BN254Fr], nbLen),
+					windowSize: windowSize,
+					buckets:    buckets,
+					complete:   complete,
+				}, &assignment, ecc.BN254.ScalarField())
+				assert.NoError(err, "buckets %t, window size %d, complete %t", buckets, windowSize, complete)
+			}
+		}
+	}
+}
+
+func TestMultiScalarMulWindowedConstraints(t *testing.T) {
+	assert := test.NewAssert(t)
+	nbLen := 16
+	nbConstraints := func(windowSize int) int {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+			Points:     make([]AffinePoint[emulated.BN254Fp], nbLen),
+			Scalars:    make([]emulated.Element[emulated.BN254Fr], nbLen),
+			windowSize: windowSize,
+		})
+		assert.NoError(err)
+		return ccs.GetNbConstraints()
+	}
+	naive := nbConstraints(0)
+	for _, windowSize := range []int{4, 5} {
+		windowed := nbConstraints(windowSize)
+		assert.Less(windowed, naive, "window size %d", windowSize)
+		t.Logf("%d points: %d constraints, %d with window size %d", nbLen, naive, windowed, windowSize)
+	}
+}
+
+func TestMultiScalarMulBucketsConstraints(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the bucket method doesn't have precomputations per point, so that the
+	// cost of an additional point is lower than with the windowed method. We
+	// use short scalars to keep the circuits small.
+	nbConstraints := func(nbLen, windowSize int, buckets bool) int {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+			Points:       make([]AffinePoint[emulated.BN254Fp], nbLen),
+			Scalars:      make([]emulated.Element[emulated.BN254Fr], nbLen),
+			windowSize:   windowSize,
+			buckets:      buckets,
+			nbScalarBits: 64,
+		})
+		assert.NoError(err)
+		return ccs.GetNbConstraints()
+	}
+	windowed := nbConstraints(16, 4, false) - nbConstraints(8, 4, false)
+	buckets := nbConstraints(16, 6, true) - nbConstraints(8, 6, true)
+	assert.Less(buckets, windowed)
+	t.Logf("constraints per point: %d with windows, %d with buckets", windowed/8, buckets/8)
+}
+
+func TestMultiScalarMulWindowedEdgeCases(t *testing.T) {
+	assert := test.NewAssert(t)
+	nbLen := 4
+	P := make([]bn254.G1Affine, nbLen)
+	S := make([]fr_bn.Element, nbLen)
+	for i := 0; i < nbLen; i++ {
+		S[i].SetRandom()
+		P[i].ScalarMultiplicationBase(S[i].BigInt(new(big.Int)))
+	}
+	// s1 * (0,0) + s2 * P2 + 0 * P3 + s4 * P4 == s2 * P2 + s4 * P4
+	var res, tmp bn254.G1Affine
+	res.ScalarMultiplication(&P[1], S[1].BigInt(new(big.Int)))
+	tmp.ScalarMultiplication(&P[3], S[3].BigInt(new(big.Int)))
+	res.Add(&res, &tmp)
+	var infinity bn254.G1Affine
+	P[0] = infinity
+	S[2].SetZero()
+
+	cP := make([]AffinePoint[emulated.BN254Fp], len(P))
+	for i := range cP {
+		cP[i] = AffinePoint[emulated.BN254Fp]{
+			X: emulated.ValueOf[emulated.BN254Fp](P[i].X),
+			Y: emulated.ValueOf[emulated.BN254Fp](P[i].Y),
+		}
+	}
+	cS := make([]emulated.Element[emulated.BN254Fr], len(S))
+	for i := range cS {
+		cS[i] = emulated.ValueOf[emulated.BN254Fr](S[i])
+	}
+	assignment := MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+		Points:  cP,
+		Scalars: cS,
+		Res: AffinePoint[emulated.BN254Fp]{
+			X: emulated.ValueOf[emulated.BN254Fp](res.X),
+			Y: emulated.ValueOf[emulated.BN254Fp](res.Y),
+		},
+	}
+	err := test.IsSolved(&MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+		Points:     make([]AffinePoint[emulated.BN254Fp], nbLen),
+		Scalars:    make([]emulated.Element[emulated.BN254Fr], nbLen),
+		windowSize: 3,
+		complete:   true,
+	}, &assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+		Points:     make([]AffinePoint[emulated.BN254Fp], nbLen),
+		Scalars:    make([]emulated.Element[emulated.BN254Fr], nbLen),
+		windowSize: 3,
+		buckets:    true,
+		complete:   true,
+	}, &assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestMultiScalarMulWindowedFolded(t *testing.T) {
+	assert := test.NewAssert(t)
+	nbLen := 4
+	P := make([]bn254.G1Affine, nbLen)
+	S := make([]fr_bn.Element, nbLen)
+	var gamma fr_bn.Element
+	gamma.SetRandom()
+	S[0].SetOne()
+	for i := 0; i < nbLen; i++ {
+		if i > 0 {
+			S[i].Mul(&S[i-1], &gamma)
+		}
+		var r fr_bn.Element
+		r.SetRandom()
+		P[i].ScalarMultiplicationBase(r.BigInt(new(big.Int)))
+	}
+	var res bn254.G1Affine
+	_, err := res.MultiExp(P, S, ecc.MultiExpConfig{})
+	assert.NoError(err)
+
+	cP := make([]AffinePoint[emulated.BN254Fp], len(P))
+	for i := range cP {
+		cP[i] = AffinePoint[emulated.BN254Fp]{
+			X: emulated.ValueOf[emulated.BN254Fp](P[i].X),
+			Y: emulated.ValueOf[emulated.BN254Fp](P[i].Y),
+		}
+	}
+	assignment := MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+		Points:  cP,
+		Scalars: []emulated.Element[emulated.BN254Fr]{emulated.ValueOf[emulated.BN254Fr](gamma)},
+		Res: AffinePoint[emulated.BN254Fp]{
+			X: emulated.ValueOf[emulated.BN254Fp](res.X),
+			Y: emulated.ValueOf[emulated.BN254Fp](res.Y),
+		},
+	}
+	err = test.IsSolved(&MultiScalarMulWindowedTest[emulated.BN254Fp, emulated.BN254Fr]{
+		Points:     make([]AffinePoint[emulated.BN254Fp], nbLen),
+		Scalars:    make([]emulated.Element[emulated.BN254Fr], 1),
+		windowSize: 4,
+		fold:       true,
+	}, &assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 type MultiScalarMulFoldedEdgeCasesTest[T, S emulated.FieldParams] struct {
 	Points  []AffinePoint[T]
 	Scalars []emulated.Element[S]
@@ -0,0 +1,185 @@
+package sw_emulated
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// AssertIsInSubgroup asserts that p is on the curve and in the prime order
+// subgroup generated by the base point. It doesn't modify p.
+//
+// For prime order curves (secp256k1, P-256, P-384 and BN254) the check reduces
+// to [Curve.AssertIsOnCurve]. For the G1 groups of the pairing-friendly curves
+// BLS12-381, BLS12-377, BW6-761 and BW6-633 it uses the endomorphism-based
+// tests with small scalars instead of checking [r]p == 0. The tests use
+// complete arithmetic, so that they are sound for any point of the curve,
+// including the points of small order. It panics for curves without a known
+// check.
+//
+// (0,0) is conventionally the point at infinity and is in the subgroup.
+func (c *Curve[B, S]) AssertIsInSubgroup(p *AffinePoint[B]) {
+	c.AssertIsOnCurve(p)
+
+	var fp B
+	switch fp.Modulus().String() {
+	case emulated.Secp256k1Fp{}.Modulus().String(),
+		emulated.P256Fp{}.Modulus().String(),
+		emulated.P384Fp{}.Modulus().String(),
+		emulated.BN254Fp{}.Modulus().String():
+		// prime order curves, nothing else to check
+	case emulated.BLS12381Fp{}.Modulus().String():
+		c.assertIsInSubgroupBLS12(p, seedBLS12381)
+	case emulated.BLS12377Fp{}.Modulus().String():
+		c.assertIsInSubgroupBLS12(p, seedBLS12377)
+	case emulated.BW6761Fp{}.Modulus().String():
+		c.assertIsInSubgroupBW6761(p)
+	case emulated.BW6633Fp{}.Modulus().String():
+		c.assertIsInSubgroupBW6633(p)
+	default:
+		panic("subgroup check not implemented for the curve")
+	}
+}
+
+var (
+	// seedBLS12381 is the seed x₀ of BLS12-381.
+	seedBLS12381, _ = new(big.Int).SetString("-15132376222941642752", 10)
+	// seedBLS12377 is the seed x₀ of BLS12-377 and BW6-761.
+	seedBLS12377, _ = new(big.Int).SetString("9586122913090633729", 10)
+	// seedBW6633 is the seed x₀ of BW6-633 (and BLS24-315).
+	seedBW6633, _ = new(big.Int).SetString("-3218079743", 10)
+)
+
+// assertIsInSubgroupBLS12 checks that p = -[x₀²]ϕ(p), see
+// https://eprint.iacr.org/2021/1130.pdf.
+func (c *Curve[B, S]) assertIsInSubgroupBLS12(p *AffinePoint[B], x0 *big.Int) {
+	x02 := new(big.Int).Mul(x0, x0)
+	_p := c.scalarMulByConstant(c.phi(p), x02)
+	_p = c.Neg(_p)
+	c.AssertIsEqual(_p, p)
+}
+
+// assertIsInSubgroupBW6761 checks that [x₀+1]p == [-x₀³+x₀²-1]ϕ(p), see
+// https://eprint.iacr.org/2022/352.pdf.
+func (c *Curve[B, S]) assertIsInSubgroupBW6761(p *AffinePoint[B]) {
+	xP := c.scalarMulByConstant(p, seedBLS12377)
+	x2P := c.scalarMulByConstant(xP, seedBLS12377)
+	x3P := c.scalarMulByConstant(x2P, seedBLS12377)
+
+	left := c.addComplete(xP, p)
+	right := c.addComplete(x2P, c.Neg(x3P))
+	right = c.addComplete(right, c.Neg(p))
+	right = c.phi(right)
+	c.AssertIsEqual(left, right)
+}
+
+// assertIsInSubgroupBW6633 checks that ϕ(p+[x₀]p) == [x₀⁵]p-[x₀]p-[x₀⁴]p, see
+// https://eprint.iacr.org/2022/352.pdf.
+func (c *Curve[B, S]) assertIsInSubgroupBW6633(p *AffinePoint[B]) {
+	xP := c.scalarMulByConstant(p, seedBW6633)
+	x2P := c.scalarMulByConstant(xP, seedBW6633)
+	x3P := c.scalarMulByConstant(x2P, seedBW6633)
+	x4P := c.scalarMulByConstant(x3P, seedBW6633)
+	x5P := c.scalarMulByConstant(x4P, seedBW6633)
+
+	left := c.addComplete(p, xP)
+	left = c.phi(left)
+	right := c.addComplete(x5P, c.Neg(xP))
+	right = c.addComplete(right, c.Neg(x4P))
+	c.AssertIsEqual(left, right)
+}
+
+// phi computes the endomorphism ϕ(p) = (ω·p.x, p.y) where ω is the third root
+// of unity of the curve parameters. It doesn't modify p.
+func (c *Curve[B, S]) phi(p *AffinePoint[B]) *AffinePoint[B] {
+	if c.thirdRootOne == nil {
+		panic("curve does not have an endomorphism")
+	}
+	return &AffinePoint[B]{
+		X: *c.baseApi.Mul(&p.X, c.thirdRootOne),
+		Y: p.Y,
+	}
+}
+
+// scalarMulByConstant computes [k]p for a constant k using a double-and-add
+// over the NAF of k. It doesn't modify p.
+//
+// ✅ It uses complete arithmetic (see [Curve.addComplete]), so that p can be
+// any point of the curve, including (0,0) and the points of order dividing k.
+func (c *Curve[B, S]) scalarMulByConstant(p *AffinePoint[B], k *big.Int) *AffinePoint[B] {
+	if k.Sign() == 0 {
+		zero := c.baseApi.Zero()
+		return &AffinePoint[B]{X: *zero, Y: *zero}
+	}
+	if k.Sign() < 0 {
+		return c.Neg(c.scalarMulByConstant(p, new(big.Int).Neg(k)))
+	}
+	naf := make([]int8, k.BitLen()+1)
+	n := ecc.NafDecomposition(k, naf)
+	negP := c.Neg(p)
+	// the most significant digit of a NAF is always 1
+	res := p
+	for i := n - 2; i >= 0; i-- {
+		res = c.addComplete(res, res)
+		switch naf[i] {
+		case 1:
+			res = c.addComplete(res, p)
+		case -1:
+			res = c.addComplete(res, negP)
+		}
+	}
+	return res
+}
+
+// addComplete adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p and q can be any points of the curve or (0,0). Unlike [Curve.AddUnified],
+// it distinguishes the points with opposite ordinates: the slope is the one of
+// the tangent when p.x == q.x, and of the chord otherwise, so that the
+// division is never 0/0.
+func (c *Curve[B, S]) addComplete(p, q *AffinePoint[B]) *AffinePoint[B] {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := c.api.And(c.baseApi.IsZero(&p.X), c.baseApi.IsZero(&p.Y))
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := c.api.And(c.baseApi.IsZero(&q.X), c.baseApi.IsZero(&q.Y))
+
+	// if p.x == q.x: λ = (3p.x² + a)/(p.y + q.y), otherwise λ = (q.y - p.y)/(q.x - p.x)
+	qxpx := c.baseApi.Sub(&q.X, &p.X)
+	sameX := c.baseApi.IsZero(qxpx)
+	pxpx := c.baseApi.MulMod(&p.X, &p.X)
+	tangent := c.baseApi.MulConst(pxpx, big.NewInt(3))
+	if c.addA {
+		tangent = c.baseApi.Add(tangent, &c.a)
+	}
+	num := c.baseApi.Select(sameX, tangent, c.baseApi.Sub(&q.Y, &p.Y))
+	denum := c.baseApi.Select(sameX, c.baseApi.Add(&p.Y, &q.Y), qxpx)
+	// the denominator is zero only when q = -p, assign dummy 1 and continue
+	selector3 := c.baseApi.IsZero(denum)
+	denum = c.baseApi.Select(selector3, c.baseApi.One(), denum)
+	λ := c.baseApi.Div(num, denum)
+
+	// x = λ² - p.x - q.x
+	xr := c.baseApi.MulMod(λ, λ)
+	xr = c.baseApi.Sub(xr, c.baseApi.Add(&p.X, &q.X))
+
+	// y = λ(p.x - xr) - p.y
+	yr := c.baseApi.Sub(&p.X, xr)
+	yr = c.baseApi.MulMod(yr, λ)
+	yr = c.baseApi.Sub(yr, &p.Y)
+	result := &AffinePoint[B]{
+		X: *c.baseApi.Reduce(xr),
+		Y: *c.baseApi.Reduce(yr),
+	}
+
+	zero := c.baseApi.Zero()
+	infinity := &AffinePoint[B]{X: *zero, Y: *zero}
+	// if q = -p, return (0,0)
+	result = c.Select(selector3, infinity, result)
+	// if p=(0,0) return q
+	result = c.Select(selector1, q, result)
+	// if q=(0,0) return p
+	result = c.Select(selector2, p, result)
+
+	return result
+}
@@ -0,0 +1,303 @@
+package sw_emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fr_bls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	fr_bn "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	fr_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type IsInSubgroupTest[T, S emulated.FieldParams] struct {
+	Q AffinePoint[T]
+}
+
+func (c *IsInSubgroupTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsInSubgroup(&c.Q)
+	return nil
+}
+
+// randomPointOutsideSubgroup returns a random point on the curve Y² = X³ + b
+// over the base field T. For curves with a non-trivial cofactor the point is
+// outside of the prime order subgroup with overwhelming probability.
+func randomPointOutsideSubgroup[T emulated.FieldParams](b int64) AffinePoint[T] {
+	var fp T
+	x, y := randomCurvePoint(fp.Modulus(), b)
+	return AffinePoint[T]{
+		X: emulated.ValueOf[T](x),
+		Y: emulated.ValueOf[T](y),
+	}
+}
+
+// torsionPoint returns a random point [r⋅m]Q, where Q is a random point on the
+// curve Y² = X³ + b over the base field T and r is the order of the subgroup.
+// The point is of order dividing h/m, where h is the cofactor, and it is
+// different from the point at infinity.
+func torsionPoint[T, S emulated.FieldParams](b int64, m *big.Int) AffinePoint[T] {
+	var fp T
+	var fr S
+	k := new(big.Int).Mul(fr.Modulus(), m)
+	for {
+		x, y := randomCurvePoint(fp.Modulus(), b)
+		if x, y = nativeScalarMul(fp.Modulus(), x, y, k); x != nil {
+			return AffinePoint[T]{
+				X: emulated.ValueOf[T](x),
+				Y: emulated.ValueOf[T](y),
+			}
+		}
+	}
+}
+
+// bls12Cofactor returns the cofactor (x₀-1)²/3 of the G1 group of the BLS12
+// curve of seed x₀.
+func bls12Cofactor(x0 *big.Int) *big.Int {
+	h := new(big.Int).Sub(x0, big.NewInt(1))
+	h.Mul(h, h)
+	return h.Div(h, big.NewInt(3))
+}
+
+func randomCurvePoint(p *big.Int, b int64) (x, y *big.Int) {
+	for {
+		x, err := rand.Int(rand.Reader, p)
+		if err != nil {
+			panic(err)
+		}
+		rhs := new(big.Int).Exp(x, big.NewInt(3), p)
+		rhs.Add(rhs, big.NewInt(b))
+		rhs.Mod(rhs, p)
+		if y := new(big.Int).ModSqrt(rhs, p); y != nil {
+			return x, y
+		}
+	}
+}
+
+// nativeScalarMul computes [k](x, y) on a curve Y² = X³ + b over Fp. The point
+// at infinity is represented by nil coordinates.
+func nativeScalarMul(p, x, y, k *big.Int) (*big.Int, *big.Int) {
+	var rx, ry *big.Int
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		rx, ry = nativeAdd(p, rx, ry, rx, ry)
+		if k.Bit(i) == 1 {
+			rx, ry = nativeAdd(p, rx, ry, x, y)
+		}
+	}
+	return rx, ry
+}
+
+func nativeAdd(p, x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	if x1 == nil {
+		return x2, y2
+	}
+	if x2 == nil {
+		return x1, y1
+	}
+	var num, denum *big.Int
+	if x1.Cmp(x2) == 0 {
+		if new(big.Int).Add(y1, y2).Mod(new(big.Int).Add(y1, y2), p).Sign() == 0 {
+			return nil, nil
+		}
+		num = new(big.Int).Mul(x1, x1)
+		num.Mul(num, big.NewInt(3))
+		denum = new(big.Int).Lsh(y1, 1)
+	} else {
+		num = new(big.Int).Sub(y2, y1)
+		denum = new(big.Int).Sub(x2, x1)
+	}
+	denum.Mod(denum, p).ModInverse(denum, p)
+	λ := num.Mul(num, denum)
+	λ.Mod(λ, p)
+	x3 := new(big.Int).Mul(λ, λ)
+	x3.Sub(x3, x1).Sub(x3, x2).Mod(x3, p)
+	y3 := new(big.Int).Sub(x1, x3)
+	y3.Mul(y3, λ).Sub(y3, y1).Mod(y3, p)
+	return x3, y3
+}
+
+func TestIsInSubgroupBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bn254.Generators()
+	var r fr_bn.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var Q bn254.G1Affine
+	Q.ScalarMultiplication(&g, s)
+
+	circuit := IsInSubgroupTest[emulated.BN254Fp, emulated.BN254Fr]{}
+	witness := IsInSubgroupTest[emulated.BN254Fp, emulated.BN254Fr]{
+		Q: AffinePoint[emulated.BN254Fp]{
+			X: emulated.ValueOf[emulated.BN254Fp](Q.X),
+			Y: emulated.ValueOf[emulated.BN254Fp](Q.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+func TestIsInSubgroupBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bls12381.Generators()
+	var r fr_bls381.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var Q bls12381.G1Affine
+	Q.ScalarMultiplication(&g, s)
+
+	circuit := IsInSubgroupTest[emulated.BLS12381Fp, emulated.BLS12381Fr]{}
+	witness := IsInSubgroupTest[emulated.BLS12381Fp, emulated.BLS12381Fr]{
+		Q: AffinePoint[emulated.BLS12381Fp]{
+			X: emulated.ValueOf[emulated.BLS12381Fp](Q.X),
+			Y: emulated.ValueOf[emulated.BLS12381Fp](Q.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// random point on the curve is not in the subgroup
+	witness.Q = randomPointOutsideSubgroup[emulated.BLS12381Fp](4)
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+
+	// points of the cofactor torsion, which hit the exceptional cases of the
+	// incomplete formulas, are not in the subgroup
+	for _, m := range []*big.Int{big.NewInt(1), new(big.Int).Div(bls12Cofactor(seedBLS12381), big.NewInt(3))} {
+		witness.Q = torsionPoint[emulated.BLS12381Fp, emulated.BLS12381Fr](4, m)
+		err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.Error(err)
+	}
+}
+
+func TestIsInSubgroupBLS12377(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bls12377.Generators()
+	var r fr_bls12377.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var Q bls12377.G1Affine
+	Q.ScalarMultiplication(&g, s)
+
+	circuit := IsInSubgroupTest[emulated.BLS12377Fp, emulated.BLS12377Fr]{}
+	witness := IsInSubgroupTest[emulated.BLS12377Fp, emulated.BLS12377Fr]{
+		Q: AffinePoint[emulated.BLS12377Fp]{
+			X: emulated.ValueOf[emulated.BLS12377Fp](Q.X),
+			Y: emulated.ValueOf[emulated.BLS12377Fp](Q.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// random point on the curve is not in the subgroup
+	witness.Q = randomPointOutsideSubgroup[emulated.BLS12377Fp](1)
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+
+	// points of the cofactor torsion, which hit the exceptional cases of the
+	// incomplete formulas, are not in the subgroup
+	for _, m := range []*big.Int{big.NewInt(1)} {
+		witness.Q = torsionPoint[emulated.BLS12377Fp, emulated.BLS12377Fr](1, m)
+		err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.Error(err)
+	}
+
+	// (-1, 0) is of order 2
+	witness.Q = AffinePoint[emulated.BLS12377Fp]{
+		X: emulated.ValueOf[emulated.BLS12377Fp](-1),
+		Y: emulated.ValueOf[emulated.BLS12377Fp](0),
+	}
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+func TestIsInSubgroupBW6761(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bw6761.Generators()
+	var r fr_bw6761.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var Q bw6761.G1Affine
+	Q.ScalarMultiplication(&g, s)
+
+	circuit := IsInSubgroupTest[emulated.BW6761Fp, emulated.BW6761Fr]{}
+	witness := IsInSubgroupTest[emulated.BW6761Fp, emulated.BW6761Fr]{
+		Q: AffinePoint[emulated.BW6761Fp]{
+			X: emulated.ValueOf[emulated.BW6761Fp](Q.X),
+			Y: emulated.ValueOf[emulated.BW6761Fp](Q.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// random point on the curve is not in the subgroup
+	witness.Q = randomPointOutsideSubgroup[emulated.BW6761Fp](-1)
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+
+	// points of the cofactor torsion, which hit the exceptional cases of the
+	// incomplete formulas, are not in the subgroup
+	for _, m := range []*big.Int{big.NewInt(1)} {
+		witness.Q = torsionPoint[emulated.BW6761Fp, emulated.BW6761Fr](-1, m)
+		err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.Error(err)
+	}
+
+	// (1, 0) is of order 2
+	witness.Q = AffinePoint[emulated.BW6761Fp]{
+		X: emulated.ValueOf[emulated.BW6761Fp](1),
+		Y: emulated.ValueOf[emulated.BW6761Fp](0),
+	}
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+func TestIsInSubgroupBW6633(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g, _ := bw6633.Generators()
+	var r fr_bw6633.Element
+	_, _ = r.SetRandom()
+	s := new(big.Int)
+	r.BigInt(s)
+	var Q bw6633.G1Affine
+	Q.ScalarMultiplication(&g, s)
+
+	circuit := IsInSubgroupTest[emulated.BW6633Fp, emulated.BW6633Fr]{}
+	witness := IsInSubgroupTest[emulated.BW6633Fp, emulated.BW6633Fr]{
+		Q: AffinePoint[emulated.BW6633Fp]{
+			X: emulated.ValueOf[emulated.BW6633Fp](Q.X),
+			Y: emulated.ValueOf[emulated.BW6633Fp](Q.Y),
+		},
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// random point on the curve is not in the subgroup
+	witness.Q = randomPointOutsideSubgroup[emulated.BW6633Fp](4)
+	err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.Error(err)
+
+	// points of the cofactor torsion, which hit the exceptional cases of the
+	// incomplete formulas, are not in the subgroup
+	for _, m := range []*big.Int{big.NewInt(1)} {
+		witness.Q = torsionPoint[emulated.BW6633Fp, emulated.BW6633Fr](4, m)
+		err = test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.Error(err)
+	}
+}
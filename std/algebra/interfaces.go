@@ -24,6 +24,15 @@ type Curve[FR emulated.FieldParams, G1El G1ElementT] interface {
 	// AssertIsEqual asserts that two points are equal.
 	AssertIsEqual(*G1El, *G1El)
 
+	// AssertIsOnCurve asserts that the point satisfies the curve equation.
+	AssertIsOnCurve(*G1El)
+
+	// AssertIsInSubgroup asserts that the point is on the curve and in the
+	// prime order subgroup. For curves with a cofactor it uses
+	// endomorphism-based checks instead of a scalar multiplication by the
+	// group order.
+	AssertIsInSubgroup(*G1El)
+
 	// Neg negates the points and returns a negated point. It does not modify
 	// the input.
 	Neg(*G1El) *G1El
@@ -100,9 +109,19 @@ type Pairing[G1El G1ElementT, G2El G2ElementT, GtEl GtElementT] interface {
 	// AssertIsEqual asserts the equality of the inputs.
 	AssertIsEqual(*GtEl, *GtEl)
 
-	// AssertIsOnG1 asserts that the input is on the G1 curve.
+	// AssertIsOnCurve asserts that the input satisfies the G1 curve equation.
+	// It does not check the subgroup membership.
+	AssertIsOnCurve(*G1El)
+
+	// AssertIsOnTwist asserts that the input satisfies the G2 (twisted) curve
+	// equation. It does not check the subgroup membership.
+	AssertIsOnTwist(*G2El)
+
+	// AssertIsOnG1 asserts that the input is on the G1 curve and in the prime
+	// order subgroup.
 	AssertIsOnG1(*G1El)
 
-	// AssertIsOnG2 asserts that the input is on the G2 curve.
+	// AssertIsOnG2 asserts that the input is on the G2 curve and in the prime
+	// order subgroup.
 	AssertIsOnG2(*G2El)
 }
@@ -421,6 +421,42 @@ func (p *G1Affine) AssertIsEqual(api frontend.API, other G1Affine) {
 	api.AssertIsEqual(p.Y, other.Y)
 }
 
+// AssertIsOnCurve asserts that p satisfies the curve equation Y² = X³ + 1 or
+// is (0,0). It doesn't modify p.
+func (p *G1Affine) AssertIsOnCurve(api frontend.API) {
+	// (X,Y) ∈ {Y² == X³ + 1} U (0,0)
+
+	// if p=(0,0) we assign b=0 and continue
+	selector := api.And(api.IsZero(p.X), api.IsZero(p.Y))
+	b := api.Select(selector, 0, 1)
+
+	left := api.Mul(p.Y, p.Y)
+	right := api.Mul(p.X, api.Mul(p.X, p.X))
+	right = api.Add(right, b)
+	api.AssertIsEqual(left, right)
+}
+
+// AssertIsInSubgroup asserts that p is on the curve and in the prime order
+// subgroup G1. It doesn't modify p.
+func (p *G1Affine) AssertIsInSubgroup(api frontend.API) {
+	// 1- Check p is on the curve
+	p.AssertIsOnCurve(api)
+
+	// 2- Check p has the right subgroup order
+	// [x²]ϕ(p)
+	phiP := G1Affine{
+		X: api.Mul(p.X, "80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945"),
+		Y: p.Y,
+	}
+	var _p G1Affine
+	_p.scalarMulBySeed(api, &phiP)
+	_p.scalarMulBySeed(api, &_p)
+	_p.Neg(api, _p)
+
+	// [r]p == 0 <==>  p = -[x²]ϕ(p)
+	p.AssertIsEqual(api, _p)
+}
+
 // DoubleAndAdd computes 2*p1+p in affine coords
 func (p *G1Affine) DoubleAndAdd(api frontend.API, p1, p2 *G1Affine) *G1Affine {
 
@@ -105,6 +105,17 @@ func (c *Curve) AssertIsEqual(P, Q *G1Affine) {
 	P.AssertIsEqual(c.api, *Q)
 }
 
+// AssertIsOnCurve asserts that P is on the curve. It doesn't modify P.
+func (c *Curve) AssertIsOnCurve(P *G1Affine) {
+	P.AssertIsOnCurve(c.api)
+}
+
+// AssertIsInSubgroup asserts that P is on the curve and in the prime order
+// subgroup G1. It doesn't modify P.
+func (c *Curve) AssertIsInSubgroup(P *G1Affine) {
+	P.AssertIsInSubgroup(c.api)
+}
+
 // Neg negates P and returns the result. Does not modify P.
 func (c *Curve) Neg(P *G1Affine) *G1Affine {
 	res := &G1Affine{
@@ -337,35 +348,13 @@ func (p *Pairing) AssertIsEqual(e1, e2 *GT) {
 
 // AssertIsOnCurve asserts if p belongs to the curve. It doesn't modify p.
 func (c *Pairing) AssertIsOnCurve(p *G1Affine) {
-	// (X,Y) ∈ {Y² == X³ + 1} U (0,0)
-
-	// if p=(0,0) we assign b=0 and continue
-	selector := c.api.And(c.api.IsZero(p.X), c.api.IsZero(p.Y))
-	b := c.api.Select(selector, 0, 1)
-
-	left := c.api.Mul(p.Y, p.Y)
-	right := c.api.Mul(p.X, c.api.Mul(p.X, p.X))
-	right = c.api.Add(right, b)
-	c.api.AssertIsEqual(left, right)
+	p.AssertIsOnCurve(c.api)
 }
 
+// AssertIsOnG1 asserts if P belongs to the curve and the prime order subgroup
+// G1. It doesn't modify P.
 func (c *Pairing) AssertIsOnG1(P *G1Affine) {
-	// 1- Check P is on the curve
-	c.AssertIsOnCurve(P)
-
-	// 2- Check P has the right subgroup order
-	// [x²]ϕ(P)
-	phiP := G1Affine{
-		X: c.api.Mul(P.X, "80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945"),
-		Y: P.Y,
-	}
-	var _P G1Affine
-	_P.scalarMulBySeed(c.api, &phiP)
-	_P.scalarMulBySeed(c.api, &_P)
-	_P.Neg(c.api, _P)
-
-	// [r]Q == 0 <==>  P = -[x²]ϕ(P)
-	P.AssertIsEqual(c.api, _P)
+	P.AssertIsInSubgroup(c.api)
 }
 
 // AssertIsOnTwist asserts if p belongs to the curve. It doesn't modify p.
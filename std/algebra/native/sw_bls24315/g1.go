@@ -378,6 +378,73 @@ func (p *G1Affine) AssertIsEqual(api frontend.API, other G1Affine) {
 	api.AssertIsEqual(p.Y, other.Y)
 }
 
+// AssertIsOnCurve asserts that p satisfies the curve equation Y² = X³ + 1 or
+// is (0,0). It doesn't modify p.
+func (p *G1Affine) AssertIsOnCurve(api frontend.API) {
+	// (X,Y) ∈ {Y² == X³ + 1} U (0,0)
+
+	// if p=(0,0) we assign b=0 and continue
+	selector := api.And(api.IsZero(p.X), api.IsZero(p.Y))
+	b := api.Select(selector, 0, 1)
+
+	left := api.Mul(p.Y, p.Y)
+	right := api.Mul(p.X, api.Mul(p.X, p.X))
+	right = api.Add(right, b)
+	api.AssertIsEqual(left, right)
+}
+
+// AssertIsInSubgroup asserts that p is on the curve and in the prime order
+// subgroup G1. It doesn't modify p.
+func (p *G1Affine) AssertIsInSubgroup(api frontend.API) {
+	// 1- Check p is on the curve
+	p.AssertIsOnCurve(api)
+
+	// 2- Check p has the right subgroup order
+	// [x₀⁴]ϕ(p)
+	phiP := G1Affine{
+		X: api.Mul(p.X, "39705142672498995661671850106945620852186608752525090699191017895721506694646055668218723303426"),
+		Y: p.Y,
+	}
+	var _p G1Affine
+	_p.scalarMulBySeed(api, &phiP)
+	_p.scalarMulBySeed(api, &_p)
+	_p.scalarMulBySeed(api, &_p)
+	_p.scalarMulBySeed(api, &_p)
+	_p.Neg(api, _p)
+
+	// [r]p == 0 <==>  p = -[x₀⁴]ϕ(p)
+	p.AssertIsEqual(api, _p)
+}
+
+// scalarMulBySeed sets P = [-x₀]Q and returns P, where x₀=-3218079743 is the
+// seed of the curve. It uses the decomposition -x₀ = 2³²-2³⁰-2²²+2²⁰-1.
+func (P *G1Affine) scalarMulBySeed(api frontend.API, Q *G1Affine) *G1Affine {
+	var z, z20, z22, z30, t G1Affine
+	z20 = *Q
+	for i := 0; i < 20; i++ {
+		z20.Double(api, z20)
+	}
+	z22.Double(api, z20)
+	z22.Double(api, z22)
+	z30 = z22
+	for i := 0; i < 8; i++ {
+		z30.Double(api, z30)
+	}
+	z.Double(api, z30)
+	z.Double(api, z)
+	t.Neg(api, z30)
+	z.AddAssign(api, t)
+	t.Neg(api, z22)
+	z.AddAssign(api, t)
+	z.AddAssign(api, z20)
+	t.Neg(api, *Q)
+	z.AddAssign(api, t)
+	P.X = z.X
+	P.Y = z.Y
+
+	return P
+}
+
 // DoubleAndAdd computes 2*p1+p in affine coords
 func (p *G1Affine) DoubleAndAdd(api frontend.API, p1, p2 *G1Affine) *G1Affine {
 
@@ -443,6 +443,55 @@ func (p *g2AffP) AssertIsEqual(api frontend.API, other g2AffP) {
 	p.Y.AssertIsEqual(api, other.Y)
 }
 
+// scalarMulBySeed sets P = [-x₀]Q and returns P, where x₀=-3218079743 is the
+// seed of the curve. It uses the decomposition -x₀ = 2³²-2³⁰-2²²+2²⁰-1.
+func (P *g2AffP) scalarMulBySeed(api frontend.API, Q *g2AffP) *g2AffP {
+	var z, z20, z22, z30, t g2AffP
+	z20 = *Q
+	for i := 0; i < 20; i++ {
+		z20.Double(api, z20)
+	}
+	z22.Double(api, z20)
+	z22.Double(api, z22)
+	z30 = z22
+	for i := 0; i < 8; i++ {
+		z30.Double(api, z30)
+	}
+	z.Double(api, z30)
+	z.Double(api, z)
+	t.Neg(api, z30)
+	z.AddAssign(api, t)
+	t.Neg(api, z22)
+	z.AddAssign(api, t)
+	z.AddAssign(api, z20)
+	t.Neg(api, *Q)
+	z.AddAssign(api, t)
+	P.X = z.X
+	P.Y = z.Y
+
+	return P
+}
+
+// psi sets P = ψ(q) = u⁻¹∘π∘u(q) and returns P, where u is the twist
+// isomorphism and π the Frobenius map.
+func (P *g2AffP) psi(api frontend.API, q *g2AffP) *g2AffP {
+	var x, y fields_bls24315.E4
+	// π on 𝔽p⁴ = 𝔽p²[v]/(v²-u) is (b0,b1) ↦ (b̄0,b̄1·γ)
+	x.B0.Conjugate(api, q.X.B0)
+	x.B1.Conjugate(api, q.X.B1)
+	x.B1.MulByFp(api, x.B1, "14265754707630841383590096931465005402246260064523506653409458152869013672931584279153351926943")
+	x.MulByFp(api, x, "17432737665785421589107433512831558061649422754130449334965277047994983947893909429238815314776")
+	y.B0.Conjugate(api, q.Y.B0)
+	y.B1.Conjugate(api, q.Y.B1)
+	y.B1.MulByFp(api, y.B1, "14265754707630841383590096931465005402246260064523506653409458152869013672931584279153351926943")
+	y.MulByFp(api, y, "13266452002786802757645810648664867986567631927642464177452792960815113608167203350720036682455")
+
+	P.X = x
+	P.Y = y
+
+	return P
+}
+
 // DoubleAndAdd computes 2*p1+p2 in affine coords
 func (p *g2AffP) DoubleAndAdd(api frontend.API, p1, p2 *g2AffP) *g2AffP {
 
@@ -105,6 +105,17 @@ func (c *Curve) AssertIsEqual(P, Q *G1Affine) {
 	P.AssertIsEqual(c.api, *Q)
 }
 
+// AssertIsOnCurve asserts that P is on the curve. It doesn't modify P.
+func (c *Curve) AssertIsOnCurve(P *G1Affine) {
+	P.AssertIsOnCurve(c.api)
+}
+
+// AssertIsInSubgroup asserts that P is on the curve and in the prime order
+// subgroup G1. It doesn't modify P.
+func (c *Curve) AssertIsInSubgroup(P *G1Affine) {
+	P.AssertIsInSubgroup(c.api)
+}
+
 // Neg negates P and returns the result. Does not modify P.
 func (c *Curve) Neg(P *G1Affine) *G1Affine {
 	res := &G1Affine{
@@ -318,12 +329,55 @@ func (p *Pairing) AssertIsEqual(e1, e2 *GT) {
 	e1.AssertIsEqual(p.api, *e2)
 }
 
+// AssertIsOnCurve asserts if p belongs to the curve. It doesn't modify p.
+func (p *Pairing) AssertIsOnCurve(P *G1Affine) {
+	P.AssertIsOnCurve(p.api)
+}
+
+// AssertIsOnG1 asserts if P belongs to the curve and the prime order subgroup
+// G1. It doesn't modify P.
 func (p *Pairing) AssertIsOnG1(P *G1Affine) {
-	panic("not implemented")
+	P.AssertIsInSubgroup(p.api)
+}
+
+// AssertIsOnTwist asserts if Q belongs to the twist. It doesn't modify Q.
+func (p *Pairing) AssertIsOnTwist(Q *G2Affine) {
+	// (X,Y) ∈ {Y² == X³ + 1/v} U (0,0)
+
+	// if Q=(0,0) we assign b=0 and continue
+	selector := p.api.And(Q.P.X.IsZero(p.api), Q.P.Y.IsZero(p.api))
+	b := fields_bls24315.E4{
+		B0: fields_bls24315.E2{A0: 0, A1: 0},
+		B1: fields_bls24315.E2{
+			A0: 0,
+			A1: p.api.Select(selector, 0, "6108483493771298205388567675447533806912846525679192205394505462405828322019437284165171866703"),
+		},
+	}
+
+	var left, right fields_bls24315.E4
+	left.Square(p.api, Q.P.Y)
+	right.Square(p.api, Q.P.X)
+	right.Mul(p.api, right, Q.P.X)
+	right.Add(p.api, right, b)
+	left.AssertIsEqual(p.api, right)
 }
 
-func (p *Pairing) AssertIsOnG2(P *G2Affine) {
-	panic("not implemented")
+// AssertIsOnG2 asserts if Q belongs to the twist and the prime order subgroup
+// G2. It doesn't modify Q.
+func (p *Pairing) AssertIsOnG2(Q *G2Affine) {
+	// 1- Check Q is on the curve
+	p.AssertIsOnTwist(Q)
+
+	// 2- Check Q has the right subgroup order
+	// [-x₀]Q
+	var xQ, psiQ g2AffP
+	xQ.scalarMulBySeed(p.api, &Q.P)
+	xQ.Neg(p.api, xQ)
+	// ψ(Q)
+	psiQ.psi(p.api, &Q.P)
+
+	// [r]Q == 0 <==>  ψ(Q) == [x₀]Q
+	xQ.AssertIsEqual(p.api, psiQ)
 }
 
 // NewG1Affine allocates a witness from the native G1 element and returns it.
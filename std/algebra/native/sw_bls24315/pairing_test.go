@@ -23,6 +23,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls24315"
@@ -87,6 +88,52 @@ func TestPairingBLS315(t *testing.T) {
 
 }
 
+type groupMembershipBLS315 struct {
+	P G1Affine
+	Q G2Affine
+}
+
+func (circuit *groupMembershipBLS315) Define(api frontend.API) error {
+	pr := NewPairing(api)
+	pr.AssertIsOnG1(&circuit.P)
+	pr.AssertIsOnG2(&circuit.Q)
+	return nil
+}
+
+func TestGroupMembershipBLS315(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, Q, _, _ := pairingData()
+
+	// badP is on the curve but not in G1
+	var badP bls24315.G1Affine
+	for {
+		var rhs fp.Element
+		badP.X.SetRandom()
+		rhs.Square(&badP.X).Mul(&rhs, &badP.X).Add(&rhs, new(fp.Element).SetOne())
+		if badP.Y.Sqrt(&rhs) != nil {
+			break
+		}
+	}
+	// badQ is on the twist but not in G2
+	var u bls24315.E4
+	u.SetRandom()
+	badQ := bls24315.MapToCurve2(u)
+	assert.True(badP.IsOnCurve() && !badP.IsInSubGroup())
+	assert.True(badQ.IsOnCurve() && !badQ.IsInSubGroup())
+
+	witness := groupMembershipBLS315{P: NewG1Affine(P), Q: NewG2Affine(Q)}
+	err := test.IsSolved(&groupMembershipBLS315{}, &witness, ecc.BW6_633.ScalarField())
+	assert.NoError(err)
+
+	witness = groupMembershipBLS315{P: NewG1Affine(badP), Q: NewG2Affine(Q)}
+	err = test.IsSolved(&groupMembershipBLS315{}, &witness, ecc.BW6_633.ScalarField())
+	assert.Error(err)
+
+	witness = groupMembershipBLS315{P: NewG1Affine(P), Q: NewG2Affine(badQ)}
+	err = test.IsSolved(&groupMembershipBLS315{}, &witness, ecc.BW6_633.ScalarField())
+	assert.Error(err)
+}
+
 type triplePairingBLS315 struct {
 	P1, P2, P3 G1Affine
 	Q1, Q2, Q3 G2Affine
@@ -0,0 +1,79 @@
+package sw_grumpkin
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+)
+
+// CommitmentKey is a key for Pedersen-style vector commitments on Grumpkin.
+// The commitment to the values v_i with blinding factor r is
+//
+//	C = ∑ [v_i]G_i + [r]H.
+//
+// The generators are derived deterministically and nobody knows the discrete
+// logarithm relations between them.
+type CommitmentKey struct {
+	G []PointAffine
+	H PointAffine
+}
+
+// NewCommitmentKey derives a commitment key for committing to up to n values.
+// The generators are obtained using try-and-increment on the SHA-256 hash of
+// the personalization, the generator index and a counter. Keys with different
+// personalizations are independent.
+func NewCommitmentKey(personalization string, n int) (CommitmentKey, error) {
+	var ck CommitmentKey
+	var err error
+	ck.G = make([]PointAffine, n)
+	for i := range ck.G {
+		if ck.G[i], err = deriveGenerator(personalization, uint32(i)); err != nil {
+			return CommitmentKey{}, err
+		}
+	}
+	// the blinding generator uses the last index so that the keys for
+	// different lengths are prefixes of each other.
+	if ck.H, err = deriveGenerator(personalization, ^uint32(0)); err != nil {
+		return CommitmentKey{}, err
+	}
+	return ck, nil
+}
+
+// Commit computes the commitment to values with the blinding factor
+// out-of-circuit. It returns an error if there are more values than
+// generators in the key.
+func (ck CommitmentKey) Commit(values []*big.Int, blinding *big.Int) (PointAffine, error) {
+	if len(values) > len(ck.G) {
+		return PointAffine{}, fmt.Errorf("got %d values, key supports at most %d", len(values), len(ck.G))
+	}
+	var res, tmp PointAffine
+	for i := range values {
+		tmp.ScalarMultiplication(&ck.G[i], values[i])
+		res.Add(&res, &tmp)
+	}
+	tmp.ScalarMultiplication(&ck.H, blinding)
+	res.Add(&res, &tmp)
+	return res, nil
+}
+
+// Commit computes in-circuit the commitment ∑ [values_i]G_i + [blinding]H to
+// the native values with the blinding factor using the commitment key ck. The
+// generators of the key are embedded in the circuit as constants. The values
+// and the blinding factor may be zero.
+//
+// It returns an error if there are more values than generators in the key.
+func Commit(api frontend.API, ck CommitmentKey, values []frontend.Variable, blinding frontend.Variable) (G1Affine, error) {
+	if len(values) > len(ck.G) {
+		return G1Affine{}, fmt.Errorf("got %d values, key supports at most %d", len(values), len(ck.G))
+	}
+	var res G1Affine
+	res.ScalarMul(api, NewG1Affine(ck.H), blinding, algopts.WithCompleteArithmetic())
+	for i := range values {
+		var tmp G1Affine
+		tmp.ScalarMul(api, NewG1Affine(ck.G[i]), values[i], algopts.WithCompleteArithmetic())
+		res.AddUnified(api, tmp)
+	}
+	return res, nil
+}
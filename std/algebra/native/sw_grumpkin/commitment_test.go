@@ -0,0 +1,72 @@
+package sw_grumpkin
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type commitCircuit struct {
+	ck       CommitmentKey
+	Values   []frontend.Variable
+	Blinding frontend.Variable
+	C        G1Affine `gnark:",public"`
+}
+
+func (c *commitCircuit) Define(api frontend.API) error {
+	res, err := Commit(api, c.ck, c.Values, c.Blinding)
+	if err != nil {
+		return err
+	}
+	res.AssertIsEqual(api, c.C)
+	return nil
+}
+
+func TestCommit(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 3
+	ck, err := NewCommitmentKey("sw_grumpkin test", n)
+	assert.NoError(err)
+	for i := range ck.G {
+		assert.True(ck.G[i].IsOnCurve())
+		assert.False(ck.H.Equal(&ck.G[i]))
+	}
+	values := make([]*big.Int, n)
+	wValues := make([]frontend.Variable, n)
+	for i := range values {
+		var v fr_bn254.Element
+		v.SetRandom()
+		values[i] = v.BigInt(new(big.Int))
+		wValues[i] = values[i]
+	}
+	// zero value
+	values[1].SetUint64(0)
+	var r fr_bn254.Element
+	r.SetRandom()
+	blinding := r.BigInt(new(big.Int))
+
+	c, err := ck.Commit(values, blinding)
+	assert.NoError(err)
+	circuit := commitCircuit{ck: ck, Values: make([]frontend.Variable, n)}
+	witness := commitCircuit{Values: wValues, Blinding: blinding, C: NewG1Affine(c)}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+
+	// commitment with different blinding must not verify
+	c2, err := ck.Commit(values, new(big.Int).Add(blinding, big.NewInt(1)))
+	assert.NoError(err)
+	witness.C = NewG1Affine(c2)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// keys are prefixes of each other
+	ck2, err := NewCommitmentKey("sw_grumpkin test", n+1)
+	assert.NoError(err)
+	assert.True(ck2.G[n-1].Equal(&ck.G[n-1]))
+	assert.True(ck2.H.Equal(&ck.H))
+	_, err = ck.Commit(make([]*big.Int, n+1), blinding)
+	assert.Error(err)
+}
@@ -0,0 +1,94 @@
+package sw_grumpkin
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+)
+
+var configOnce sync.Once
+
+// curveConfig holds the parameters of the GLV endomorphism
+//
+//	Φ: (x,y) ↦ (ω·x, y)
+//
+// where ω is a primitive third root of unity in the base field 𝔽_r. It acts on
+// the group as the multiplication by λ, a primitive third root of unity in the
+// scalar field 𝔽_q.
+type curveConfig struct {
+	thirdRootOne *big.Int
+	lambda       *big.Int
+	glvBasis     *ecc.Lattice
+	// nbits is the bound on the bit length of the absolute values of the
+	// sub-scalars of the GLV decomposition.
+	nbits int
+	fr    *big.Int
+	fq    *big.Int
+}
+
+var configGrumpkin curveConfig
+
+func (cc *curveConfig) phi(api frontend.API, res, P *G1Affine) *G1Affine {
+	res.X = api.Mul(P.X, cc.thirdRootOne)
+	res.Y = P.Y
+	return res
+}
+
+// getCurveConfig returns the Grumpkin configuration. It panics if the native
+// field is not the scalar field of BN254.
+func getCurveConfig(nativeField *big.Int) *curveConfig {
+	if nativeField.Cmp(ecc.BN254.ScalarField()) != 0 {
+		panic(fmt.Sprintf("Grumpkin is not defined over the native field %s", nativeField.String()))
+	}
+	configOnce.Do(func() {
+		modR := ecc.BN254.ScalarField()
+		modQ := ecc.BN254.BaseField()
+		omega := cubeRootOfUnity(modR)
+		lambda := cubeRootOfUnity(modQ)
+		// the endomorphism acts as λ or λ² depending on the choice of ω.
+		g := Generator()
+		var phiG, lambdaG PointAffine
+		var w fr.Element
+		w.SetBigInt(omega)
+		phiG.X.Mul(&g.X, &w)
+		phiG.Y.Set(&g.Y)
+		if !lambdaG.ScalarMultiplication(&g, lambda).Equal(&phiG) {
+			lambda.Mul(lambda, lambda).Mod(lambda, modQ)
+		}
+		var lattice ecc.Lattice
+		ecc.PrecomputeLattice(modQ, lambda, &lattice)
+		// |k_i| ≤ |V1[i]| + |V2[i]|
+		var bound, tmp big.Int
+		for i := 0; i < 2; i++ {
+			tmp.Abs(&lattice.V1[i])
+			tmp.Add(&tmp, new(big.Int).Abs(&lattice.V2[i]))
+			if tmp.Cmp(&bound) > 0 {
+				bound.Set(&tmp)
+			}
+		}
+		configGrumpkin = curveConfig{
+			thirdRootOne: omega,
+			lambda:       lambda,
+			glvBasis:     &lattice,
+			nbits:        bound.BitLen(),
+			fr:           modR,
+			fq:           modQ,
+		}
+	})
+	return &configGrumpkin
+}
+
+// cubeRootOfUnity returns the primitive third root of unity (-1+√-3)/2 mod p.
+func cubeRootOfUnity(p *big.Int) *big.Int {
+	res := new(big.Int).Sub(p, big.NewInt(3))
+	if res.ModSqrt(res, p) == nil {
+		panic("no cube root of unity")
+	}
+	res.Sub(res, big.NewInt(1))
+	res.Mul(res, new(big.Int).ModInverse(big.NewInt(2), p))
+	return res.Mod(res, p)
+}
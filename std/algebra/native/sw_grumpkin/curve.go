@@ -0,0 +1,233 @@
+package sw_grumpkin
+
+import (
+	"fmt"
+	"slices"
+
+	fp_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
+	"github.com/consensys/gnark/std/selector"
+)
+
+// Curve allows G1 operations in Grumpkin.
+type Curve struct {
+	api frontend.API
+	fr  *emulated.Field[ScalarField]
+}
+
+// NewCurve initializes a new [Curve] instance.
+func NewCurve(api frontend.API) (*Curve, error) {
+	f, err := emulated.NewField[ScalarField](api)
+	if err != nil {
+		return nil, fmt.Errorf("scalar field: %w", err)
+	}
+	return &Curve{
+		api: api,
+		fr:  f,
+	}, nil
+}
+
+// MarshalScalar returns the binary decomposition of the scalar in big-endian
+// order.
+func (c *Curve) MarshalScalar(s Scalar, opts ...algopts.AlgebraOption) []frontend.Variable {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		panic(fmt.Sprintf("parse opts: %v", err))
+	}
+	nbBits := 8 * ((ScalarField{}.Modulus().BitLen() + 7) / 8)
+	var ss *emulated.Element[ScalarField]
+	if cfg.ToBitsCanonical {
+		ss = c.fr.ReduceStrict(&s)
+	} else {
+		ss = c.fr.Reduce(&s)
+	}
+	x := c.fr.ToBits(ss)[:nbBits]
+	slices.Reverse(x)
+	return x
+}
+
+// MarshalG1 returns [P.X || P.Y] in binary. Both P.X and P.Y are in big
+// endian. The second most significant bit is set for the point at infinity.
+func (c *Curve) MarshalG1(P G1Affine, opts ...algopts.AlgebraOption) []frontend.Variable {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		panic(fmt.Sprintf("parse opts: %v", err))
+	}
+	nbBits := 8 * ((c.api.Compiler().FieldBitLen() + 7) / 8)
+	bOpts := []bits.BaseConversionOption{bits.WithNbDigits(nbBits)}
+	if !cfg.ToBitsCanonical {
+		bOpts = append(bOpts, bits.OmitModulusCheck())
+	}
+	res := make([]frontend.Variable, 2*nbBits)
+	x := bits.ToBinary(c.api, P.X, bOpts...)
+	y := bits.ToBinary(c.api, P.Y, bOpts...)
+	for i := 0; i < nbBits; i++ {
+		res[i] = x[nbBits-1-i]
+		res[i+nbBits] = y[nbBits-1-i]
+	}
+	xZ := c.api.IsZero(P.X)
+	yZ := c.api.IsZero(P.Y)
+	res[1] = c.api.Mul(xZ, yZ)
+	return res
+}
+
+// Add points P and Q and return the result. Does not modify the inputs.
+func (c *Curve) Add(P, Q *G1Affine) *G1Affine {
+	res := &G1Affine{
+		X: P.X,
+		Y: P.Y,
+	}
+	res.AddAssign(c.api, *Q)
+	return res
+}
+
+// AddUnified adds any two points and returns the sum. It does not modify the input
+// points.
+func (c *Curve) AddUnified(P, Q *G1Affine) *G1Affine {
+	res := &G1Affine{
+		X: P.X,
+		Y: P.Y,
+	}
+	res.AddUnified(c.api, *Q)
+	return res
+}
+
+// AssertIsEqual asserts the equality of P and Q.
+func (c *Curve) AssertIsEqual(P, Q *G1Affine) {
+	P.AssertIsEqual(c.api, *Q)
+}
+
+// AssertIsOnCurve asserts that P is on the curve.
+func (c *Curve) AssertIsOnCurve(P *G1Affine) {
+	P.AssertIsOnCurve(c.api)
+}
+
+// AssertIsInSubgroup asserts that P is in the group. As Grumpkin has prime
+// order, it is the same as [Curve.AssertIsOnCurve].
+func (c *Curve) AssertIsInSubgroup(P *G1Affine) {
+	P.AssertIsOnCurve(c.api)
+}
+
+// Neg negates P and returns the result. Does not modify P.
+func (c *Curve) Neg(P *G1Affine) *G1Affine {
+	res := &G1Affine{
+		X: P.X,
+		Y: P.Y,
+	}
+	res.Neg(c.api, *P)
+	return res
+}
+
+// ScalarMul computes scalar*P and returns the result. It doesn't modify the
+// inputs.
+func (c *Curve) ScalarMul(P *G1Affine, s *Scalar, opts ...algopts.AlgebraOption) *G1Affine {
+	res := new(G1Affine)
+	res.scalarMulGLV(c.api, c.fr, *P, s, opts...)
+	return res
+}
+
+// ScalarMulBase computes scalar*G where G is the standard base point of the
+// curve. It doesn't modify the scalar.
+func (c *Curve) ScalarMulBase(s *Scalar, opts ...algopts.AlgebraOption) *G1Affine {
+	g := NewG1Affine(Generator())
+	return c.ScalarMul(&g, s, opts...)
+}
+
+// MultiScalarMul computes ∑scalars_i * P_i and returns it. It doesn't modify
+// the inputs. It returns an error if there is a mismatch in the lengths of the
+// inputs.
+//
+// Without complete arithmetic, the points share the doublings of a single GLV
+// double-and-add loop. The points must then be different from (0,0) and the
+// result must not be the point at infinity. With
+// [algopts.WithCompleteArithmetic], every point is multiplied separately and
+// the results are added with unified additions.
+//
+// The option [algopts.WithFoldingScalarMul] is not supported.
+func (c *Curve) MultiScalarMul(P []*G1Affine, scalars []*Scalar, opts ...algopts.AlgebraOption) (*G1Affine, error) {
+	if len(P) == 0 {
+		return &G1Affine{
+			X: 0,
+			Y: 0,
+		}, nil
+	}
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new config: %w", err)
+	}
+	if cfg.FoldMulti {
+		return nil, fmt.Errorf("folding scalar multiplication not supported")
+	}
+	if len(P) != len(scalars) {
+		return nil, fmt.Errorf("mismatching points and scalars slice lengths")
+	}
+	if !cfg.CompleteArithmetic {
+		Q := make([]G1Affine, len(P))
+		for i := range P {
+			Q[i] = *P[i]
+		}
+		res := new(G1Affine)
+		res.multiScalarMulGLV(c.api, c.fr, Q, scalars)
+		return res, nil
+	}
+	res := c.ScalarMul(P[0], scalars[0], opts...)
+	for i := 1; i < len(P); i++ {
+		q := c.ScalarMul(P[i], scalars[i], opts...)
+		res = c.AddUnified(res, q)
+	}
+	return res, nil
+}
+
+// Select sets p1 if b=1, p2 if b=0, and returns it. b must be boolean constrained
+func (c *Curve) Select(b frontend.Variable, p1, p2 *G1Affine) *G1Affine {
+	return &G1Affine{
+		X: c.api.Select(b, p1.X, p2.X),
+		Y: c.api.Select(b, p1.Y, p2.Y),
+	}
+}
+
+// Lookup2 performs a 2-bit lookup between p1, p2, p3, p4 based on bits b0  and b1.
+// Returns:
+//   - p1 if b0=0 and b1=0,
+//   - p2 if b0=1 and b1=0,
+//   - p3 if b0=0 and b1=1,
+//   - p4 if b0=1 and b1=1.
+func (c *Curve) Lookup2(b1, b2 frontend.Variable, p1, p2, p3, p4 *G1Affine) *G1Affine {
+	return &G1Affine{
+		X: c.api.Lookup2(b1, b2, p1.X, p2.X, p3.X, p4.X),
+		Y: c.api.Lookup2(b1, b2, p1.Y, p2.Y, p3.Y, p4.Y),
+	}
+}
+
+// Mux performs a lookup from the inputs and returns inputs[sel]. It is most
+// efficient for power of two lengths of the inputs, but works for any number of
+// inputs.
+func (c *Curve) Mux(sel frontend.Variable, inputs ...*G1Affine) *G1Affine {
+	xs := make([]frontend.Variable, len(inputs))
+	ys := make([]frontend.Variable, len(inputs))
+	for i := range inputs {
+		xs[i] = inputs[i].X
+		ys[i] = inputs[i].Y
+	}
+	return &G1Affine{
+		X: selector.Mux(c.api, sel, xs...),
+		Y: selector.Mux(c.api, sel, ys...),
+	}
+}
+
+// Scalar is a scalar in the group of Grumpkin points. The scalar field of
+// Grumpkin is the base field of BN254, so the scalars are emulated.
+type Scalar = emulated.Element[ScalarField]
+
+// NewScalar allocates a witness from the native scalar and returns it.
+func NewScalar(v fp_bn254.Element) Scalar {
+	return emulated.ValueOf[ScalarField](v)
+}
+
+// ScalarField defines the [emulated.FieldParams] implementation of the scalar
+// field of Grumpkin.
+type ScalarField = emparams.BN254Fp
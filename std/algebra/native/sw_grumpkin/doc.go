@@ -0,0 +1,17 @@
+// Package sw_grumpkin implements the arithmetics of the Grumpkin curve as a
+// SNARK circuit over BN254. Grumpkin is the curve Y² = X³ - 17 defined over
+// the scalar field of BN254 and its group order is the base field modulus of
+// BN254. The two curves form a cycle so the operations use native field
+// arithmetic.
+//
+// The package implements the scalar multiplication and multi-scalar
+// multiplication using the GLV endomorphism, and Pedersen-style vector
+// commitments. The scalars of Grumpkin are elements of the base field of BN254
+// and they are emulated. As gnark-crypto does not implement Grumpkin, the
+// package also provides the out-of-circuit arithmetic [PointAffine] needed for
+// witness generation.
+//
+// References:
+// Grumpkin: https://hackmd.io/@aztec-network/ByzgNxBfd#2-Grumpkin---A-curve-on-top-of-BN-254-for-SNARK-efficient-group-operations
+// GLV: https://www.iacr.org/archive/crypto2001/21390189.pdf
+package sw_grumpkin
@@ -0,0 +1,532 @@
+package sw_grumpkin
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// G1Affine point in affine coords
+type G1Affine struct {
+	X, Y frontend.Variable
+}
+
+// NewG1Affine allocates a witness from the out-of-circuit point and returns it.
+func NewG1Affine(v PointAffine) G1Affine {
+	return G1Affine{
+		X: v.X,
+		Y: v.Y,
+	}
+}
+
+// Neg outputs -p
+func (p *G1Affine) Neg(api frontend.API, p1 G1Affine) *G1Affine {
+	p.X = p1.X
+	p.Y = api.Sub(0, p1.Y)
+	return p
+}
+
+// AddAssign adds p1 to p using the affine formulas with division, and return p
+func (p *G1Affine) AddAssign(api frontend.API, p1 G1Affine) *G1Affine {
+
+	// compute lambda = (p1.y-p.y)/(p1.x-p.x)
+	lambda := api.DivUnchecked(api.Sub(p1.Y, p.Y), api.Sub(p1.X, p.X))
+
+	// xr = lambda**2-p.x-p1.x
+	xr := api.Sub(api.Mul(lambda, lambda), api.Add(p.X, p1.X))
+
+	// p.y = lambda(p.x-xr) - p.y
+	p.Y = api.Sub(api.Mul(lambda, api.Sub(p.X, xr)), p.Y)
+
+	//p.x = xr
+	p.X = xr
+	return p
+}
+
+// AddUnified adds q to p and returns p. It handles all the edge cases: the
+// inputs may be equal, opposite or the point at infinity (0,0).
+func (p *G1Affine) AddUnified(api frontend.API, q G1Affine) *G1Affine {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := api.And(api.IsZero(p.X), api.IsZero(p.Y))
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := api.And(api.IsZero(q.X), api.IsZero(q.Y))
+
+	// λ = ((p.x+q.x)² - p.x*q.x + a)/(p.y + q.y), here a=0
+	pxqx := api.Mul(p.X, q.X)
+	pxplusqx := api.Add(p.X, q.X)
+	num := api.Mul(pxplusqx, pxplusqx)
+	num = api.Sub(num, pxqx)
+	denum := api.Add(p.Y, q.Y)
+	// if p.y + q.y = 0, assign dummy 1 to denum and continue
+	selector3 := api.IsZero(denum)
+	denum = api.Select(selector3, 1, denum)
+	λ := api.Div(num, denum)
+
+	// x = λ^2 - p.x - q.x
+	xr := api.Mul(λ, λ)
+	xr = api.Sub(xr, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	yr := api.Sub(p.X, xr)
+	yr = api.Mul(yr, λ)
+	yr = api.Sub(yr, p.Y)
+	result := G1Affine{
+		X: xr,
+		Y: yr,
+	}
+
+	// if p=(0,0) return q
+	result.Select(api, selector1, q, result)
+	// if q=(0,0) return p
+	result.Select(api, selector2, *p, result)
+	// if p.y + q.y = 0, return (0, 0)
+	result.Select(api, selector3, G1Affine{0, 0}, result)
+
+	p.X = result.X
+	p.Y = result.Y
+
+	return p
+}
+
+// Select sets p1 if b=1, p2 if b=0, and returns it. b must be boolean constrained
+func (p *G1Affine) Select(api frontend.API, b frontend.Variable, p1, p2 G1Affine) *G1Affine {
+
+	p.X = api.Select(b, p1.X, p2.X)
+	p.Y = api.Select(b, p1.Y, p2.Y)
+
+	return p
+
+}
+
+// Lookup2 performs a 2-bit lookup between p1, p2, p3, p4 based on bits b0  and b1.
+// Returns:
+//   - p1 if b0=0 and b1=0,
+//   - p2 if b0=1 and b1=0,
+//   - p3 if b0=0 and b1=1,
+//   - p4 if b0=1 and b1=1.
+func (p *G1Affine) Lookup2(api frontend.API, b1, b2 frontend.Variable, p1, p2, p3, p4 G1Affine) *G1Affine {
+
+	p.X = api.Lookup2(b1, b2, p1.X, p2.X, p3.X, p4.X)
+	p.Y = api.Lookup2(b1, b2, p1.Y, p2.Y, p3.Y, p4.Y)
+
+	return p
+
+}
+
+// Double double a point in affine coords
+func (p *G1Affine) Double(api frontend.API, p1 G1Affine) *G1Affine {
+
+	var three, two big.Int
+	three.SetInt64(3)
+	two.SetInt64(2)
+
+	// compute lambda = (3*p1.x**2+a)/2*p1.y, here a=0 (j invariant 0 curve)
+	lambda := api.DivUnchecked(api.Mul(p1.X, p1.X, three), api.Mul(p1.Y, two))
+
+	// xr = lambda**2-2*p1.x
+	xr := api.Sub(api.Mul(lambda, lambda), api.Mul(p1.X, two))
+
+	// p.y = lambda(p.x-xr) - p.y
+	p.Y = api.Sub(api.Mul(lambda, api.Sub(p1.X, xr)), p1.Y)
+
+	//p.x = xr
+	p.X = xr
+
+	return p
+}
+
+// DoubleAndAdd computes 2*p1+p in affine coords
+func (p *G1Affine) DoubleAndAdd(api frontend.API, p1, p2 *G1Affine) *G1Affine {
+
+	// compute lambda1 = (y2-y1)/(x2-x1)
+	l1 := api.DivUnchecked(api.Sub(p1.Y, p2.Y), api.Sub(p1.X, p2.X))
+
+	// compute x3 = lambda1**2-x1-x2
+	x3 := api.Mul(l1, l1)
+	x3 = api.Sub(x3, api.Add(p1.X, p2.X))
+
+	// omit y3 computation
+	// compute lambda2 = lambda1+2*y1/(x3-x1)
+	l2 := api.DivUnchecked(api.Mul(p1.Y, big.NewInt(2)), api.Sub(x3, p1.X))
+	l2 = api.Add(l2, l1)
+
+	// compute x4 =lambda2**2-x1-x3
+	x4 := api.Mul(l2, l2)
+	x4 = api.Sub(x4, api.Add(p1.X, x3))
+
+	// compute y4 = lambda2*(x4 - x1)-y1
+	y4 := api.Sub(x4, p1.X)
+	y4 = api.Mul(l2, y4)
+	y4 = api.Sub(y4, p1.Y)
+
+	p.X = x4
+	p.Y = y4
+
+	return p
+}
+
+// AssertIsEqual constraint self to be equal to other into the given constraint system
+func (p *G1Affine) AssertIsEqual(api frontend.API, other G1Affine) {
+	api.AssertIsEqual(p.X, other.X)
+	api.AssertIsEqual(p.Y, other.Y)
+}
+
+// AssertIsOnCurve asserts that p satisfies the curve equation Y² = X³ - 17. As
+// Grumpkin has prime order, it also asserts that p is in the group. The point
+// at infinity (0,0) does not pass the check.
+func (p *G1Affine) AssertIsOnCurve(api frontend.API) {
+	left := api.Mul(p.Y, p.Y)
+	right := api.Sub(api.Mul(p.X, p.X, p.X), 17)
+	api.AssertIsEqual(left, right)
+}
+
+// ScalarMul sets P = [s] Q and returns P. The scalar s is a native field
+// element, so it is interpreted as an integer in [0, r) which is a subset of
+// the Grumpkin scalar field. To multiply by an arbitrary Grumpkin scalar use
+// [Curve.ScalarMul].
+//
+// The method chooses an implementation based on scalar s. If it is constant,
+// then the compiled circuit depends on s. If it is variable type, then
+// the circuit is independent of the inputs.
+func (P *G1Affine) ScalarMul(api frontend.API, Q G1Affine, s interface{}, opts ...algopts.AlgebraOption) *G1Affine {
+	if n, ok := api.Compiler().ConstantValue(s); ok {
+		return P.constScalarMul(api, Q, n, opts...)
+	} else {
+		return P.varScalarMul(api, Q, s, opts...)
+	}
+}
+
+// ScalarMulBase computes s * g and returns it, where g is the fixed generator.
+// It doesn't modify s.
+func (P *G1Affine) ScalarMulBase(api frontend.API, s frontend.Variable, opts ...algopts.AlgebraOption) *G1Affine {
+	return P.ScalarMul(api, NewG1Affine(Generator()), s, opts...)
+}
+
+// varScalarMul sets P = [s]Q for the native scalar s and returns P.
+func (P *G1Affine) varScalarMul(api frontend.API, Q G1Affine, s frontend.Variable, opts ...algopts.AlgebraOption) *G1Affine {
+	fr, err := emulated.NewField[ScalarField](api)
+	if err != nil {
+		panic(err)
+	}
+	// s < r < q, so the bits of s are also the canonical bits of the scalar.
+	sBits := bits.ToBinary(api, s)
+	return P.scalarMulGLV(api, fr, Q, fr.FromBits(sBits...), opts...)
+}
+
+// scalarMulGLV sets P = [s]Q and returns P. It doesn't modify Q nor s.
+//
+// We use the endomorphism à la GLV to compute [s]Q as
+//
+//	[s1]Q + [s2]Φ(Q)
+//
+// where s = s1 + λ*s2 mod q. The sub-scalars are about half the size of q and
+// we scan their bits simultaneously as in [Halo] (see Section 6.2 and appendix
+// C).
+//
+// ⚠️  The scalar s must be nonzero and the point Q different from (0,0) unless
+// [algopts.WithCompleteArithmetic] is set. (0,0) is not on the curve but we
+// conventionally take it as the neutral/infinity point as per the [EVM].
+//
+// [Halo]: https://eprint.iacr.org/2019/1021.pdf
+// [EVM]: https://ethereum.github.io/yellowpaper/paper.pdf
+func (P *G1Affine) scalarMulGLV(api frontend.API, fr *emulated.Field[ScalarField], Q G1Affine, s *Scalar, opts ...algopts.AlgebraOption) *G1Affine {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		panic(err)
+	}
+	s1bits, s2bits, neg1, neg2 := decomposeGLV(api, fr, s)
+	return P.scalarBitsMul(api, Q, s1bits, s2bits, neg1, neg2, cfg.CompleteArithmetic)
+}
+
+// decomposeGLV decomposes the scalar s as s = ±s1 ± λ*s2 mod q. It returns the
+// bit decompositions of |s1| and |s2| and the signs, which are 1 if the
+// corresponding sub-scalar is negative.
+func decomposeGLV(api frontend.API, fr *emulated.Field[ScalarField], s *Scalar) (s1bits, s2bits []frontend.Variable, neg1, neg2 frontend.Variable) {
+	cc := getCurveConfig(api.Compiler().Field())
+
+	// the sub-scalars s1, s2 can be negative. We obtain in the hint |s1|, |s2|
+	// and boolean flags to negate the points Q, Φ(Q) instead of the
+	// corresponding sub-scalars.
+	sd, err := fr.NewHintWithNativeOutput(decomposeScalar, 4, s)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	neg1, neg2 = sd[2], sd[3]
+	api.AssertIsBoolean(neg1)
+	api.AssertIsBoolean(neg2)
+	s1bits = bits.ToBinary(api, sd[0], bits.WithNbDigits(cc.nbits))
+	s2bits = bits.ToBinary(api, sd[1], bits.WithNbDigits(cc.nbits))
+
+	// ±s1 ± λ*s2 == s mod q
+	s1 := fr.FromBits(s1bits...)
+	s1 = fr.Select(neg1, fr.Neg(s1), s1)
+	s2 := fr.FromBits(s2bits...)
+	s2 = fr.Select(neg2, fr.Neg(s2), s2)
+	fr.AssertIsEqual(fr.Add(s1, fr.Mul(s2, fr.NewElement(cc.lambda))), s)
+	return s1bits, s2bits, neg1, neg2
+}
+
+// glvTable holds the points added in the GLV double-and-add loop for a point
+// Q and the signs of the sub-scalars.
+type glvTable struct {
+	// q and phiQ are -Q, Q and -Φ(Q), Φ(Q), with the signs of the sub-scalars
+	q, phiQ [2]G1Affine
+	// b are Q+Φ(Q), -Q-Φ(Q), Q-Φ(Q) and -Q+Φ(Q)
+	b [4]G1Affine
+}
+
+func newGLVTable(api frontend.API, Q G1Affine, neg1, neg2 frontend.Variable) *glvTable {
+	cc := getCurveConfig(api.Compiler().Field())
+	var t glvTable
+	// precompute ±Q, ±Φ(Q) with the signs of the sub-scalars
+	negQY := api.Neg(Q.Y)
+	t.q[1] = G1Affine{X: Q.X, Y: api.Select(neg1, negQY, Q.Y)}
+	t.q[0].Neg(api, t.q[1])
+	cc.phi(api, &t.phiQ[1], &G1Affine{X: Q.X, Y: api.Select(neg2, negQY, Q.Y)})
+	t.phiQ[0].Neg(api, t.phiQ[1])
+
+	// At each iteration we need to compute:
+	// 		[2]Acc ± Q ± Φ(Q).
+	// We can compute [2]Acc and look up the (precomputed) point B from:
+	// 		B1 = +Q + Φ(Q)
+	t.b[0] = t.q[1]
+	t.b[0].AddAssign(api, t.phiQ[1])
+	// 		B2 = -Q - Φ(Q)
+	t.b[1].Neg(api, t.b[0])
+	// 		B3 = +Q - Φ(Q)
+	t.b[2] = t.q[1]
+	t.b[2].AddAssign(api, t.phiQ[0])
+	// 		B4 = -Q + Φ(Q)
+	t.b[3].Neg(api, t.b[2])
+	//
+	// Note that half the points are negatives of the other half,
+	// hence have the same X coordinates.
+	return &t
+}
+
+// lookup returns the point ±Q ± Φ(Q) for the bits b1 and b2 of the
+// sub-scalars.
+func (t *glvTable) lookup(api frontend.API, b1, b2 frontend.Variable) *G1Affine {
+	return &G1Affine{
+		X: api.Select(api.Xor(b1, b2), t.b[2].X, t.b[1].X),
+		Y: api.Lookup2(b1, b2, t.b[1].Y, t.b[2].Y, t.b[3].Y, t.b[0].Y),
+	}
+}
+
+// scalarBitsMul computes [±s1]Q + [±s2]Φ(Q) where s1bits and s2bits are the
+// bit decompositions of s1 and s2 and neg1 and neg2 are the signs.
+func (P *G1Affine) scalarBitsMul(api frontend.API, Q G1Affine, s1bits, s2bits []frontend.Variable, neg1, neg2 frontend.Variable, complete bool) *G1Affine {
+	nbits := len(s1bits)
+
+	var selector frontend.Variable
+	if complete {
+		// if Q=(0,0) we assign a dummy (1,1) to Q and continue
+		selector = api.And(api.IsZero(Q.X), api.IsZero(Q.Y))
+		Q.Select(api, selector, G1Affine{X: 1, Y: 1}, Q)
+	}
+	addFn := (*G1Affine).AddAssign
+	if complete {
+		addFn = (*G1Affine).AddUnified
+	}
+	t := newGLVTable(api, Q, neg1, neg2)
+
+	// We suppose that the first bits of the sub-scalars are 1 and set Acc = Q
+	// + Φ(Q). When the high bits of the sub-scalars are 0, the accumulator
+	// stays equal to Q + Φ(Q) and doubleAndAdd(Acc, B) as (Acc+B)+Acc would
+	// hit the incomplete case Acc == -B. Grumpkin has prime order so we can
+	// not use a small order point to avoid it. Instead, we add a fixed point
+	// O with unknown discrete logarithm to the accumulator and subtract
+	// [2^(nbits-1)]O from the result at the end.
+	offset, offsetEnd := scalarMulOffsets(nbits)
+	Acc := t.b[0]
+	Acc.AddAssign(api, offset)
+
+	for i := nbits - 1; i > 0; i-- {
+		// Acc = [2]Acc + B
+		Acc.DoubleAndAdd(api, &Acc, t.lookup(api, s1bits[i], s2bits[i]))
+	}
+
+	// i = 0
+	// subtract the Q, Φ(Q) if the first bits are 0.
+	// When complete is set, we use AddUnified instead of AddAssign. This means
+	// when s=0 then Acc=(0,0) because AddUnified(Q, -Q) = (0,0).
+	addFn(&t.q[0], api, Acc)
+	Acc.Select(api, s1bits[0], Acc, t.q[0])
+	addFn(&t.phiQ[0], api, Acc)
+	Acc.Select(api, s2bits[0], Acc, t.phiQ[0])
+
+	// subtract [2^(nbits-1)]O since we added O at the beginning
+	addFn(&Acc, api, offsetEnd)
+	if complete {
+		Acc.Select(api, selector, G1Affine{X: 0, Y: 0}, Acc)
+	}
+
+	P.X = Acc.X
+	P.Y = Acc.Y
+
+	return P
+}
+
+// multiScalarMulGLV sets P = ∑[s_i]Q_i and returns P. The points share the
+// doublings of a single GLV double-and-add loop, so that every additional
+// point costs a point addition per bit of the sub-scalars instead of a
+// doubling and an addition.
+//
+// ⚠️  The points must be different from (0,0) and the result must not be the
+// point at infinity. Use the complete arithmetic of [Curve.MultiScalarMul]
+// otherwise.
+func (P *G1Affine) multiScalarMulGLV(api frontend.API, fr *emulated.Field[ScalarField], Q []G1Affine, s []*Scalar) *G1Affine {
+	type glvPoint struct {
+		s1bits, s2bits []frontend.Variable
+		table          *glvTable
+	}
+	points := make([]glvPoint, len(Q))
+	for i := range Q {
+		s1bits, s2bits, neg1, neg2 := decomposeGLV(api, fr, s[i])
+		points[i] = glvPoint{s1bits: s1bits, s2bits: s2bits, table: newGLVTable(api, Q[i], neg1, neg2)}
+	}
+	nbits := len(points[0].s1bits)
+
+	// see scalarBitsMul for the initialization of the accumulator with the
+	// offset point, it also avoids the incomplete cases of the additions of the
+	// points after the first one.
+	offset, offsetEnd := scalarMulOffsets(nbits)
+	Acc := offset
+	for i := range points {
+		Acc.AddAssign(api, points[i].table.b[0])
+	}
+
+	for i := nbits - 1; i > 0; i-- {
+		// Acc = [2]Acc + ∑B_j
+		Acc.DoubleAndAdd(api, &Acc, points[0].table.lookup(api, points[0].s1bits[i], points[0].s2bits[i]))
+		for j := 1; j < len(points); j++ {
+			Acc.AddAssign(api, *points[j].table.lookup(api, points[j].s1bits[i], points[j].s2bits[i]))
+		}
+	}
+
+	// i = 0
+	// subtract the Q_j, Φ(Q_j) if the first bits are 0.
+	for j := range points {
+		t := points[j].table
+		t.q[0].AddAssign(api, Acc)
+		Acc.Select(api, points[j].s1bits[0], Acc, t.q[0])
+		t.phiQ[0].AddAssign(api, Acc)
+		Acc.Select(api, points[j].s2bits[0], Acc, t.phiQ[0])
+	}
+
+	// subtract [2^(nbits-1)]O since we added O at the beginning
+	Acc.AddAssign(api, offsetEnd)
+
+	P.X = Acc.X
+	P.Y = Acc.Y
+
+	return P
+}
+
+// scalarMulOffsets returns the offset point O used in the scalar
+// multiplication and the point -[2^(nbits-1)]O.
+func scalarMulOffsets(nbits int) (G1Affine, G1Affine) {
+	o, err := deriveGenerator("sw_grumpkin scalar multiplication offset", 0)
+	if err != nil {
+		panic(err)
+	}
+	var end PointAffine
+	end.ScalarMultiplication(&o, new(big.Int).Lsh(big.NewInt(1), uint(nbits-1)))
+	end.Neg(&end)
+	return NewG1Affine(o), NewG1Affine(end)
+}
+
+// constScalarMul sets P = [s] Q and returns P.
+func (P *G1Affine) constScalarMul(api frontend.API, Q G1Affine, s *big.Int, opts ...algopts.AlgebraOption) *G1Affine {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		panic(err)
+	}
+	cc := getCurveConfig(api.Compiler().Field())
+	s = new(big.Int).Mod(s, cc.fq)
+	if s.BitLen() == 0 {
+		P.X = 0
+		P.Y = 0
+		return P
+	}
+	// see the comments in scalarBitsMul. However, two-bit lookup is cheaper if
+	// bits are constant and here it makes sense to use the table in the main
+	// loop.
+	var Acc, negQ, negPhiQ, phiQ G1Affine
+	cc.phi(api, &phiQ, &Q)
+
+	k := ecc.SplitScalar(s, cc.glvBasis)
+	if k[0].Sign() == -1 {
+		k[0].Neg(&k[0])
+		Q.Neg(api, Q)
+	}
+	if k[1].Sign() == -1 {
+		k[1].Neg(&k[1])
+		phiQ.Neg(api, phiQ)
+	}
+	nbits := k[0].BitLen()
+	if k[1].BitLen() > nbits {
+		nbits = k[1].BitLen()
+	}
+	negQ.Neg(api, Q)
+	negPhiQ.Neg(api, phiQ)
+	var table [4]G1Affine
+	table[0] = negQ
+	table[1] = Q
+	table[2] = negQ
+	table[3] = Q
+
+	if cfg.CompleteArithmetic {
+		table[0].AddUnified(api, negPhiQ)
+		table[1].AddUnified(api, negPhiQ)
+		table[2].AddUnified(api, phiQ)
+		table[3].AddUnified(api, phiQ)
+	} else {
+		table[0].AddAssign(api, negPhiQ)
+		table[1].AddAssign(api, negPhiQ)
+		table[2].AddAssign(api, phiQ)
+		table[3].AddAssign(api, phiQ)
+	}
+
+	Acc = table[3]
+	// if both high bits are set, then we would get to the incomplete part,
+	// handle it separately.
+	if k[0].Bit(nbits-1) == 1 && k[1].Bit(nbits-1) == 1 {
+		if cfg.CompleteArithmetic {
+			Acc.AddUnified(api, Acc)
+			Acc.AddUnified(api, table[3])
+		} else {
+			Acc.Double(api, Acc)
+			Acc.AddAssign(api, table[3])
+		}
+		nbits = nbits - 1
+	}
+	for i := nbits - 1; i > 0; i-- {
+		if cfg.CompleteArithmetic {
+			Acc.AddUnified(api, Acc)
+			Acc.AddUnified(api, table[k[0].Bit(i)+2*k[1].Bit(i)])
+		} else {
+			Acc.DoubleAndAdd(api, &Acc, &table[k[0].Bit(i)+2*k[1].Bit(i)])
+		}
+	}
+
+	// i = 0
+	if cfg.CompleteArithmetic {
+		negQ.AddUnified(api, Acc)
+		Acc.Select(api, k[0].Bit(0), Acc, negQ)
+		negPhiQ.AddUnified(api, Acc)
+	} else {
+		negQ.AddAssign(api, Acc)
+		Acc.Select(api, k[0].Bit(0), Acc, negQ)
+		negPhiQ.AddAssign(api, Acc)
+	}
+	Acc.Select(api, k[1].Bit(0), Acc, negPhiQ)
+	P.X, P.Y = Acc.X, Acc.Y
+
+	return P
+}
@@ -0,0 +1,412 @@
+package sw_grumpkin
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fp_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/test"
+)
+
+func randomPoint(t *testing.T) PointAffine {
+	s, err := rand.Int(rand.Reader, ecc.BN254.BaseField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p PointAffine
+	p.ScalarMultiplicationBase(s)
+	return p
+}
+
+func TestGenerator(t *testing.T) {
+	assert := test.NewAssert(t)
+	g := Generator()
+	assert.True(g.IsOnCurve())
+	assert.False(g.IsInfinity())
+
+	var p PointAffine
+	p.ScalarMultiplication(&g, new(big.Int).Sub(ecc.BN254.BaseField(), big.NewInt(1)))
+	p.Add(&p, &g)
+	assert.True(p.IsInfinity(), "group order")
+
+	// Φ(G) = [λ]G
+	cc := getCurveConfig(ecc.BN254.ScalarField())
+	var w fr_bn254.Element
+	w.SetBigInt(cc.thirdRootOne)
+	var phiG PointAffine
+	phiG.X.Mul(&g.X, &w)
+	phiG.Y = g.Y
+	p.ScalarMultiplication(&g, cc.lambda)
+	assert.True(p.Equal(&phiG), "endomorphism eigenvalue")
+}
+
+type g1AddAssign struct {
+	A, B G1Affine
+	C    G1Affine `gnark:",public"`
+}
+
+func (circuit *g1AddAssign) Define(api frontend.API) error {
+	expected := circuit.A
+	expected.AddAssign(api, circuit.B)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestAddAssignG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	a, b := randomPoint(t), randomPoint(t)
+	var c PointAffine
+	c.Add(&a, &b)
+	witness := g1AddAssign{
+		A: NewG1Affine(a),
+		B: NewG1Affine(b),
+		C: NewG1Affine(c),
+	}
+	assert.CheckCircuit(&g1AddAssign{}, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+}
+
+type g1AddUnified struct {
+	A, B G1Affine
+	C    G1Affine `gnark:",public"`
+}
+
+func (circuit *g1AddUnified) Define(api frontend.API) error {
+	expected := circuit.A
+	expected.AddUnified(api, circuit.B)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestAddUnifiedG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	a, b := randomPoint(t), randomPoint(t)
+	var c, negA PointAffine
+	negA.Neg(&a)
+	c.Add(&a, &b)
+	c2 := a
+	c2.Double(&c2)
+	for _, tc := range []struct{ a, b, c PointAffine }{
+		{a, b, c},
+		{a, a, c2},
+		{a, negA, PointAffine{}},
+		{PointAffine{}, b, b},
+		{a, PointAffine{}, a},
+	} {
+		witness := g1AddUnified{
+			A: NewG1Affine(tc.a),
+			B: NewG1Affine(tc.b),
+			C: NewG1Affine(tc.c),
+		}
+		err := test.IsSolved(&g1AddUnified{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type g1DoubleAndAdd struct {
+	A, B G1Affine
+	C    G1Affine `gnark:",public"`
+}
+
+func (circuit *g1DoubleAndAdd) Define(api frontend.API) error {
+	var expected G1Affine
+	expected.DoubleAndAdd(api, &circuit.A, &circuit.B)
+	expected.AssertIsEqual(api, circuit.C)
+	var double G1Affine
+	double.Double(api, circuit.A)
+	double.AddAssign(api, circuit.B)
+	double.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestDoubleAndAddG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	a, b := randomPoint(t), randomPoint(t)
+	var c PointAffine
+	c.Double(&a).Add(&c, &b)
+	witness := g1DoubleAndAdd{
+		A: NewG1Affine(a),
+		B: NewG1Affine(b),
+		C: NewG1Affine(c),
+	}
+	assert.CheckCircuit(&g1DoubleAndAdd{}, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+}
+
+type g1IsOnCurve struct {
+	A G1Affine
+}
+
+func (circuit *g1IsOnCurve) Define(api frontend.API) error {
+	circuit.A.AssertIsOnCurve(api)
+	return nil
+}
+
+func TestIsOnCurveG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	a := randomPoint(t)
+	err := test.IsSolved(&g1IsOnCurve{}, &g1IsOnCurve{A: NewG1Affine(a)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	a.Y.Double(&a.Y)
+	err = test.IsSolved(&g1IsOnCurve{}, &g1IsOnCurve{A: NewG1Affine(a)}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type g1varScalarMul struct {
+	A G1Affine
+	C G1Affine `gnark:",public"`
+	R frontend.Variable
+}
+
+func (circuit *g1varScalarMul) Define(api frontend.API) error {
+	expected := G1Affine{}
+	expected.ScalarMul(api, circuit.A, circuit.R)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestVarScalarMulG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_bn254.Element
+	r.SetRandom()
+	s := r.BigInt(new(big.Int))
+	a := randomPoint(t)
+	var c PointAffine
+	c.ScalarMultiplication(&a, s)
+	witness := g1varScalarMul{
+		A: NewG1Affine(a),
+		C: NewG1Affine(c),
+		R: r,
+	}
+	assert.CheckCircuit(&g1varScalarMul{}, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+}
+
+type g1varScalarMulEdgeCases struct {
+	A    G1Affine
+	C    G1Affine `gnark:",public"`
+	R, S frontend.Variable
+}
+
+func (circuit *g1varScalarMulEdgeCases) Define(api frontend.API) error {
+	expected1 := G1Affine{}
+	expected2 := G1Affine{}
+	expected3 := G1Affine{}
+	infinity := G1Affine{X: 0, Y: 0}
+	expected1.ScalarMul(api, infinity, circuit.R, algopts.WithCompleteArithmetic())
+	expected2.ScalarMul(api, circuit.A, 0, algopts.WithCompleteArithmetic())
+	expected3.ScalarMul(api, circuit.A, circuit.S, algopts.WithCompleteArithmetic())
+	expected1.AssertIsEqual(api, infinity)
+	expected2.AssertIsEqual(api, infinity)
+	expected3.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestVarScalarMulG1EdgeCases(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_bn254.Element
+	r.SetRandom()
+	a := randomPoint(t)
+	for _, s := range []int64{0, 1, 2, -1} {
+		var c PointAffine
+		c.ScalarMultiplication(&a, new(big.Int).Mod(big.NewInt(s), ecc.BN254.ScalarField()))
+		witness := g1varScalarMulEdgeCases{
+			A: NewG1Affine(a),
+			C: NewG1Affine(c),
+			R: r,
+			S: s,
+		}
+		err := test.IsSolved(&g1varScalarMulEdgeCases{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "scalar %d", s)
+	}
+}
+
+type g1constScalarMul struct {
+	A G1Affine
+	C G1Affine `gnark:",public"`
+	R *big.Int
+}
+
+func (circuit *g1constScalarMul) Define(api frontend.API) error {
+	expected := G1Affine{}
+	expected.ScalarMul(api, circuit.A, circuit.R)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestConstantScalarMulG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_bn254.Element
+	r.SetRandom()
+	a := randomPoint(t)
+	for _, s := range []*big.Int{r.BigInt(new(big.Int)), big.NewInt(1), big.NewInt(2), big.NewInt(3)} {
+		var c PointAffine
+		c.ScalarMultiplication(&a, s)
+		witness := g1constScalarMul{
+			A: NewG1Affine(a),
+			C: NewG1Affine(c),
+		}
+		err := test.IsSolved(&g1constScalarMul{R: s}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "scalar %s", s)
+	}
+}
+
+type g1varScalarMulBase struct {
+	C G1Affine `gnark:",public"`
+	R frontend.Variable
+}
+
+func (circuit *g1varScalarMulBase) Define(api frontend.API) error {
+	expected := G1Affine{}
+	expected.ScalarMulBase(api, circuit.R)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestVarScalarMulBaseG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	var r fr_bn254.Element
+	r.SetRandom()
+	var c PointAffine
+	c.ScalarMultiplicationBase(r.BigInt(new(big.Int)))
+	witness := g1varScalarMulBase{
+		C: NewG1Affine(c),
+		R: r,
+	}
+	err := test.IsSolved(&g1varScalarMulBase{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type scalarMulTest struct {
+	P, Q G1Affine
+	S    Scalar
+}
+
+func (c *scalarMulTest) Define(api frontend.API) error {
+	cr, err := NewCurve(api)
+	if err != nil {
+		return err
+	}
+	res := cr.ScalarMul(&c.P, &c.S)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestScalarMul(t *testing.T) {
+	assert := test.NewAssert(t)
+	// scalars larger than the native field
+	var s fp_bn254.Element
+	s.SetBigInt(new(big.Int).Sub(ecc.BN254.BaseField(), big.NewInt(2)))
+	var r fp_bn254.Element
+	r.SetRandom()
+	p := randomPoint(t)
+	for _, sc := range []fp_bn254.Element{s, r} {
+		var q PointAffine
+		q.ScalarMultiplication(&p, sc.BigInt(new(big.Int)))
+		witness := scalarMulTest{
+			P: NewG1Affine(p),
+			Q: NewG1Affine(q),
+			S: NewScalar(sc),
+		}
+		err := test.IsSolved(&scalarMulTest{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type multiScalarMulTest struct {
+	Points  []G1Affine
+	Scalars []Scalar
+	Res     G1Affine
+
+	complete bool
+}
+
+func (c *multiScalarMulTest) Define(api frontend.API) error {
+	cr, err := NewCurve(api)
+	if err != nil {
+		return err
+	}
+	ps := make([]*G1Affine, len(c.Points))
+	for i := range c.Points {
+		ps[i] = &c.Points[i]
+	}
+	ss := make([]*Scalar, len(c.Scalars))
+	for i := range c.Scalars {
+		ss[i] = &c.Scalars[i]
+	}
+	var opts []algopts.AlgebraOption
+	if c.complete {
+		opts = append(opts, algopts.WithCompleteArithmetic())
+	}
+	res, err := cr.MultiScalarMul(ps, ss, opts...)
+	if err != nil {
+		return err
+	}
+	cr.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMultiScalarMul(t *testing.T) {
+	assert := test.NewAssert(t)
+	nbLen := 4
+	cP := make([]G1Affine, nbLen)
+	cS := make([]Scalar, nbLen)
+	var res, tmp PointAffine
+	for i := 0; i < nbLen; i++ {
+		var s fp_bn254.Element
+		s.SetRandom()
+		p := randomPoint(t)
+		if i == 1 {
+			// zero scalar
+			s.SetZero()
+		}
+		if i == 2 {
+			// point at infinity
+			p = PointAffine{}
+		}
+		tmp.ScalarMultiplication(&p, s.BigInt(new(big.Int)))
+		res.Add(&res, &tmp)
+		cP[i] = NewG1Affine(p)
+		cS[i] = NewScalar(s)
+	}
+	witness := multiScalarMulTest{
+		Points:  cP,
+		Scalars: cS,
+		Res:     NewG1Affine(res),
+	}
+	err := test.IsSolved(&multiScalarMulTest{
+		Points:   make([]G1Affine, nbLen),
+		Scalars:  make([]Scalar, nbLen),
+		complete: true,
+	}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestMultiScalarMulIncomplete(t *testing.T) {
+	assert := test.NewAssert(t)
+	nbLen := 4
+	cP := make([]G1Affine, nbLen)
+	cS := make([]Scalar, nbLen)
+	var res, tmp PointAffine
+	for i := 0; i < nbLen; i++ {
+		var s fp_bn254.Element
+		s.SetRandom()
+		p := randomPoint(t)
+		tmp.ScalarMultiplication(&p, s.BigInt(new(big.Int)))
+		res.Add(&res, &tmp)
+		cP[i] = NewG1Affine(p)
+		cS[i] = NewScalar(s)
+	}
+	witness := multiScalarMulTest{
+		Points:  cP,
+		Scalars: cS,
+		Res:     NewG1Affine(res),
+	}
+	err := test.IsSolved(&multiScalarMulTest{
+		Points:  make([]G1Affine, nbLen),
+		Scalars: make([]Scalar, nbLen),
+	}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,44 @@
+package sw_grumpkin
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		decomposeScalar,
+	}
+}
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// decomposeScalar decomposes the emulated scalar s as s = s1 + λ*s2 mod q. It
+// returns the native outputs |s1|, |s2| and the flags which are 1 if the
+// corresponding sub-scalar is negative and 0 otherwise.
+func decomposeScalar(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(inputs, outputs, func(field *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 {
+			return fmt.Errorf("expecting one input")
+		}
+		if len(outputs) != 4 {
+			return fmt.Errorf("expecting four outputs")
+		}
+		cc := getCurveConfig(mod)
+		sp := ecc.SplitScalar(inputs[0], cc.glvBasis)
+		for i := 0; i < 2; i++ {
+			outputs[i].Abs(&sp[i])
+			outputs[i+2].SetUint64(0)
+			if sp[i].Sign() == -1 {
+				outputs[i+2].SetUint64(1)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,168 @@
+package sw_grumpkin
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// PointAffine is a Grumpkin point in affine coordinates used for computing
+// witness values out-of-circuit. The point at infinity is represented as
+// (0,0).
+//
+// gnark-crypto does not implement Grumpkin, so the type provides the minimal
+// arithmetic needed for assigning and checking circuits. It is not optimized
+// and is not constant time.
+type PointAffine struct {
+	X, Y fr.Element
+}
+
+// bCurveCoeff is the coefficient b=-17 of the curve equation Y² = X³ + b.
+var bCurveCoeff fr.Element
+
+func init() {
+	bCurveCoeff.SetInt64(-17)
+}
+
+// Generator returns the standard generator (1, √-16) of Grumpkin.
+func Generator() PointAffine {
+	var g PointAffine
+	g.X.SetOne()
+	g.Y.SetString("0x0000000000000002cf135e7506a45d632d270d45f1181294833fc48d823f272c")
+	return g
+}
+
+// IsInfinity returns true if p is the point at infinity.
+func (p *PointAffine) IsInfinity() bool {
+	return p.X.IsZero() && p.Y.IsZero()
+}
+
+// IsOnCurve returns true if p is on the curve or is the point at infinity.
+// As Grumpkin has prime order, every point on the curve is in the group.
+func (p *PointAffine) IsOnCurve() bool {
+	if p.IsInfinity() {
+		return true
+	}
+	var left, right fr.Element
+	left.Square(&p.Y)
+	right.Square(&p.X).Mul(&right, &p.X).Add(&right, &bCurveCoeff)
+	return left.Equal(&right)
+}
+
+// Equal returns true if p and q are the same point.
+func (p *PointAffine) Equal(q *PointAffine) bool {
+	return p.X.Equal(&q.X) && p.Y.Equal(&q.Y)
+}
+
+// Neg sets p = -q and returns p.
+func (p *PointAffine) Neg(q *PointAffine) *PointAffine {
+	p.X.Set(&q.X)
+	p.Y.Neg(&q.Y)
+	return p
+}
+
+// Add sets p = q + r and returns p.
+func (p *PointAffine) Add(q, r *PointAffine) *PointAffine {
+	if q.IsInfinity() {
+		*p = *r
+		return p
+	}
+	if r.IsInfinity() {
+		*p = *q
+		return p
+	}
+	if q.X.Equal(&r.X) {
+		if q.Y.Equal(&r.Y) {
+			return p.Double(q)
+		}
+		*p = PointAffine{}
+		return p
+	}
+	// λ = (y2-y1)/(x2-x1)
+	var l, t, xr, yr fr.Element
+	l.Sub(&r.Y, &q.Y)
+	t.Sub(&r.X, &q.X)
+	l.Div(&l, &t)
+	// xr = λ²-x1-x2
+	xr.Square(&l).Sub(&xr, &q.X).Sub(&xr, &r.X)
+	// yr = λ(x1-xr)-y1
+	yr.Sub(&q.X, &xr).Mul(&yr, &l).Sub(&yr, &q.Y)
+	p.X, p.Y = xr, yr
+	return p
+}
+
+// Double sets p = [2]q and returns p.
+func (p *PointAffine) Double(q *PointAffine) *PointAffine {
+	if q.IsInfinity() {
+		*p = PointAffine{}
+		return p
+	}
+	// λ = 3x²/2y
+	var l, t, xr, yr fr.Element
+	l.Square(&q.X)
+	t.Double(&l)
+	l.Add(&l, &t)
+	t.Double(&q.Y)
+	l.Div(&l, &t)
+	// xr = λ²-2x
+	t.Double(&q.X)
+	xr.Square(&l).Sub(&xr, &t)
+	// yr = λ(x-xr)-y
+	yr.Sub(&q.X, &xr).Mul(&yr, &l).Sub(&yr, &q.Y)
+	p.X, p.Y = xr, yr
+	return p
+}
+
+// ScalarMultiplication sets p = [s]q and returns p. The scalar s is reduced
+// modulo the group order.
+func (p *PointAffine) ScalarMultiplication(q *PointAffine, s *big.Int) *PointAffine {
+	e := new(big.Int).Mod(s, ScalarField{}.Modulus())
+	base := *q
+	var res PointAffine
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		res.Double(&res)
+		if e.Bit(i) == 1 {
+			res.Add(&res, &base)
+		}
+	}
+	*p = res
+	return p
+}
+
+// ScalarMultiplicationBase sets p = [s]G where G is the standard generator and
+// returns p.
+func (p *PointAffine) ScalarMultiplicationBase(s *big.Int) *PointAffine {
+	g := Generator()
+	return p.ScalarMultiplication(&g, s)
+}
+
+// deriveGenerator returns the first candidate point on the curve with the
+// x-coordinate given by SHA-256(personalization || BE32(index) || counter).
+// The y-coordinate is chosen to be even.
+func deriveGenerator(personalization string, index uint32) (PointAffine, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha256.New()
+		h.Write([]byte(personalization))
+		var buf [5]byte
+		binary.BigEndian.PutUint32(buf[:4], index)
+		buf[4] = byte(ctr)
+		h.Write(buf[:])
+		var p PointAffine
+		p.X.SetBytes(h.Sum(nil))
+		// y² = x³ - 17
+		var yy fr.Element
+		yy.Square(&p.X).Mul(&yy, &p.X).Add(&yy, &bCurveCoeff)
+		if p.Y.Sqrt(&yy) == nil || yy.IsZero() {
+			continue
+		}
+		var y big.Int
+		if p.Y.BigInt(&y).Bit(0) == 1 {
+			p.Y.Neg(&p.Y)
+		}
+		return p, nil
+	}
+	return PointAffine{}, fmt.Errorf("no generator found for index %d", index)
+}
@@ -0,0 +1,154 @@
+package twistededwards
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/expand"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// secLevel is the target security level k in bits for hashing to field.
+const secLevel = 128
+
+// HashToCurve hashes the message msg to a point in the prime order subgroup
+// using the domain separation tag dst as defined in [RFC 9380 Section 3]. The
+// message is hashed to field elements using expand_message_xmd with SHA-256
+// and mapped to the curve c using [MapToCurve].
+//
+// [RFC 9380 Section 3]: https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func HashToCurve(c Curve, msg []uints.U8, dst []byte) (Point, error) {
+	u, err := hashToField(c.API(), msg, dst, 2)
+	if err != nil {
+		return Point{}, err
+	}
+	q0 := MapToCurve(c, u[0])
+	q1 := MapToCurve(c, u[1])
+	return clearCofactor(c, c.Add(q0, q1)), nil
+}
+
+// MapToCurve maps the field element u to a point on the curve c using the
+// Elligator 2 method as defined in [RFC 9380 Section 6.8.2]. The point is
+// computed on the birationally equivalent Montgomery curve and then mapped to
+// the twisted Edwards curve. The result is not necessarily in the prime order
+// subgroup.
+//
+// [RFC 9380 Section 6.8.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-6.8.2
+func MapToCurve(c Curve, u frontend.Variable) Point {
+	api := c.API()
+	p := api.Compiler().Field()
+	j, k, z := elligatorConstants(p, c.Params())
+	// c1 = J/K, c2 = 1/K²
+	c1 := new(big.Int).ModInverse(k, p)
+	c2 := new(big.Int).Mul(c1, c1)
+	c2.Mod(c2, p)
+	c1.Mul(c1, j).Mod(c1, p)
+
+	// x1 = -c1 * inv0(1 + Z*u²), or -c1 if the denominator is zero
+	uu := api.Mul(u, u)
+	den := api.Add(1, api.Mul(uu, z))
+	denIsZero := api.IsZero(den)
+	x1 := api.Neg(api.DivUnchecked(c1, api.Select(denIsZero, 1, den)))
+	gx1 := evalMontgomery(api, x1, c1, c2)
+	// x2 = -x1 - c1
+	x2 := api.Sub(api.Neg(x1), c1)
+
+	// if gx1 is not a square, then Z*gx1 is. In that case g(x2) = Z*u²*gx1 and
+	// we obtain its root as u*sqrt(Z*gx1). In the exceptional case the
+	// denominator is zero we have x2 = 0 and thus g(x2) = 0.
+	res, err := api.Compiler().NewHint(isSquareHint, 1, gx1)
+	if err != nil {
+		panic(fmt.Sprintf("is square hint: %v", err))
+	}
+	isSquare := res[0]
+	api.AssertIsBoolean(isSquare)
+	s := sqrt(api, api.Select(isSquare, gx1, api.Mul(gx1, z)))
+	x := api.Select(isSquare, x1, x2)
+	y := api.Select(isSquare, s, api.Select(denIsZero, 0, api.Mul(u, s)))
+	// sgn0(y) must be 1 if gx1 is square and 0 otherwise
+	sy := bits.ToBinary(api, y)[0]
+	y = api.Select(api.Xor(sy, isSquare), api.Neg(y), y)
+
+	// the point (s, t) on the Montgomery curve K*t² = s³ + J*s² + s
+	ms := api.Mul(x, k)
+	mt := api.Mul(y, k)
+	// map to the twisted Edwards curve (s/t, (s-1)/(s+1)). The exceptional
+	// cases are mapped to the identity.
+	sp1 := api.Add(ms, 1)
+	isExc := api.IsZero(api.Mul(mt, sp1))
+	return Point{
+		X: api.Select(isExc, 0, api.DivUnchecked(ms, api.Select(isExc, 1, mt))),
+		Y: api.Select(isExc, 1, api.DivUnchecked(api.Sub(ms, 1), api.Select(isExc, 1, sp1))),
+	}
+}
+
+// hashToField hashes msg into count native field elements as defined in [RFC
+// 9380 Section 5.2]. The reduction modulo the native field is implicit in the
+// native arithmetic.
+//
+// [RFC 9380 Section 5.2]: https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2
+func hashToField(api frontend.API, msg []uints.U8, dst []byte, count int) ([]frontend.Variable, error) {
+	L := (api.Compiler().FieldBitLen() + secLevel + 7) / 8
+	bts, err := expand.ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, fmt.Errorf("expand message: %w", err)
+	}
+	ret := make([]frontend.Variable, count)
+	for i := range ret {
+		var acc frontend.Variable = 0
+		for _, b := range bts[i*L : (i+1)*L] {
+			acc = api.Add(api.Mul(acc, 256), b.Val)
+		}
+		ret[i] = acc
+	}
+	return ret, nil
+}
+
+// clearCofactor multiplies p by the cofactor of the curve.
+func clearCofactor(c Curve, p Point) Point {
+	h := c.Params().Cofactor
+	res := p
+	for i := h.BitLen() - 2; i >= 0; i-- {
+		res = c.Double(res)
+		if h.Bit(i) == 1 {
+			res = c.Add(res, p)
+		}
+	}
+	return res
+}
+
+// evalMontgomery returns x³ + c1*x² + c2*x.
+func evalMontgomery(api frontend.API, x frontend.Variable, c1, c2 *big.Int) frontend.Variable {
+	xx := api.Mul(x, x)
+	return api.Add(api.Mul(xx, x), api.Mul(xx, c1), api.Mul(x, c2))
+}
+
+// sqrt returns a square root of x computed in a hint.
+func sqrt(api frontend.API, x frontend.Variable) frontend.Variable {
+	res, err := api.Compiler().NewHint(sqrtHint, 1, x)
+	if err != nil {
+		panic(fmt.Sprintf("sqrt hint: %v", err))
+	}
+	api.AssertIsEqual(api.Mul(res[0], res[0]), x)
+	return res[0]
+}
+
+// elligatorConstants returns the coefficients J and K of the Montgomery curve
+// K*t² = s³ + J*s² + s birationally equivalent to the twisted Edwards curve and
+// the smallest non-square Z in the field.
+func elligatorConstants(p *big.Int, params *CurveParams) (j, k, z *big.Int) {
+	// J = 2(a+d)/(a-d), K = 4/(a-d)
+	amd := new(big.Int).Sub(params.A, params.D)
+	amd.ModInverse(amd.Mod(amd, p), p)
+	j = new(big.Int).Add(params.A, params.D)
+	j.Lsh(j, 1).Mul(j, amd).Mod(j, p)
+	k = new(big.Int).Lsh(amd, 2)
+	k.Mod(k, p)
+	z = big.NewInt(2)
+	for big.Jacobi(z, p) != -1 {
+		z.Add(z, big.NewInt(1))
+	}
+	return j, k, z
+}
@@ -0,0 +1,152 @@
+package twistededwards
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	tbn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToCurveCircuit struct {
+	curveID twistededwards.ID
+	U       frontend.Variable
+}
+
+func (circuit *mapToCurveCircuit) Define(api frontend.API) error {
+	curve, err := NewEdCurve(api, circuit.curveID)
+	if err != nil {
+		return err
+	}
+	curve.AssertIsOnCurve(MapToCurve(curve, circuit.U))
+	return nil
+}
+
+func TestMapToCurve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range curves {
+		snarkField, err := GetSnarkField(curve)
+		assert.NoError(err)
+		for i := 0; i < 4; i++ {
+			u, err := rand.Int(rand.Reader, snarkField)
+			assert.NoError(err)
+			err = test.IsSolved(&mapToCurveCircuit{curveID: curve}, &mapToCurveCircuit{curveID: curve, U: u}, snarkField)
+			assert.NoError(err, curve)
+		}
+	}
+}
+
+type hashToCurveCircuit struct {
+	Msg      []uints.U8
+	Expected Point
+	dst      []byte
+}
+
+func (circuit *hashToCurveCircuit) Define(api frontend.API) error {
+	curve, err := NewEdCurve(api, twistededwards.BN254)
+	if err != nil {
+		return err
+	}
+	res, err := HashToCurve(curve, circuit.Msg, circuit.dst)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(res.X, circuit.Expected.X)
+	api.AssertIsEqual(res.Y, circuit.Expected.Y)
+	return nil
+}
+
+// elligator2 is the reference implementation of the Elligator 2 map following
+// the straight-line description in RFC 9380.
+func elligator2(p *big.Int, params *CurveParams, u *big.Int) (x, y *big.Int) {
+	j, k, z := elligatorConstants(p, params)
+	kInv := new(big.Int).ModInverse(k, p)
+	c1 := new(big.Int).Mul(j, kInv)
+	c1.Mod(c1, p)
+	c2 := new(big.Int).Mul(kInv, kInv)
+	c2.Mod(c2, p)
+	g := func(x *big.Int) *big.Int {
+		r := new(big.Int).Exp(x, big.NewInt(3), p)
+		r.Add(r, new(big.Int).Mul(c1, new(big.Int).Mul(x, x)))
+		r.Add(r, new(big.Int).Mul(c2, x))
+		return r.Mod(r, p)
+	}
+	tv := new(big.Int).Mul(u, u)
+	tv.Mul(tv, z).Add(tv, big.NewInt(1)).Mod(tv, p)
+	x1 := new(big.Int)
+	if tv.Sign() != 0 {
+		x1.ModInverse(tv, p)
+	}
+	x1.Mul(x1, c1).Neg(x1).Mod(x1, p)
+	if x1.Sign() == 0 {
+		x1.Neg(c1).Mod(x1, p)
+	}
+	x2 := new(big.Int).Add(x1, c1)
+	x2.Neg(x2).Mod(x2, p)
+	var mx, my *big.Int
+	if gx1 := g(x1); big.Jacobi(gx1, p) >= 0 {
+		mx, my = x1, new(big.Int).ModSqrt(gx1, p)
+		if my.Bit(0) == 0 {
+			my.Sub(p, my).Mod(my, p)
+		}
+	} else {
+		mx, my = x2, new(big.Int).ModSqrt(g(x2), p)
+		if my.Bit(0) == 1 {
+			my.Sub(p, my)
+		}
+	}
+	s := new(big.Int).Mul(mx, k)
+	t := new(big.Int).Mul(my, k)
+	s.Mod(s, p)
+	t.Mod(t, p)
+	sp1 := new(big.Int).Add(s, big.NewInt(1))
+	sp1.Mod(sp1, p)
+	if t.Sign() == 0 || sp1.Sign() == 0 {
+		return big.NewInt(0), big.NewInt(1)
+	}
+	x = new(big.Int).ModInverse(t, p)
+	x.Mul(x, s).Mod(x, p)
+	y = new(big.Int).ModInverse(sp1, p)
+	y.Mul(y, new(big.Int).Sub(s, big.NewInt(1))).Mod(y, p)
+	return x, y
+}
+
+func TestHashToCurve(t *testing.T) {
+	assert := test.NewAssert(t)
+	params, err := GetCurveParams(twistededwards.BN254)
+	assert.NoError(err)
+	snarkField, err := GetSnarkField(twistededwards.BN254)
+	assert.NoError(err)
+	dst := []byte("QUUX-V01-CS02-with-BabyJubjub_XMD:SHA-256_ELL2_RO_")
+	msg := []byte("abc")
+
+	const L = 48
+	bts, err := hash.ExpandMsgXmd(msg, dst, 2*L)
+	assert.NoError(err)
+	var res tbn254.PointAffine
+	for i := 0; i < 2; i++ {
+		u := new(big.Int).SetBytes(bts[i*L : (i+1)*L])
+		u.Mod(u, snarkField)
+		x, y := elligator2(snarkField, params, u)
+		var q tbn254.PointAffine
+		q.X.SetBigInt(x)
+		q.Y.SetBigInt(y)
+		assert.True(q.IsOnCurve())
+		if i == 0 {
+			res = q
+		} else {
+			res.Add(&res, &q)
+		}
+	}
+	res.ScalarMultiplication(&res, params.Cofactor)
+
+	circuit := hashToCurveCircuit{Msg: make([]uints.U8, len(msg)), dst: dst}
+	witness := hashToCurveCircuit{Msg: uints.NewU8Array(msg), Expected: Point{X: res.X, Y: res.Y}}
+	err = test.IsSolved(&circuit, &witness, snarkField)
+	assert.NoError(err)
+}
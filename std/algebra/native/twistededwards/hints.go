@@ -0,0 +1,47 @@
+package twistededwards
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{isSquareHint, sqrtHint}
+}
+
+// isSquareHint returns 1 if the input is a square in the native field and 0
+// otherwise.
+func isSquareHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) != 1 {
+		return fmt.Errorf("expecting one input")
+	}
+	if len(outputs) != 1 {
+		return fmt.Errorf("expecting one output")
+	}
+	outputs[0].SetUint64(0)
+	if big.Jacobi(inputs[0], mod) >= 0 {
+		outputs[0].SetUint64(1)
+	}
+	return nil
+}
+
+// sqrtHint returns a square root of the input in the native field.
+func sqrtHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) != 1 {
+		return fmt.Errorf("expecting one input")
+	}
+	if len(outputs) != 1 {
+		return fmt.Errorf("expecting one output")
+	}
+	if outputs[0].ModSqrt(inputs[0], mod) == nil {
+		return fmt.Errorf("no square root")
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra"
 	"github.com/consensys/gnark/std/algebra/algopts"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -113,6 +114,12 @@ func ValueOfCommitment[G1El algebra.G1ElementT](cmt any) (Commitment[G1El], erro
 			return ret, fmt.Errorf("mismatching types %T %T", ret, cmt)
 		}
 		s.G1El = sw_bls12381.NewG1Affine(tCmt)
+	case *Commitment[emsw_bls12377.G1Affine]:
+		tCmt, ok := cmt.(bls12377.G1Affine)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, cmt)
+		}
+		s.G1El = emsw_bls12377.NewG1Affine(tCmt)
 	case *Commitment[sw_bw6761.G1Affine]:
 		tCmt, ok := cmt.(bw6761.G1Affine)
 		if !ok {
@@ -166,6 +173,13 @@ func ValueOfOpeningProof[FR emulated.FieldParams, G1El algebra.G1ElementT](proof
 		}
 		s.Quotient = sw_bls12381.NewG1Affine(tProof.H)
 		s.ClaimedValue = sw_bls12381.NewScalar(tProof.ClaimedValue)
+	case *OpeningProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine]:
+		tProof, ok := proof.(kzg_bls12377.OpeningProof)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, proof)
+		}
+		s.Quotient = emsw_bls12377.NewG1Affine(tProof.H)
+		s.ClaimedValue = emsw_bls12377.NewScalar(tProof.ClaimedValue)
 	case *OpeningProof[sw_bw6761.ScalarField, sw_bw6761.G1Affine]:
 		tProof, ok := proof.(kzg_bw6761.OpeningProof)
 		if !ok {
@@ -224,6 +238,16 @@ func ValueOfBatchOpeningProof[FR emulated.FieldParams, G1El any](proof any) (Bat
 		for i := 0; i < len(s.ClaimedValues); i++ {
 			s.ClaimedValues[i] = sw_bls12381.NewScalar(tProof.ClaimedValues[i])
 		}
+	case *BatchOpeningProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine]:
+		tProof, ok := proof.(kzg_bls12377.BatchOpeningProof)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, proof)
+		}
+		s.Quotient = emsw_bls12377.NewG1Affine(tProof.H)
+		s.ClaimedValues = make([]emulated.Element[emsw_bls12377.ScalarField], len(tProof.ClaimedValues))
+		for i := 0; i < len(s.ClaimedValues); i++ {
+			s.ClaimedValues[i] = emsw_bls12377.NewScalar(tProof.ClaimedValues[i])
+		}
 	case *BatchOpeningProof[sw_bw6761.ScalarField, sw_bw6761.G1Affine]:
 		tProof, ok := proof.(kzg_bw6761.BatchOpeningProof)
 		if !ok {
@@ -271,6 +295,9 @@ func PlaceholderVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT]()
 	case *VerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
 		s.G2[0] = sw_bls12381.NewG2AffineFixedPlaceholder()
 		s.G2[1] = sw_bls12381.NewG2AffineFixedPlaceholder()
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		s.G2[0] = emsw_bls12377.NewG2AffineFixedPlaceholder()
+		s.G2[1] = emsw_bls12377.NewG2AffineFixedPlaceholder()
 	case *VerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine]:
 		s.G2[0] = sw_bw6761.NewG2AffineFixedPlaceholder()
 		s.G2[1] = sw_bw6761.NewG2AffineFixedPlaceholder()
@@ -313,6 +340,14 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT](vk an
 		s.G1 = sw_bls12381.NewG1Affine(tVk.G1)
 		s.G2[0] = sw_bls12381.NewG2Affine(tVk.G2[0])
 		s.G2[1] = sw_bls12381.NewG2Affine(tVk.G2[1])
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(kzg_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, vk)
+		}
+		s.G1 = emsw_bls12377.NewG1Affine(tVk.G1)
+		s.G2[0] = emsw_bls12377.NewG2Affine(tVk.G2[0])
+		s.G2[1] = emsw_bls12377.NewG2Affine(tVk.G2[1])
 	case *VerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine]:
 		tVk, ok := vk.(kzg_bw6761.VerifyingKey)
 		if !ok {
@@ -368,6 +403,14 @@ func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT](
 		s.G1 = sw_bls12381.NewG1Affine(tVk.G1)
 		s.G2[0] = sw_bls12381.NewG2AffineFixed(tVk.G2[0])
 		s.G2[1] = sw_bls12381.NewG2AffineFixed(tVk.G2[1])
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(kzg_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("mismatching types %T %T", ret, vk)
+		}
+		s.G1 = emsw_bls12377.NewG1Affine(tVk.G1)
+		s.G2[0] = emsw_bls12377.NewG2AffineFixed(tVk.G2[0])
+		s.G2[1] = emsw_bls12377.NewG2AffineFixed(tVk.G2[1])
 	case *VerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine]:
 		tVk, ok := vk.(kzg_bw6761.VerifyingKey)
 		if !ok {
@@ -390,16 +433,42 @@ func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT](
 	return ret, nil
 }
 
+type verifierCfg struct {
+	msmOpts []algopts.AlgebraOption
+}
+
+// VerifierOption allows to alter the KZG verifier behaviour.
+type VerifierOption func(cfg *verifierCfg) error
+
+// WithWindowedMSM uses windowed multi-scalar multiplication with lookup tables
+// for folding the commitments and the opening proofs, see
+// [algopts.WithWindowedMSM]. It reduces the number of constraints when
+// folding many commitments (16 or more).
+func WithWindowedMSM(windowSize int) VerifierOption {
+	return func(cfg *verifierCfg) error {
+		cfg.msmOpts = append(cfg.msmOpts, algopts.WithWindowedMSM(windowSize))
+		return nil
+	}
+}
+
 // Verifier allows verifying KZG opening proofs.
 type Verifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.G2ElementT] struct {
 	api       frontend.API
 	scalarApi *emulated.Field[FR]
 	curve     algebra.Curve[FR, G1El]
 	pairing   algebra.Pairing[G1El, G2El, GtEl]
+	msmOpts   []algopts.AlgebraOption
 }
 
-// NewVerifier initializes a new Verifier instance.
-func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.G2ElementT](api frontend.API) (*Verifier[FR, G1El, G2El, GtEl], error) {
+// NewVerifier initializes a new Verifier instance. It returns an error if the
+// given options are invalid.
+func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.G2ElementT](api frontend.API, opts ...VerifierOption) (*Verifier[FR, G1El, G2El, GtEl], error) {
+	cfg := new(verifierCfg)
+	for i := range opts {
+		if err := opts[i](cfg); err != nil {
+			return nil, fmt.Errorf("apply option %d: %w", i, err)
+		}
+	}
 	curve, err := algebra.GetCurve[FR, G1El](api)
 	if err != nil {
 		return nil, err
@@ -417,6 +486,7 @@ func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.
 		scalarApi: scalarApi,
 		curve:     curve,
 		pairing:   pairing,
+		msmOpts:   cfg.msmOpts,
 	}, nil
 }
 
@@ -522,12 +592,12 @@ func (v *Verifier[FR, G1El, G2El, GTEl]) FoldProofsMultiPoint(digests []Commitme
 	for i := 0; i < len(randomNumbers); i++ {
 		quotients[i] = &proofs[i].Quotient
 	}
-	foldedQuotients, err := v.curve.MultiScalarMul(quotients[1:], randomNumbers[1:])
+	foldedQuotients, err := v.curve.MultiScalarMul(quotients[1:], randomNumbers[1:], v.msmOpts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("fold quotients: %w", err)
 	}
 	foldedQuotients = v.curve.Add(foldedQuotients, quotients[0])
-	foldedPointsQuotients, err := v.curve.MultiScalarMul(quotients, randomPointNumbers)
+	foldedPointsQuotients, err := v.curve.MultiScalarMul(quotients, randomPointNumbers, v.msmOpts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("fold point quotients: %w", err)
 	}
@@ -623,7 +693,7 @@ func (v *Verifier[FR, G1El, G2El, GTEl]) FoldProof(digests []Commitment[G1El], b
 	for i := range digestsP {
 		digestsP[i] = &digests[i].G1El
 	}
-	foldedDigests, err := v.curve.MultiScalarMul(digestsP[1:], gammai[1:])
+	foldedDigests, err := v.curve.MultiScalarMul(digestsP[1:], gammai[1:], v.msmOpts...)
 	if err != nil {
 		return retP, retC, fmt.Errorf("multi scalar mul: %w", err)
 	}
@@ -699,7 +769,7 @@ func (v *Verifier[FR, G1El, G2El, GTEl]) fold(digests []Commitment[G1El], fai []
 	for i := range digestPoints {
 		digestPoints[i] = &digests[i].G1El
 	}
-	foldedDigest, err := v.curve.MultiScalarMul(digestPoints[1:], ci[1:])
+	foldedDigest, err := v.curve.MultiScalarMul(digestPoints[1:], ci[1:], v.msmOpts...)
 	if err != nil {
 		return Commitment[G1El]{}, nil, fmt.Errorf("fold digests: %w", err)
 	}
@@ -701,11 +701,13 @@ type BatchVerifyMultiPointsTest[S emulated.FieldParams, G1El, G2El, GTEl any] st
 	Digests [4]Commitment[G1El]
 	Proofs  [4]OpeningProof[S, G1El]
 	Points  [4]emulated.Element[S]
+
+	opts []VerifierOption
 }
 
 func (circuit *BatchVerifyMultiPointsTest[S, G1El, G2El, GTEl]) Define(api frontend.API) error {
 
-	verifier, err := NewVerifier[S, G1El, G2El, GTEl](api)
+	verifier, err := NewVerifier[S, G1El, G2El, GTEl](api, circuit.opts...)
 	if err != nil {
 		return fmt.Errorf("get pairing: %w", err)
 	}
@@ -780,6 +782,10 @@ func TestBatchVerifyMultiPoints(t *testing.T) {
 	var circuit BatchVerifyMultiPointsTest[emulated.BN254Fr, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]
 	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BLS12_381), test.WithBackends(backend.PLONK))
 
+	// fold using windowed MSM with lookups
+	circuit.opts = []VerifierOption{WithWindowedMSM(4)}
+	err = test.IsSolved(&circuit, &assignment, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
 }
 
 type KZGVerificationConstantVkCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GTEl algebra.GtElementT] struct {
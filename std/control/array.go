@@ -0,0 +1,69 @@
+package control
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/selector"
+)
+
+// Array is an array of variable length: only the first Length values are
+// elements of the array, the capacity of the array being len(Values). An Array
+// can be a field of a circuit, whose witness then holds the length.
+//
+// We must have 0 <= Length <= len(Values), which is asserted by the methods
+// of the array, otherwise a proof cannot be generated.
+type Array struct {
+	Values []frontend.Variable
+	Length frontend.Variable
+}
+
+// NewArray returns the array of the first length values.
+func NewArray(values []frontend.Variable, length frontend.Variable) Array {
+	return Array{Values: values, Length: length}
+}
+
+// Mask returns the mask of the elements of the array: out[i] is 1 if i <
+// Length, and 0 otherwise.
+func (a Array) Mask(api frontend.API) []frontend.Variable {
+	return Mask(api, len(a.Values), a.Length)
+}
+
+// ForEach runs the body of a loop over the elements of the array, see [For].
+func (a Array) ForEach(api frontend.API, body func(api frontend.API, i int, v, active frontend.Variable) error) error {
+	return For(api, len(a.Values), a.Length, func(api frontend.API, i int, active frontend.Variable) error {
+		return body(api, i, a.Values[i], active)
+	})
+}
+
+// Get returns the element at the index i, which must be less than Length.
+func (a Array) Get(api frontend.API, i frontend.Variable) frontend.Variable {
+	api.AssertIsLessOrEqual(api.Add(i, 1), a.Length)
+	return selector.Mux(api, i, a.Values...)
+}
+
+// Sum returns the sum of the elements of the array.
+func (a Array) Sum(api frontend.API) frontend.Variable {
+	mask := a.Mask(api)
+	res := frontend.Variable(0)
+	for i := range mask {
+		res = api.MulAcc(res, mask[i], a.Values[i])
+	}
+	return res
+}
+
+// AssertIsEqual asserts that a and b have the same length and the same
+// elements. The values past the length are not compared.
+func (a Array) AssertIsEqual(api frontend.API, b Array) {
+	api.AssertIsEqual(a.Length, b.Length)
+	mask := Mask(api, min(len(a.Values), len(b.Values)), a.Length)
+	for i := range mask {
+		api.AssertIsEqual(api.Mul(mask[i], api.Sub(a.Values[i], b.Values[i])), 0)
+	}
+}
+
+// AssertPadding asserts that the values past the length are equal to pad.
+func (a Array) AssertPadding(api frontend.API, pad frontend.Variable) {
+	mask := a.Mask(api)
+	for i := range mask {
+		api.AssertIsEqual(api.Mul(api.Sub(1, mask[i]), api.Sub(a.Values[i], pad)), 0)
+	}
+}
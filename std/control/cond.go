@@ -0,0 +1,77 @@
+package control
+
+import (
+	"github.com/consensys/gnark/frontend"
+)
+
+// If runs the body of a conditional block, whose assertions only hold if cond
+// is 1. The circuit defined by the body is the same for both values of cond,
+// but the body is given an api whose assertions are gated by cond: for example
+// api.AssertIsEqual(a, b) asserts cond⋅(a-b) == 0. The blocks can be nested.
+//
+// When cond is 0, the values returned by the operations of the api asserting
+// their inputs (Div, Inverse and ToBinary) are computed on neutral inputs and
+// are unspecified. Only the assertions of the api are gated: the gadgets
+// checking their inputs with the builder (for example range checks or lookups)
+// must be given masked inputs, for example api.Select(cond, v, 0).
+//
+// cond must be boolean, otherwise a proof cannot be generated.
+func If(api frontend.API, cond frontend.Variable, body func(api frontend.API) error) error {
+	api.AssertIsBoolean(cond)
+	return body(newConditionalAPI(api, cond))
+}
+
+// conditionalAPI gates the assertions of api by the boolean cond.
+type conditionalAPI struct {
+	frontend.API
+	cond frontend.Variable
+}
+
+func newConditionalAPI(api frontend.API, cond frontend.Variable) frontend.API {
+	if c, ok := api.Compiler().ConstantValue(cond); ok && c.IsUint64() && c.Uint64() == 1 {
+		return api
+	}
+	return &conditionalAPI{API: api, cond: cond}
+}
+
+// gate returns v if cond is 1, and 0 otherwise.
+func (c *conditionalAPI) gate(v frontend.Variable) frontend.Variable {
+	return c.API.Mul(c.cond, v)
+}
+
+// gateNonZero returns v if cond is 1, and 1 otherwise.
+func (c *conditionalAPI) gateNonZero(v frontend.Variable) frontend.Variable {
+	return c.API.Select(c.cond, v, 1)
+}
+
+func (c *conditionalAPI) AssertIsEqual(i1, i2 frontend.Variable) {
+	c.API.AssertIsEqual(c.gate(c.API.Sub(i1, i2)), 0)
+}
+
+func (c *conditionalAPI) AssertIsDifferent(i1, i2 frontend.Variable) {
+	c.API.AssertIsDifferent(c.gateNonZero(c.API.Sub(i1, i2)), 0)
+}
+
+func (c *conditionalAPI) AssertIsBoolean(i1 frontend.Variable) {
+	c.API.AssertIsBoolean(c.gate(i1))
+}
+
+func (c *conditionalAPI) AssertIsCrumb(i1 frontend.Variable) {
+	c.API.AssertIsCrumb(c.gate(i1))
+}
+
+func (c *conditionalAPI) AssertIsLessOrEqual(v frontend.Variable, bound frontend.Variable) {
+	c.API.AssertIsLessOrEqual(c.gate(v), bound)
+}
+
+func (c *conditionalAPI) Div(i1, i2 frontend.Variable) frontend.Variable {
+	return c.API.Div(i1, c.gateNonZero(i2))
+}
+
+func (c *conditionalAPI) Inverse(i1 frontend.Variable) frontend.Variable {
+	return c.API.Inverse(c.gateNonZero(i1))
+}
+
+func (c *conditionalAPI) ToBinary(i1 frontend.Variable, n ...int) []frontend.Variable {
+	return c.API.ToBinary(c.gate(i1), n...)
+}
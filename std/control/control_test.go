@@ -0,0 +1,167 @@
+package control_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/control"
+	"github.com/consensys/gnark/test"
+)
+
+type forCircuit struct {
+	In  [6]frontend.Variable
+	N   frontend.Variable
+	Sum frontend.Variable
+}
+
+func (c *forCircuit) Define(api frontend.API) error {
+	sum := frontend.Variable(0)
+	err := control.For(api, len(c.In), c.N, func(api frontend.API, i int, active frontend.Variable) error {
+		// only the first N inputs are non-zero
+		api.AssertIsDifferent(c.In[i], 0)
+		sum = api.Add(sum, api.Mul(active, c.In[i]))
+		return nil
+	})
+	api.AssertIsEqual(sum, c.Sum)
+	return err
+}
+
+func TestFor(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&forCircuit{},
+		test.WithValidAssignment(&forCircuit{In: [6]frontend.Variable{1, 2, 3, 0, 0, 0}, N: 3, Sum: 6}),
+		test.WithValidAssignment(&forCircuit{In: [6]frontend.Variable{1, 2, 3, 4, 5, 6}, N: 6, Sum: 21}),
+		test.WithValidAssignment(&forCircuit{In: [6]frontend.Variable{0, 0, 0, 0, 0, 0}, N: 0, Sum: 0}),
+		test.WithInvalidAssignment(&forCircuit{In: [6]frontend.Variable{1, 0, 3, 0, 0, 0}, N: 3, Sum: 4}),
+		test.WithInvalidAssignment(&forCircuit{In: [6]frontend.Variable{1, 2, 3, 0, 0, 0}, N: 3, Sum: 5}),
+		test.WithInvalidAssignment(&forCircuit{In: [6]frontend.Variable{1, 2, 3, 4, 5, 6}, N: 7, Sum: 21}),
+		test.WithCurves(ecc.BN254))
+}
+
+type whileCircuit struct {
+	In    [6]frontend.Variable
+	Count frontend.Variable
+	Last  frontend.Variable
+}
+
+func (c *whileCircuit) Define(api frontend.API) error {
+	last := frontend.Variable(0)
+	count, err := control.While(api, len(c.In), func(api frontend.API, i int, active frontend.Variable) (frontend.Variable, error) {
+		// stop at the first zero input, which must be followed by a one
+		if i+1 < len(c.In) {
+			stop := api.IsZero(c.In[i])
+			err := control.If(api, stop, func(api frontend.API) error {
+				api.AssertIsEqual(c.In[i+1], 1)
+				return nil
+			})
+			return api.Sub(1, stop), err
+		}
+		last = api.Select(active, c.In[i], last)
+		return 1, nil
+	})
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(count, c.Count)
+	api.AssertIsEqual(last, c.Last)
+	return nil
+}
+
+func TestWhile(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&whileCircuit{},
+		test.WithValidAssignment(&whileCircuit{In: [6]frontend.Variable{3, 2, 0, 1, 0, 0}, Count: 3, Last: 0}),
+		test.WithValidAssignment(&whileCircuit{In: [6]frontend.Variable{0, 1, 5, 5, 5, 5}, Count: 1, Last: 0}),
+		test.WithValidAssignment(&whileCircuit{In: [6]frontend.Variable{3, 2, 4, 1, 7, 9}, Count: 6, Last: 9}),
+		test.WithInvalidAssignment(&whileCircuit{In: [6]frontend.Variable{3, 2, 0, 1, 0, 0}, Count: 4, Last: 0}),
+		test.WithInvalidAssignment(&whileCircuit{In: [6]frontend.Variable{3, 2, 0, 2, 0, 0}, Count: 3, Last: 0}),
+		test.WithCurves(ecc.BN254))
+}
+
+type ifCircuit struct {
+	Cond, Nested frontend.Variable
+	A, B         frontend.Variable
+}
+
+func (c *ifCircuit) Define(api frontend.API) error {
+	return control.If(api, c.Cond, func(api frontend.API) error {
+		api.AssertIsEqual(c.A, c.B)
+		api.AssertIsLessOrEqual(c.A, 10)
+		api.AssertIsEqual(api.Mul(api.Div(c.A, c.B), c.B), c.A)
+		return control.If(api, c.Nested, func(api frontend.API) error {
+			api.AssertIsBoolean(c.A)
+			api.AssertIsDifferent(c.A, 0)
+			return nil
+		})
+	})
+}
+
+func TestIf(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&ifCircuit{},
+		test.WithValidAssignment(&ifCircuit{Cond: 0, Nested: 1, A: 12, B: 0}),
+		test.WithValidAssignment(&ifCircuit{Cond: 1, Nested: 0, A: 5, B: 5}),
+		test.WithValidAssignment(&ifCircuit{Cond: 1, Nested: 1, A: 1, B: 1}),
+		test.WithInvalidAssignment(&ifCircuit{Cond: 1, Nested: 0, A: 5, B: 4}),
+		test.WithInvalidAssignment(&ifCircuit{Cond: 1, Nested: 0, A: 12, B: 12}),
+		test.WithInvalidAssignment(&ifCircuit{Cond: 1, Nested: 1, A: 5, B: 5}),
+		test.WithInvalidAssignment(&ifCircuit{Cond: 2, Nested: 0, A: 5, B: 4}),
+		test.WithCurves(ecc.BN254))
+}
+
+type arrayCircuit struct {
+	A, B  control.Array
+	Index frontend.Variable
+	Elem  frontend.Variable
+	Sum   frontend.Variable
+}
+
+func (c *arrayCircuit) Define(api frontend.API) error {
+	c.A.AssertIsEqual(api, c.B)
+	c.A.AssertPadding(api, 0)
+	api.AssertIsEqual(c.A.Get(api, c.Index), c.Elem)
+	api.AssertIsEqual(c.A.Sum(api), c.Sum)
+	return c.B.ForEach(api, func(api frontend.API, i int, v, active frontend.Variable) error {
+		api.AssertIsLessOrEqual(v, 100)
+		return nil
+	})
+}
+
+func newArrayCircuit() *arrayCircuit {
+	return &arrayCircuit{
+		A: control.Array{Values: make([]frontend.Variable, 5)},
+		B: control.Array{Values: make([]frontend.Variable, 4)},
+	}
+}
+
+func newArrayAssignment(a []frontend.Variable, lenA int, b []frontend.Variable, lenB, index, elem, sum int) *arrayCircuit {
+	return &arrayCircuit{
+		A:     control.NewArray(a, lenA),
+		B:     control.NewArray(b, lenB),
+		Index: index,
+		Elem:  elem,
+		Sum:   sum,
+	}
+}
+
+func TestArray(t *testing.T) {
+	assert := test.NewAssert(t)
+	a := []frontend.Variable{1, 2, 3, 0, 0}
+	assert.CheckCircuit(newArrayCircuit(),
+		test.WithValidAssignment(newArrayAssignment(a, 3, []frontend.Variable{1, 2, 3, 500}, 3, 2, 3, 6)),
+		test.WithValidAssignment(newArrayAssignment(a, 3, []frontend.Variable{1, 2, 3, 500}, 3, 0, 1, 6)),
+		// different elements
+		test.WithInvalidAssignment(newArrayAssignment(a, 3, []frontend.Variable{1, 4, 3, 0}, 3, 2, 3, 6)),
+		// different lengths
+		test.WithInvalidAssignment(newArrayAssignment(a, 3, []frontend.Variable{1, 2, 3, 0}, 4, 2, 3, 6)),
+		// index out of range
+		test.WithInvalidAssignment(newArrayAssignment(a, 3, []frontend.Variable{1, 2, 3, 0}, 3, 3, 0, 6)),
+		// non-zero padding
+		test.WithInvalidAssignment(newArrayAssignment([]frontend.Variable{1, 2, 3, 0, 7}, 3, []frontend.Variable{1, 2, 3, 0}, 3, 2, 3, 6)),
+		// element out of bound
+		test.WithInvalidAssignment(newArrayAssignment([]frontend.Variable{1, 200, 3, 0, 0}, 3, []frontend.Variable{1, 200, 3, 0}, 3, 2, 3, 204)),
+		// length larger than the capacity of B
+		test.WithInvalidAssignment(newArrayAssignment([]frontend.Variable{1, 2, 3, 4, 5}, 5, []frontend.Variable{1, 2, 3, 4}, 5, 2, 3, 15)),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,13 @@
+// Package control provides control flow helpers for circuits.
+//
+// A circuit has a static structure, so that a loop over a variable number of
+// iterations is unrolled up to a static maximum, and each iteration is masked
+// by a boolean telling if it is active. This package provides:
+//
+//   - [For] and [While], the loops with a variable number of iterations;
+//   - [If], the blocks whose assertions only hold when a condition is true;
+//   - [Array], the arrays with a variable length up to their capacity.
+//
+// The masks are computed with the hints of the selector package, which are
+// registered with std.RegisterHints.
+package control
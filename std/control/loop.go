@@ -0,0 +1,74 @@
+package control
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/selector"
+)
+
+// Mask returns the mask of the first n of max elements. More precisely, for
+// each i we have:
+//
+//	if i < n
+//	    out[i] = 1
+//	else
+//	    out[i] = 0
+//
+// We must have 0 <= n <= max, otherwise a proof cannot be generated.
+func Mask(api frontend.API, max int, n frontend.Variable) []frontend.Variable {
+	switch max {
+	case 0:
+		api.AssertIsEqual(n, 0)
+		return nil
+	case 1:
+		api.AssertIsBoolean(n)
+		return []frontend.Variable{n}
+	}
+	ones := make([]frontend.Variable, max)
+	for i := range ones {
+		ones[i] = 1
+	}
+	return selector.Partition(api, n, false, ones)
+}
+
+// For runs the body of a loop over n iterations, where n is a variable of at
+// most max. The loop is unrolled max times: the body is called for each i in
+// [0, max) with active = 1 if i < n and active = 0 otherwise, and with an api
+// whose assertions only hold for the active iterations (see [If]). The values
+// computed by the inactive iterations must be masked with active, for example
+// with api.Select(active, v, 0).
+//
+// We must have 0 <= n <= max, otherwise a proof cannot be generated.
+func For(api frontend.API, max int, n frontend.Variable, body func(api frontend.API, i int, active frontend.Variable) error) error {
+	mask := Mask(api, max, n)
+	for i := range mask {
+		if err := body(newConditionalAPI(api, mask[i]), i, mask[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// While runs the body of a loop while it returns 1, up to max iterations, and
+// returns the number of active iterations. The body is called for each i in
+// [0, max) with active = 1 if all the previous iterations returned 1 and
+// active = 0 otherwise, and with an api whose assertions only hold for the
+// active iterations (see [If]). The value returned by an active iteration must
+// be boolean, the value returned by an inactive iteration is ignored.
+//
+// If all the iterations return 1, the returned count is max: the loop is
+// stopped even if the condition of the loop still holds, which can be
+// asserted by the caller.
+func While(api frontend.API, max int, body func(api frontend.API, i int, active frontend.Variable) (frontend.Variable, error)) (frontend.Variable, error) {
+	active := frontend.Variable(1)
+	count := frontend.Variable(0)
+	for i := 0; i < max; i++ {
+		cont, err := body(newConditionalAPI(api, active), i, active)
+		if err != nil {
+			return nil, err
+		}
+		count = api.Add(count, active)
+		active = api.Mul(active, cont)
+		api.AssertIsBoolean(active)
+	}
+	return count, nil
+}
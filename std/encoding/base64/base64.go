@@ -0,0 +1,146 @@
+// Package base64 implements in-circuit base64 decoding as specified by RFC
+// 4648.
+//
+// Every input character is mapped to a 6-bit value (sextet) using a lookup
+// table. Every four sextets are then split and recombined into three bytes.
+// The decoding is strict: the padding bits of the last character must be zero
+// and the padding characters may only appear at the end of the padded input.
+package base64
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/bitslice"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+const (
+	// padValue is the table value for the padding character.
+	padValue = 64
+	// invalid is the table value for characters not in the alphabet.
+	invalid = 0xff
+
+	encodeStd = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	encodeURL = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+)
+
+// Encoding is a base64 encoding defined by a 64-character alphabet and
+// padding.
+type Encoding struct {
+	alphabet string
+	padding  bool
+}
+
+var (
+	// StdEncoding is the standard base64 encoding with padding.
+	StdEncoding = &Encoding{alphabet: encodeStd, padding: true}
+	// URLEncoding is the URL and filename safe base64 encoding with padding.
+	URLEncoding = &Encoding{alphabet: encodeURL, padding: true}
+	// RawStdEncoding is the standard base64 encoding without padding.
+	RawStdEncoding = &Encoding{alphabet: encodeStd, padding: false}
+	// RawURLEncoding is the URL and filename safe base64 encoding without
+	// padding. It is used for example in JSON Web Tokens.
+	RawURLEncoding = &Encoding{alphabet: encodeURL, padding: false}
+)
+
+// DecodedLen returns the maximal length of the decoded data for an input of n
+// characters. It panics if n is not a valid input length for the encoding.
+func (e *Encoding) DecodedLen(n int) int {
+	if e.padding {
+		if n%4 != 0 {
+			panic(fmt.Sprintf("padded input length %d not multiple of 4", n))
+		}
+		return n / 4 * 3
+	}
+	if n%4 == 1 {
+		panic(fmt.Sprintf("invalid unpadded input length %d", n))
+	}
+	return n/4*3 + max(n%4-1, 0)
+}
+
+// Decode decodes the input and returns the decoded bytes and the length of the
+// decoded data. It asserts that the input is correctly encoded.
+//
+// For the encodings without padding, the length of the output is fixed by the
+// length of the input. For the encodings with padding, the output has always
+// [Encoding.DecodedLen] bytes, but the last one or two bytes are zero when the
+// input is padded. The returned length excludes these bytes.
+func (e *Encoding) Decode(api frontend.API, in []uints.U8) (out []uints.U8, length frontend.Variable) {
+	n := e.DecodedLen(len(in))
+	if len(in) == 0 {
+		return nil, 0
+	}
+	rc := rangecheck.New(api)
+	tbl := logderivlookup.New(api)
+	for c := 0; c < 256; c++ {
+		tbl.Insert(e.decodeChar(byte(c)))
+	}
+	inds := make([]frontend.Variable, len(in))
+	for i := range in {
+		// the characters must be range checked as otherwise the lookup could
+		// be out of the table.
+		rc.Check(in[i].Val, 8)
+		inds[i] = in[i].Val
+	}
+	sextets := tbl.Lookup(inds...)
+	isPad := make([]frontend.Variable, len(in))
+	for i := range sextets {
+		isPad[i] = 0
+		if e.padding && i >= len(in)-2 {
+			// only the last two characters may be padding. The padding
+			// characters decode to zero.
+			isPad[i] = api.IsZero(api.Sub(sextets[i], padValue))
+			sextets[i] = api.Sub(sextets[i], api.Mul(isPad[i], padValue))
+		}
+		rc.Check(sextets[i], 6)
+	}
+	length = n
+	if e.padding {
+		// if the second to last character is padding, then so is the last.
+		api.AssertIsEqual(api.Mul(isPad[len(in)-2], api.Sub(1, isPad[len(in)-1])), 0)
+		length = api.Sub(n, isPad[len(in)-2], isPad[len(in)-1])
+	}
+
+	out = make([]uints.U8, 0, n)
+	for i := 0; i < len(in); i += 4 {
+		chunk := sextets[i:min(i+4, len(in))]
+		// s0 | s1_hi(2) ; s1_lo(4) | s2_hi(4) ; s2_lo(2) | s3
+		s1lo, s1hi := bitslice.Partition(api, chunk[1], 4, bitslice.WithNbDigits(6))
+		out = append(out, uints.U8{Val: api.Add(api.Mul(chunk[0], 1<<2), s1hi)})
+		if len(chunk) == 2 {
+			// the unused bits of the last character must be zero.
+			api.AssertIsEqual(s1lo, 0)
+			break
+		}
+		s2lo, s2hi := bitslice.Partition(api, chunk[2], 2, bitslice.WithNbDigits(6))
+		b1 := api.Add(api.Mul(s1lo, 1<<4), s2hi)
+		if len(chunk) == 3 {
+			api.AssertIsEqual(s2lo, 0)
+			out = append(out, uints.U8{Val: b1})
+			break
+		}
+		b2 := api.Add(api.Mul(s2lo, 1<<6), chunk[3])
+		if e.padding && i+4 == len(in) {
+			// the decoded bytes corresponding to the padding must be zero.
+			api.AssertIsEqual(api.Mul(isPad[i+2], b1), 0)
+			api.AssertIsEqual(api.Mul(isPad[i+3], b2), 0)
+		}
+		out = append(out, uints.U8{Val: b1}, uints.U8{Val: b2})
+	}
+	return out, length
+}
+
+func (e *Encoding) decodeChar(c byte) int {
+	if e.padding && c == '=' {
+		return padValue
+	}
+	for i := 0; i < len(e.alphabet); i++ {
+		if e.alphabet[i] == c {
+			return i
+		}
+	}
+	return invalid
+}
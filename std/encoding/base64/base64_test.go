@@ -0,0 +1,89 @@
+package base64
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type decodeCircuit struct {
+	enc      *Encoding
+	In       []uints.U8
+	Expected []uints.U8
+	Length   frontend.Variable
+}
+
+func (c *decodeCircuit) Define(api frontend.API) error {
+	res, length := c.enc.Decode(api, c.In)
+	if len(res) != len(c.Expected) {
+		panic("length mismatch")
+	}
+	for i := range res {
+		api.AssertIsEqual(res[i].Val, c.Expected[i].Val)
+	}
+	api.AssertIsEqual(length, c.Length)
+	return nil
+}
+
+func TestDecode(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, v := range []struct {
+		enc    *Encoding
+		stdEnc *base64.Encoding
+	}{
+		{StdEncoding, base64.StdEncoding},
+		{URLEncoding, base64.URLEncoding},
+		{RawStdEncoding, base64.RawStdEncoding},
+		{RawURLEncoding, base64.RawURLEncoding},
+	} {
+		for _, data := range []string{"f", "fo", "foo", "foob", "fooba", "foobar", "\xfb\xff\xbf?>"} {
+			in := v.stdEnc.EncodeToString([]byte(data))
+			expected := make([]byte, v.enc.DecodedLen(len(in)))
+			copy(expected, data)
+			circuit := &decodeCircuit{enc: v.enc, In: make([]uints.U8, len(in)), Expected: make([]uints.U8, len(expected))}
+			assignment := &decodeCircuit{In: uints.NewU8Array([]byte(in)), Expected: uints.NewU8Array(expected), Length: len(data)}
+			err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+			assert.NoError(err, in)
+		}
+	}
+}
+
+type decodeOnlyCircuit struct {
+	enc *Encoding
+	In  []uints.U8
+}
+
+func (c *decodeOnlyCircuit) Define(api frontend.API) error {
+	c.enc.Decode(api, c.In)
+	return nil
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, v := range []struct {
+		enc     *Encoding
+		in      string
+		isValid bool
+	}{
+		{StdEncoding, "Zm9vZg==", true},
+		{StdEncoding, "Zm9=Zg==", false}, // padding in the middle
+		{StdEncoding, "Zm=v", false},     // padding followed by data
+		{StdEncoding, "Zh==", false},     // non-zero padding bits
+		{RawStdEncoding, "Zh", false},    // non-zero padding bits
+		{RawURLEncoding, "-_8", true},
+		{RawURLEncoding, "+/8", false}, // invalid alphabet
+		{StdEncoding, "-_8=", false},   // invalid alphabet
+	} {
+		circuit := &decodeOnlyCircuit{enc: v.enc, In: make([]uints.U8, len(v.in))}
+		err := test.IsSolved(circuit, &decodeOnlyCircuit{In: uints.NewU8Array([]byte(v.in))}, ecc.BN254.ScalarField())
+		if v.isValid {
+			assert.NoError(err, v.in)
+		} else {
+			assert.Error(err, v.in)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package hex implements in-circuit hexadecimal decoding.
+//
+// Both lower and upper case characters are accepted. Every decoded byte
+// consumes two input characters, the first one encoding the most significant
+// nibble.
+package hex
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// invalid is the table value for characters not in the alphabet. It does not
+// fit into a nibble and thus fails the range check.
+const invalid = 0xff
+
+// Decode decodes the hexadecimal input and returns the decoded bytes. It
+// asserts that all the input characters are valid hexadecimal digits. It
+// panics if the length of the input is odd.
+func Decode(api frontend.API, in []uints.U8) []uints.U8 {
+	if len(in)%2 != 0 {
+		panic(fmt.Sprintf("odd input length %d", len(in)))
+	}
+	if len(in) == 0 {
+		return nil
+	}
+	rc := rangecheck.New(api)
+	tbl := logderivlookup.New(api)
+	for c := 0; c < 256; c++ {
+		tbl.Insert(decodeChar(byte(c)))
+	}
+	inds := make([]frontend.Variable, len(in))
+	for i := range in {
+		// the characters must be range checked as otherwise the lookup could
+		// be out of the table.
+		rc.Check(in[i].Val, 8)
+		inds[i] = in[i].Val
+	}
+	nibbles := tbl.Lookup(inds...)
+	ret := make([]uints.U8, len(in)/2)
+	for i := range ret {
+		rc.Check(nibbles[2*i], 4)
+		rc.Check(nibbles[2*i+1], 4)
+		ret[i] = uints.U8{Val: api.Add(api.Mul(nibbles[2*i], 16), nibbles[2*i+1])}
+	}
+	return ret
+}
+
+func decodeChar(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'f':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'F':
+		return int(c-'A') + 10
+	}
+	return invalid
+}
@@ -0,0 +1,38 @@
+package hex
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type decodeCircuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *decodeCircuit) Define(api frontend.API) error {
+	res := Decode(api, c.In)
+	for i := range res {
+		api.AssertIsEqual(res[i].Val, c.Expected[i].Val)
+	}
+	return nil
+}
+
+func TestDecode(t *testing.T) {
+	assert := test.NewAssert(t)
+	expected := []byte{0x00, 0x12, 0xab, 0xcd, 0xef, 0xff}
+	for _, in := range []string{hex.EncodeToString(expected), "0012ABcDEFff"} {
+		circuit := &decodeCircuit{In: make([]uints.U8, len(in)), Expected: make([]uints.U8, len(expected))}
+		err := test.IsSolved(circuit, &decodeCircuit{In: uints.NewU8Array([]byte(in)), Expected: uints.NewU8Array(expected)}, ecc.BN254.ScalarField())
+		assert.NoError(err, in)
+	}
+	in := "0012abcdeg00"
+	circuit := &decodeCircuit{In: make([]uints.U8, len(in)), Expected: make([]uints.U8, len(expected))}
+	err := test.IsSolved(circuit, &decodeCircuit{In: uints.NewU8Array([]byte(in)), Expected: uints.NewU8Array([]byte{0x00, 0x12, 0xab, 0xcd, 0xe0, 0x00})}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,272 @@
+// Package json implements in-circuit extraction of values from JSON documents.
+//
+// Given a JSON document as a slice of bytes and a key path fixed at circuit
+// definition time, the gadget proves the value of the key at the path. This
+// allows for example to prove claims from signed JSON Web Tokens when combined
+// with signature verification of the document.
+//
+// The document is scanned byte by byte using a small lexer automaton stored in
+// a lookup table. The lexer tracks whether we are inside a string and whether
+// the current character is escaped, so that structural characters inside
+// strings (including escaped quotes) are not mistaken. Alongside, we track the
+// nesting depth and the number of path keys matched so far along the current
+// nesting chain. A key at depth d is compared against the next path key only if
+// all the previous path keys have been matched in the enclosing objects.
+//
+// The gadget does not validate the full JSON grammar. It assumes that the
+// document is well-formed, which is the case for example for documents signed
+// by a trusted issuer. For well-formed documents, the extraction is sound. The
+// gadget asserts that the key path appears exactly once and that the value is a
+// string or a primitive (number, boolean or null).
+//
+// The keys in the path are compared byte by byte against the raw keys in the
+// document, so they have to be given in their JSON-escaped form. Similarly,
+// string values are returned in their raw escaped form without the enclosing
+// quotes.
+package json
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// lexer states
+const (
+	lexOut = iota // outside of strings
+	lexStr        // inside a string
+	lexEsc        // inside a string after a backslash
+	nbLexStates
+)
+
+// lexer events. Every consumed character triggers at most one event.
+const (
+	evOpen     = iota // '{' or '[' outside of strings
+	evClose           // '}' or ']' outside of strings
+	evColon           // ':' outside of strings
+	evComma           // ',' outside of strings
+	evStrOpen         // opening quote
+	evStrClose        // closing quote
+	evContent         // character inside a string, including escapes
+	evScalar          // non-whitespace character of a primitive
+	nbEvents
+)
+
+// endOfKey is the key table value after the last byte of the key. It is not a
+// valid byte so that it never compares equal to the input.
+const endOfKey = 256
+
+// Value is an extracted JSON value.
+type Value struct {
+	// Bytes are the bytes of the value. Only the first Length bytes are
+	// defined, the rest are zero.
+	Bytes []uints.U8
+	// Length is the number of bytes of the value.
+	Length frontend.Variable
+	// IsString is 1 if the value is a string and 0 otherwise.
+	IsString frontend.Variable
+}
+
+// ExtractValue returns the value of the key at path in the JSON document. The
+// returned value has maxLen bytes, the actual length of the value is stored in
+// [Value.Length]. It asserts that the path appears exactly once in the document
+// and that the value is not longer than maxLen.
+func ExtractValue(api frontend.API, doc []uints.U8, path []string, maxLen int) Value {
+	if len(path) == 0 {
+		panic("empty path")
+	}
+	if len(doc) == 0 {
+		panic("empty document")
+	}
+	rc := rangecheck.New(api)
+	lexTbl, evTbl := lexerTables(api)
+	keyTbl, keyLen := keyTable(api, path)
+	nbPath := len(path)
+
+	var (
+		lex      frontend.Variable = lexOut
+		depth    frontend.Variable = 0
+		matched  frontend.Variable = 0
+		pending  frontend.Variable = 0
+		ok       frontend.Variable = 0
+		k        frontend.Variable = 0
+		found    frontend.Variable = 0
+		isString frontend.Variable = 0
+		nbStarts frontend.Variable = 0
+		start    frontend.Variable = 0
+		length   frontend.Variable = 0
+		prevCapt frontend.Variable = 0
+	)
+	for i := range doc {
+		// the bytes must be range checked as otherwise the lookups could be
+		// out of the tables.
+		rc.Check(doc[i].Val, 8)
+		lexIdx := api.Add(api.Mul(lex, 256), doc[i].Val)
+		res := lexTbl.Lookup(lexIdx)
+		evCode := evTbl.Lookup(lexIdx)[0]
+		ev := bits.ToBinary(api, evCode, bits.WithNbDigits(nbEvents))
+		lex = res[0]
+		isWs := api.Sub(1, api.Add(ev[0], ev[1], ev[2:]...))
+
+		isKeyLevel := api.IsZero(api.Sub(depth, matched, 1))
+		isDone := api.IsZero(api.Sub(matched, nbPath))
+		isValueLevel := api.IsZero(api.Sub(depth, matched))
+
+		// compare the current string against the next key in the path
+		keyByte := keyTbl.Lookup(api.Add(api.Mul(matched, keyLen), k))[0]
+		okEq := api.Mul(ok, api.IsZero(api.Sub(keyByte, doc[i].Val)))
+		okEnd := api.Mul(ok, api.IsZero(api.Sub(keyByte, endOfKey)))
+		colonMatch := api.Mul(ev[evColon], pending)
+		// the value of the last matched key ends at a comma or at the closing
+		// of the enclosing object.
+		drop := api.Mul(api.Add(ev[evComma], ev[evClose]), isValueLevel, api.Sub(1, api.IsZero(matched)))
+
+		// capture the bytes of the value of the last key in the path
+		isFinal := api.Mul(isDone, isValueLevel)
+		captured := api.Mul(isFinal, api.Add(ev[evContent], ev[evScalar]))
+		// the value must not be an object or an array
+		api.AssertIsEqual(api.Mul(isFinal, ev[evOpen]), 0)
+		isString = api.Add(isString, api.Mul(isFinal, ev[evStrOpen]))
+		isStart := api.Mul(captured, api.Sub(1, prevCapt))
+		nbStarts = api.Add(nbStarts, isStart)
+		start = api.Add(start, api.Mul(isStart, i))
+		length = api.Add(length, captured)
+		prevCapt = captured
+
+		found = api.Add(found, api.Mul(colonMatch, api.IsZero(api.Sub(matched, nbPath-1))))
+		ok = api.Add(api.Mul(ev[evStrOpen], isKeyLevel, api.Sub(1, isDone)), api.Mul(ev[evContent], okEq))
+		k = api.Mul(api.Sub(1, ev[evStrOpen]), api.Add(k, api.Mul(ev[evContent], okEq)))
+		pending = api.Add(api.Mul(ev[evStrClose], okEnd), api.Mul(isWs, pending))
+		matched = api.Sub(api.Add(matched, colonMatch), drop)
+		depth = api.Sub(api.Add(depth, ev[evOpen]), ev[evClose])
+	}
+	api.AssertIsEqual(found, 1)
+	api.AssertIsBoolean(nbStarts)
+	api.AssertIsBoolean(isString)
+
+	// copy the value bytes out of the document
+	docTbl := logderivlookup.New(api)
+	for i := range doc {
+		docTbl.Insert(doc[i].Val)
+	}
+	for i := 0; i < maxLen; i++ {
+		docTbl.Insert(0)
+	}
+	inds := make([]frontend.Variable, maxLen)
+	for i := range inds {
+		inds[i] = api.Add(start, i)
+	}
+	vals := docTbl.Lookup(inds...)
+	ret := Value{Bytes: make([]uints.U8, maxLen), Length: length, IsString: isString}
+	// inRange is 1 while i < length. It becomes 0 when i == length and we
+	// assert that it happens exactly once, i.e. length <= maxLen.
+	var inRange frontend.Variable = 1
+	for i := 0; i < maxLen; i++ {
+		inRange = api.Sub(inRange, api.IsZero(api.Sub(length, i)))
+		ret.Bytes[i] = uints.U8{Val: api.Mul(inRange, vals[i])}
+	}
+	api.AssertIsEqual(api.Sub(inRange, api.IsZero(api.Sub(length, maxLen))), 0)
+	return ret
+}
+
+// ParseUint returns the integer value of an unsigned decimal number value. It
+// asserts that the value is not a string and that all its bytes are decimal
+// digits. It panics if the maximal length of the value is too large and the
+// result could overflow the native field.
+func ParseUint(api frontend.API, v Value) frontend.Variable {
+	if 4*len(v.Bytes) >= api.Compiler().FieldBitLen() {
+		panic(fmt.Sprintf("maximal value length %d may overflow", len(v.Bytes)))
+	}
+	api.AssertIsEqual(v.IsString, 0)
+	rc := rangecheck.New(api)
+	var inRange frontend.Variable = 1
+	var ret frontend.Variable = 0
+	for i := range v.Bytes {
+		inRange = api.Sub(inRange, api.IsZero(api.Sub(v.Length, i)))
+		digit := api.Sub(v.Bytes[i].Val, api.Mul(inRange, '0'))
+		// outside of range the bytes are zero, so the digit is zero.
+		rc.Check(api.Add(digit, 6), 4)
+		rc.Check(digit, 4)
+		ret = api.Select(inRange, api.Add(api.Mul(ret, 10), digit), ret)
+	}
+	api.AssertIsEqual(api.IsZero(v.Length), 0)
+	return ret
+}
+
+// lexerTables returns the lookup tables for the lexer. Both tables are indexed
+// by state*256+byte. The first table returns the next lexer state, the second
+// the event bitmask.
+func lexerTables(api frontend.API) (next, events *logderivlookup.Table) {
+	next = logderivlookup.New(api)
+	events = logderivlookup.New(api)
+	for s := 0; s < nbLexStates; s++ {
+		for c := 0; c < 256; c++ {
+			n, e := lexStep(s, byte(c))
+			next.Insert(n)
+			if e >= 0 {
+				events.Insert(1 << e)
+			} else {
+				events.Insert(0)
+			}
+		}
+	}
+	return next, events
+}
+
+// lexStep returns the next lexer state and the event for the character c
+// in state s. The event is -1 for whitespace.
+func lexStep(s int, c byte) (next, event int) {
+	switch s {
+	case lexStr:
+		switch c {
+		case '"':
+			return lexOut, evStrClose
+		case '\\':
+			return lexEsc, evContent
+		}
+		return lexStr, evContent
+	case lexEsc:
+		return lexStr, evContent
+	}
+	switch c {
+	case '{', '[':
+		return lexOut, evOpen
+	case '}', ']':
+		return lexOut, evClose
+	case ':':
+		return lexOut, evColon
+	case ',':
+		return lexOut, evComma
+	case '"':
+		return lexStr, evStrOpen
+	case ' ', '\t', '\n', '\r':
+		return lexOut, -1
+	}
+	return lexOut, evScalar
+}
+
+// keyTable returns the lookup table for the path keys indexed by
+// matched*keyLen+k. Positions after the end of the key contain endOfKey.
+func keyTable(api frontend.API, path []string) (tbl *logderivlookup.Table, keyLen int) {
+	for i := range path {
+		keyLen = max(keyLen, len(path[i]))
+	}
+	// one more position for the end marker
+	keyLen++
+	tbl = logderivlookup.New(api)
+	// add an additional all-end row for when all keys are matched
+	for i := 0; i <= len(path); i++ {
+		for j := 0; j < keyLen; j++ {
+			if i < len(path) && j < len(path[i]) {
+				tbl.Insert(path[i][j])
+			} else {
+				tbl.Insert(endOfKey)
+			}
+		}
+	}
+	return tbl, keyLen
+}
@@ -0,0 +1,98 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type extractCircuit struct {
+	path     []string
+	Doc      []uints.U8
+	Expected []uints.U8
+	Length   frontend.Variable
+	IsString frontend.Variable
+}
+
+func (c *extractCircuit) Define(api frontend.API) error {
+	v := ExtractValue(api, c.Doc, c.path, len(c.Expected))
+	for i := range v.Bytes {
+		api.AssertIsEqual(v.Bytes[i].Val, c.Expected[i].Val)
+	}
+	api.AssertIsEqual(v.Length, c.Length)
+	api.AssertIsEqual(v.IsString, c.IsString)
+	return nil
+}
+
+func checkExtract(t *testing.T, doc string, path []string, expected string, isString int) error {
+	const maxLen = 16
+	if len(expected) > maxLen {
+		t.Fatal("expected value too long")
+	}
+	circuit := &extractCircuit{path: path, Doc: make([]uints.U8, len(doc)), Expected: make([]uints.U8, maxLen)}
+	padded := make([]byte, maxLen)
+	copy(padded, expected)
+	assignment := &extractCircuit{
+		Doc:      uints.NewU8Array([]byte(doc)),
+		Expected: uints.NewU8Array(padded),
+		Length:   len(expected),
+		IsString: isString,
+	}
+	return test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+}
+
+func TestExtractValue(t *testing.T) {
+	assert := test.NewAssert(t)
+	doc := `{"sub": "alice", "aud":["x","sub"], "n": {"sub":"bob\"s", "x": {"sub": 1}, "exp" :1700000000 }, "e":"", "t": true}`
+	for _, v := range []struct {
+		path     []string
+		expected string
+		isString int
+	}{
+		{[]string{"sub"}, "alice", 1},
+		{[]string{"n", "sub"}, `bob\"s`, 1},
+		{[]string{"n", "exp"}, "1700000000", 0},
+		{[]string{"n", "x", "sub"}, "1", 0},
+		{[]string{"e"}, "", 1},
+		{[]string{"t"}, "true", 0},
+	} {
+		assert.NoError(checkExtract(t, doc, v.path, v.expected, v.isString), v.path)
+	}
+	// wrong value
+	assert.Error(checkExtract(t, doc, []string{"sub"}, "bob", 1))
+	// the value is an object
+	assert.Error(checkExtract(t, doc, []string{"n"}, "", 0))
+	// missing key
+	assert.Error(checkExtract(t, doc, []string{"x"}, "", 0))
+	// key is not directly in the object
+	assert.Error(checkExtract(t, doc, []string{"n", "x", "exp"}, "1700000000", 0))
+	// the key inside a string is not a key
+	assert.Error(checkExtract(t, `{"a": "\"b\": 1", "c": 2}`, []string{"b"}, "1", 0))
+	// duplicate keys
+	assert.Error(checkExtract(t, `{"a": 1, "a": 1}`, []string{"a"}, "1", 0))
+}
+
+type parseUintCircuit struct {
+	Doc      []uints.U8
+	Expected frontend.Variable
+}
+
+func (c *parseUintCircuit) Define(api frontend.API) error {
+	v := ExtractValue(api, c.Doc, []string{"exp"}, 12)
+	api.AssertIsEqual(ParseUint(api, v), c.Expected)
+	return nil
+}
+
+func TestParseUint(t *testing.T) {
+	assert := test.NewAssert(t)
+	doc := `{"iat":1600000000,"exp":1700000000}`
+	circuit := &parseUintCircuit{Doc: make([]uints.U8, len(doc))}
+	err := test.IsSolved(circuit, &parseUintCircuit{Doc: uints.NewU8Array([]byte(doc)), Expected: 1700000000}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	doc = `{"iat":1600000000,"exp":17000e0000}`
+	err = test.IsSolved(circuit, &parseUintCircuit{Doc: uints.NewU8Array([]byte(doc)), Expected: 1700000000}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
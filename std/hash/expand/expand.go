@@ -0,0 +1,97 @@
+// Package expand implements the message expansion functions of [RFC 9380].
+//
+// The message expansion is the first step of hashing to a field or to an
+// elliptic curve. It expands a message and a domain separation tag into a
+// uniformly random byte string of requested length, which is then interpreted
+// as field elements. The packages [github.com/consensys/gnark/std/algebra/emulated/sw_emulated]
+// and [github.com/consensys/gnark/std/algebra/native/twistededwards] use it for
+// hashing to curves.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+package expand
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// bInBytes is the output size of SHA-256
+	bInBytes = 32
+	// sInBytes is the input block size of SHA-256
+	sInBytes = 64
+)
+
+// ExpandMsgXmd expands the message msg into lenInBytes bytes using the domain
+// separation tag dst as defined in [RFC 9380 Section 5.3.1]. The underlying
+// hash function is SHA-256.
+//
+// The domain separation tag is fixed at circuit definition time. The method
+// returns an error if lenInBytes is too large or the tag is longer than 255
+// bytes.
+//
+// [RFC 9380 Section 5.3.1]: https://www.rfc-editor.org/rfc/rfc9380.html#section-5.3.1
+func ExpandMsgXmd(api frontend.API, msg []uints.U8, dst []byte, lenInBytes int) ([]uints.U8, error) {
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 || lenInBytes <= 0 {
+		return nil, errors.New("invalid output length")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("domain separation tag longer than 255 bytes")
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, err
+	}
+	dstPrime := uints.NewU8Array(append(append([]byte{}, dst...), byte(len(dst))))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	b0, err := sum(api,
+		uints.NewU8Array(make([]byte, sInBytes)),
+		msg,
+		uints.NewU8Array([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0}),
+		dstPrime)
+	if err != nil {
+		return nil, err
+	}
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	bi, err := sum(api, b0, []uints.U8{uints.NewU8(1)}, dstPrime)
+	if err != nil {
+		return nil, err
+	}
+	res := append([]uints.U8{}, bi...)
+	// b_i = H(strxor(b_0, b_(i-1)) || I2OSP(i, 1) || DST_prime)
+	for i := 2; i <= ell; i++ {
+		if bi, err = sum(api, xor(uapi, b0, bi), []uints.U8{uints.NewU8(uint8(i))}, dstPrime); err != nil {
+			return nil, err
+		}
+		res = append(res, bi...)
+	}
+	return res[:lenInBytes], nil
+}
+
+// sum returns the SHA-256 digest of the concatenation of the inputs.
+func sum(api frontend.API, in ...[]uints.U8) ([]uints.U8, error) {
+	h, err := sha2.New(api)
+	if err != nil {
+		return nil, err
+	}
+	for i := range in {
+		h.Write(in[i])
+	}
+	return h.Sum(), nil
+}
+
+// xor returns the bytewise XOR of a and b. The lengths of the inputs must be
+// multiples of four.
+func xor(uapi *uints.BinaryField[uints.U32], a, b []uints.U8) []uints.U8 {
+	res := make([]uints.U8, 0, len(a))
+	for i := 0; i < len(a); i += 4 {
+		x := uapi.Xor(uapi.PackMSB(a[i:i+4]...), uapi.PackMSB(b[i:i+4]...))
+		res = append(res, uapi.UnpackMSB(x)...)
+	}
+	return res
+}
@@ -0,0 +1,43 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type expandCircuit struct {
+	Msg      []uints.U8
+	Expected []uints.U8
+	dst      []byte
+}
+
+func (c *expandCircuit) Define(api frontend.API) error {
+	res, err := ExpandMsgXmd(api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		api.AssertIsEqual(res[i].Val, c.Expected[i].Val)
+	}
+	return nil
+}
+
+func TestExpandMsgXmd(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+		for _, l := range []int{32, 48, 128} {
+			expected, err := hash.ExpandMsgXmd([]byte(msg), dst, l)
+			assert.NoError(err)
+			circuit := expandCircuit{Msg: make([]uints.U8, len(msg)), Expected: make([]uints.U8, l), dst: dst}
+			witness := expandCircuit{Msg: uints.NewU8Array([]byte(msg)), Expected: uints.NewU8Array(expected)}
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err, msg, l)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package pedersen
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/twistededwards"
+	edwards "github.com/consensys/gnark/std/algebra/native/twistededwards"
+)
+
+// chunkBits is the number of message bits encoded in a single chunk.
+const chunkBits = 3
+
+// HashBits computes the Pedersen hash of the message bits out-of-circuit and
+// returns the coordinates of the resulting point. Every element of msg must be
+// 0 or 1. It is the counterpart of [Hasher.WriteBits] and [Hasher.SumPoint].
+func HashBits(id twistededwards.ID, personalization string, msg []uint8) (x, y *big.Int, err error) {
+	c, err := newNativeCurve(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range msg {
+		if msg[i] > 1 {
+			return nil, nil, fmt.Errorf("message element %d is not a bit", i)
+		}
+	}
+	segLen := segmentChunks(c.params)
+	res := c.identity()
+	for i, seg := 0, 0; i < len(msg); seg++ {
+		g, err := c.generator(personalization, seg)
+		if err != nil {
+			return nil, nil, err
+		}
+		// ⟨M⟩ = Σ enc(m_j)*2^(4j)
+		scalar := new(big.Int)
+		for j := 0; j < segLen && i < len(msg); j, i = j+1, i+chunkBits {
+			var s [chunkBits]uint8
+			copy(s[:], msg[i:min(i+chunkBits, len(msg))])
+			enc := big.NewInt(int64(1 + s[0] + 2*s[1]))
+			if s[2] == 1 {
+				enc.Neg(enc)
+			}
+			scalar.Add(scalar, enc.Lsh(enc, uint(4*j)))
+		}
+		res = c.add(res, c.scalarMul(g, scalar))
+	}
+	return res[0], res[1], nil
+}
+
+// Hash computes the Pedersen hash of the field elements out-of-circuit and
+// returns the x-coordinate of the resulting point. Every element is encoded
+// in little-endian order on the bit length of the SNARK field. It is the
+// counterpart of [Hasher.Write] and [Hasher.Sum].
+func Hash(id twistededwards.ID, personalization string, data ...*big.Int) (*big.Int, error) {
+	field, err := edwards.GetSnarkField(id)
+	if err != nil {
+		return nil, err
+	}
+	var msg []uint8
+	for i := range data {
+		if data[i].Sign() < 0 || data[i].Cmp(field) >= 0 {
+			return nil, fmt.Errorf("input %d not in field", i)
+		}
+		for j := 0; j < field.BitLen(); j++ {
+			msg = append(msg, uint8(data[i].Bit(j)))
+		}
+	}
+	x, _, err := HashBits(id, personalization, msg)
+	return x, err
+}
+
+// segmentChunks returns the maximal number of chunks c in a segment so that
+// the encoded segment is in the range [-(r-1)/2, (r-1)/2] where r is the order
+// of the prime subgroup. This guarantees that distinct segments map to
+// distinct scalars.
+func segmentChunks(params *edwards.CurveParams) int {
+	bound := new(big.Int).Rsh(params.Order, 1)
+	for c := 1; ; c++ {
+		// max ⟨M⟩ = 4 * (2^(4c) - 1) / 15
+		m := new(big.Int).Lsh(big.NewInt(1), uint(4*c))
+		m.Sub(m, big.NewInt(1)).Div(m, big.NewInt(15)).Lsh(m, 2)
+		if m.Cmp(bound) > 0 {
+			return c - 1
+		}
+	}
+}
+
+// nativeCurve implements twisted Edwards arithmetic on big integers.
+type nativeCurve struct {
+	params *edwards.CurveParams
+	p      *big.Int
+}
+
+func newNativeCurve(id twistededwards.ID) (*nativeCurve, error) {
+	params, err := edwards.GetCurveParams(id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := edwards.GetSnarkField(id)
+	if err != nil {
+		return nil, err
+	}
+	return &nativeCurve{params: params, p: p}, nil
+}
+
+func (c *nativeCurve) identity() [2]*big.Int {
+	return [2]*big.Int{big.NewInt(0), big.NewInt(1)}
+}
+
+// add returns p+q using the complete addition formulas.
+func (c *nativeCurve) add(p, q [2]*big.Int) [2]*big.Int {
+	x1y2 := new(big.Int).Mul(p[0], q[1])
+	y1x2 := new(big.Int).Mul(p[1], q[0])
+	x1x2 := new(big.Int).Mul(p[0], q[0])
+	y1y2 := new(big.Int).Mul(p[1], q[1])
+	// t = d*x1*x2*y1*y2
+	t := new(big.Int).Mul(x1x2, y1y2)
+	t.Mul(t, c.params.D).Mod(t, c.p)
+	// x3 = (x1y2 + y1x2) / (1 + t)
+	x3 := new(big.Int).Add(x1y2, y1x2)
+	den := new(big.Int).Add(big.NewInt(1), t)
+	x3.Mul(x3, den.ModInverse(den.Mod(den, c.p), c.p)).Mod(x3, c.p)
+	// y3 = (y1y2 - a x1x2) / (1 - t)
+	y3 := new(big.Int).Mul(c.params.A, x1x2)
+	y3.Sub(y1y2, y3)
+	den = new(big.Int).Sub(big.NewInt(1), t)
+	y3.Mul(y3, den.ModInverse(den.Mod(den, c.p), c.p)).Mod(y3, c.p)
+	return [2]*big.Int{x3, y3}
+}
+
+// scalarMul returns [s]p for a possibly negative scalar s.
+func (c *nativeCurve) scalarMul(p [2]*big.Int, s *big.Int) [2]*big.Int {
+	res := c.identity()
+	abs := new(big.Int).Abs(s)
+	for i := abs.BitLen() - 1; i >= 0; i-- {
+		res = c.add(res, res)
+		if abs.Bit(i) == 1 {
+			res = c.add(res, p)
+		}
+	}
+	if s.Sign() < 0 {
+		res[0].Neg(res[0]).Mod(res[0], c.p)
+	}
+	return res
+}
+
+// generator derives the generator of the segment seg. The y-coordinate
+// candidates are obtained by hashing the personalization, the segment index and
+// a counter with SHA-256. The first candidate on the curve is multiplied by the
+// cofactor and accepted if it is not the identity. The x-coordinate is chosen
+// to be even. This is not the group hash of Zcash Sapling, see the package
+// documentation.
+func (c *nativeCurve) generator(personalization string, seg int) ([2]*big.Int, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha256.New()
+		h.Write([]byte(personalization))
+		var buf [5]byte
+		binary.BigEndian.PutUint32(buf[:4], uint32(seg))
+		buf[4] = byte(ctr)
+		h.Write(buf[:])
+		y := new(big.Int).SetBytes(h.Sum(nil))
+		y.Mod(y, c.p)
+		// x² = (1 - y²) / (a - d*y²)
+		yy := new(big.Int).Mul(y, y)
+		num := new(big.Int).Sub(big.NewInt(1), yy)
+		den := new(big.Int).Mul(c.params.D, yy)
+		den.Sub(c.params.A, den).Mod(den, c.p)
+		if den.Sign() == 0 {
+			continue
+		}
+		xx := new(big.Int).ModInverse(den, c.p)
+		xx.Mul(xx, num).Mod(xx, c.p)
+		x := new(big.Int).ModSqrt(xx, c.p)
+		if x == nil {
+			continue
+		}
+		if x.Bit(0) == 1 {
+			x.Sub(c.p, x)
+		}
+		g := c.scalarMul([2]*big.Int{x, y}, c.params.Cofactor)
+		if g[0].Sign() == 0 {
+			continue
+		}
+		return g, nil
+	}
+	return [2]*big.Int{}, fmt.Errorf("no generator found for segment %d", seg)
+}
@@ -0,0 +1,146 @@
+// Package pedersen implements the windowed Pedersen hash on native twisted
+// Edwards curves.
+//
+// The construction follows the Pedersen hash of Zcash Sapling. The message
+// bits are split into chunks of 3 bits (s0, s1, s2) which are encoded as
+//
+//	enc(s0, s1, s2) = (1 - 2*s2) * (1 + s0 + 2*s1)
+//
+// and the chunks are grouped into segments of c chunks. For every segment i the
+// encoded chunks define the scalar ⟨M_i⟩ = Σ_j enc(m_j)*2^(4j) and the hash is
+// the point Σ_i [⟨M_i⟩]G_i, where G_i are independent generators of the prime
+// order subgroup. The segment length c is the largest such that the scalars
+// do not wrap around the subgroup order. The generators are derived
+// deterministically from a personalization string, so that hashes with
+// different personalizations are independent.
+//
+// NB! Only the message encoding follows Sapling. The generators are obtained by
+// hashing the personalization with SHA-256 and trying successive counters
+// until a point on the curve is found, and not with the BLAKE2s group hash of
+// Zcash. Thus, even on Jubjub with the personalization "Zcash_PH", the hashes
+// differ from the Sapling Pedersen hashes and can't be checked against the
+// Zcash test vectors.
+//
+// In-circuit, every chunk costs a 2-bit lookup of a precomputed point, a
+// conditional negation and a point addition. The out-of-circuit counterparts
+// [Hash] and [HashBits] can be used to compute the hashes for witness
+// generation and test vectors.
+//
+// NB! The Pedersen hash is collision resistant only for messages of fixed
+// length. When hashing variable length messages, the length has to be encoded
+// in the message or in the personalization.
+package pedersen
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	edwards "github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// Hasher computes the Pedersen hash in-circuit. It implements
+// [hash.FieldHasher].
+type Hasher struct {
+	api             frontend.API
+	curve           edwards.Curve
+	native          *nativeCurve
+	personalization string
+	segLen          int
+	// tables[i][j] are the points [k*2^(4j)]G_i for k = 1..4.
+	tables [][][4][2]*big.Int
+	data   []frontend.Variable
+}
+
+var _ hash.FieldHasher = (*Hasher)(nil)
+
+// New returns a new Pedersen hasher on the twisted Edwards curve id with the
+// given personalization. The curve must be defined over the native field.
+func New(api frontend.API, id twistededwards.ID, personalization string) (*Hasher, error) {
+	curve, err := edwards.NewEdCurve(api, id)
+	if err != nil {
+		return nil, err
+	}
+	native, err := newNativeCurve(id)
+	if err != nil {
+		return nil, err
+	}
+	return &Hasher{
+		api:             api,
+		curve:           curve,
+		native:          native,
+		personalization: personalization,
+		segLen:          segmentChunks(native.params),
+	}, nil
+}
+
+// Write adds the field elements to the message. Every element is decomposed
+// into bits in little-endian order on the bit length of the native field.
+func (h *Hasher) Write(data ...frontend.Variable) {
+	for i := range data {
+		h.data = append(h.data, bits.ToBinary(h.api, data[i])...)
+	}
+}
+
+// WriteBits adds the bits to the message. The method constrains the inputs to
+// be boolean.
+func (h *Hasher) WriteBits(data ...frontend.Variable) {
+	for i := range data {
+		h.api.AssertIsBoolean(data[i])
+	}
+	h.data = append(h.data, data...)
+}
+
+// Reset empties the message.
+func (h *Hasher) Reset() {
+	h.data = nil
+}
+
+// Sum returns the x-coordinate of the hash of the message.
+func (h *Hasher) Sum() frontend.Variable {
+	return h.SumPoint().X
+}
+
+// SumPoint returns the hash of the message as a curve point. It does not reset
+// the message.
+func (h *Hasher) SumPoint() edwards.Point {
+	api := h.api
+	res := edwards.Point{X: 0, Y: 1}
+	for i, seg := 0, 0; i < len(h.data); seg++ {
+		tbl := h.table(seg)
+		for j := 0; j < h.segLen && i < len(h.data); j, i = j+1, i+chunkBits {
+			s := [chunkBits]frontend.Variable{0, 0, 0}
+			copy(s[:], h.data[i:min(i+chunkBits, len(h.data))])
+			x := api.Lookup2(s[0], s[1], tbl[j][0][0], tbl[j][1][0], tbl[j][2][0], tbl[j][3][0])
+			y := api.Lookup2(s[0], s[1], tbl[j][0][1], tbl[j][1][1], tbl[j][2][1], tbl[j][3][1])
+			// negation on twisted Edwards curves negates the x-coordinate
+			x = api.Mul(x, api.Sub(1, api.Mul(s[2], 2)))
+			res = h.curve.Add(res, edwards.Point{X: x, Y: y})
+		}
+	}
+	return res
+}
+
+// table returns the precomputed chunk points of the segment seg.
+func (h *Hasher) table(seg int) [][4][2]*big.Int {
+	for len(h.tables) <= seg {
+		g, err := h.native.generator(h.personalization, len(h.tables))
+		if err != nil {
+			panic(err)
+		}
+		tbl := make([][4][2]*big.Int, h.segLen)
+		for j := range tbl {
+			tbl[j][0] = g
+			for k := 1; k < 4; k++ {
+				tbl[j][k] = h.native.add(tbl[j][k-1], g)
+			}
+			// next chunk generator [2^4]g
+			g = h.native.add(tbl[j][3], tbl[j][3])
+			g = h.native.add(g, g)
+		}
+		h.tables = append(h.tables, tbl)
+	}
+	return h.tables[seg]
+}
@@ -0,0 +1,127 @@
+package pedersen
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	edwards "github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+const testPersonalization = "gnark_pedersen_test"
+
+var testCurves = []twistededwards.ID{twistededwards.BN254, twistededwards.BLS12_381, twistededwards.BLS12_381_BANDERSNATCH}
+
+type pedersenCircuit struct {
+	id       twistededwards.ID
+	Data     []frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *pedersenCircuit) Define(api frontend.API) error {
+	h, err := New(api, c.id, testPersonalization)
+	if err != nil {
+		return err
+	}
+	h.Write(c.Data...)
+	api.AssertIsEqual(h.Sum(), c.Expected)
+	return nil
+}
+
+func TestPedersen(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, id := range testCurves {
+		field, err := edwards.GetSnarkField(id)
+		assert.NoError(err)
+		for _, nbInputs := range []int{1, 3} {
+			data := make([]*big.Int, nbInputs)
+			witness := pedersenCircuit{Data: make([]frontend.Variable, nbInputs)}
+			for i := range data {
+				data[i], err = rand.Int(rand.Reader, field)
+				assert.NoError(err)
+				witness.Data[i] = data[i]
+			}
+			expected, err := Hash(id, testPersonalization, data...)
+			assert.NoError(err)
+			witness.Expected = expected
+			err = test.IsSolved(&pedersenCircuit{id: id, Data: make([]frontend.Variable, nbInputs)}, &witness, field)
+			assert.NoError(err)
+			witness.Expected = new(big.Int).Add(expected, big.NewInt(1))
+			err = test.IsSolved(&pedersenCircuit{id: id, Data: make([]frontend.Variable, nbInputs)}, &witness, field)
+			assert.Error(err)
+		}
+	}
+}
+
+type pedersenBitsCircuit struct {
+	Msg  []frontend.Variable
+	X, Y frontend.Variable
+}
+
+func (c *pedersenBitsCircuit) Define(api frontend.API) error {
+	h, err := New(api, twistededwards.BN254, testPersonalization)
+	if err != nil {
+		return err
+	}
+	h.WriteBits(c.Msg...)
+	res := h.SumPoint()
+	api.AssertIsEqual(res.X, c.X)
+	api.AssertIsEqual(res.Y, c.Y)
+	return nil
+}
+
+func TestPedersenBits(t *testing.T) {
+	assert := test.NewAssert(t)
+	field, err := edwards.GetSnarkField(twistededwards.BN254)
+	assert.NoError(err)
+	// lengths which are not multiples of the chunk size
+	for _, l := range []int{0, 1, 5, 200} {
+		msg := make([]uint8, l)
+		witness := pedersenBitsCircuit{Msg: make([]frontend.Variable, l)}
+		for i := range msg {
+			msg[i] = uint8(i*7%5) & 1
+			witness.Msg[i] = msg[i]
+		}
+		x, y, err := HashBits(twistededwards.BN254, testPersonalization, msg)
+		assert.NoError(err)
+		witness.X, witness.Y = x, y
+		err = test.IsSolved(&pedersenBitsCircuit{Msg: make([]frontend.Variable, l)}, &witness, field)
+		assert.NoError(err, l)
+	}
+}
+
+func TestPersonalization(t *testing.T) {
+	assert := test.NewAssert(t)
+	a, err := Hash(twistededwards.BN254, "a", big.NewInt(42))
+	assert.NoError(err)
+	b, err := Hash(twistededwards.BN254, "b", big.NewInt(42))
+	assert.NoError(err)
+	assert.NotEqual(a.String(), b.String())
+}
+
+func TestGenerators(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, id := range testCurves {
+		c, err := newNativeCurve(id)
+		assert.NoError(err)
+		for seg := 0; seg < 4; seg++ {
+			g, err := c.generator(testPersonalization, seg)
+			assert.NoError(err)
+			// a*x² + y² = 1 + d*x²*y²
+			xx := new(big.Int).Mul(g[0], g[0])
+			yy := new(big.Int).Mul(g[1], g[1])
+			lhs := new(big.Int).Mul(c.params.A, xx)
+			lhs.Add(lhs, yy).Mod(lhs, c.p)
+			rhs := new(big.Int).Mul(c.params.D, xx)
+			rhs.Mul(rhs, yy).Add(rhs, big.NewInt(1)).Mod(rhs, c.p)
+			assert.Equal(0, lhs.Cmp(rhs))
+			// the generator is in the prime order subgroup
+			o := c.scalarMul(g, c.params.Order)
+			assert.Equal(0, o[0].Sign())
+			assert.Equal(0, o[1].Cmp(big.NewInt(1)))
+		}
+	}
+}
@@ -4,14 +4,18 @@ import (
 	"sync"
 
 	"github.com/consensys/gnark/constraint/solver"
+	emfields_bls12377 "github.com/consensys/gnark/std/algebra/emulated/fields_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bw6633"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bw6761"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls24315"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/algebra/native/sw_grumpkin"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
 	"github.com/consensys/gnark/std/evmprecompiles"
 	"github.com/consensys/gnark/std/internal/logderivarg"
 	"github.com/consensys/gnark/std/math/bits"
@@ -43,9 +47,11 @@ func registerHints() {
 	solver.RegisterHint(logderivarg.GetHints()...)
 	solver.RegisterHint(bitslice.GetHints()...)
 	// emulated fields
+	solver.RegisterHint(emfields_bls12377.GetHints()...)
 	solver.RegisterHint(fields_bls12381.GetHints()...)
 	solver.RegisterHint(fields_bn254.GetHints()...)
 	solver.RegisterHint(fields_bw6761.GetHints()...)
+	solver.RegisterHint(fields_bw6633.GetHints()...)
 	// native fields
 	solver.RegisterHint(fields_bls12377.GetHints()...)
 	solver.RegisterHint(fields_bls24315.GetHints()...)
@@ -54,6 +60,8 @@ func registerHints() {
 	// native curves
 	solver.RegisterHint(sw_bls12377.GetHints()...)
 	solver.RegisterHint(sw_bls24315.GetHints()...)
+	solver.RegisterHint(sw_grumpkin.GetHints()...)
+	solver.RegisterHint(twistededwards.GetHints()...)
 }
 
 func init() {
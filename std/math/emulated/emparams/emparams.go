@@ -37,6 +37,12 @@ func (sixLimbPrimeField) NbLimbs() uint     { return 6 }
 func (sixLimbPrimeField) BitsPerLimb() uint { return 64 }
 func (sixLimbPrimeField) IsPrime() bool     { return true }
 
+type tenLimbPrimeField struct{}
+
+func (tenLimbPrimeField) NbLimbs() uint     { return 10 }
+func (tenLimbPrimeField) BitsPerLimb() uint { return 64 }
+func (tenLimbPrimeField) IsPrime() bool     { return true }
+
 type twelveLimbPrimeField struct{}
 
 func (twelveLimbPrimeField) NbLimbs() uint     { return 12 }
@@ -254,6 +260,34 @@ type BW6761Fr struct{ sixLimbPrimeField }
 
 func (fp BW6761Fr) Modulus() *big.Int { return ecc.BW6_761.ScalarField() }
 
+// BW6633Fp provides type parametrization for field emulation:
+//   - limbs: 10
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x126633cc0f35f63fc1a174f01d72ab5a8fcd8c75d79d2c74e59769ad9bbda2f8152a6c0fadea490b8da9f5e83f57c497e0e8850edbda407d7b5ce7ab839c2253d369bd31147f73cd74916ea4570000d (base 16)
+//	20494478644167774678813387386538961497669590920908778075528754551012016751717791778743535050360001387419576570244406805463255765034468441182772056330021723098661967429339971741066259394985997 (base 10)
+//
+// This is the base field of the BW6-633 curve.
+type BW6633Fp struct{ tenLimbPrimeField }
+
+func (fp BW6633Fp) Modulus() *big.Int { return ecc.BW6_633.BaseField() }
+
+// BW6633Fr provides type parametrization for field emulation:
+//   - limbs: 5
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x4c23a02b586d650d3f7498be97c5eafdec1d01aa27a1ae0421ee5da52bde5026fe802ff40300001 (base 16)
+//	39705142709513438335025689890408969744933502416914749335064285505637884093126342347073617133569 (base 10)
+//
+// This is the scalar field of the BW6-633 curve.
+type BW6633Fr struct{ fiveLimbPrimeField }
+
+func (fr BW6633Fr) Modulus() *big.Int { return ecc.BW6_633.ScalarField() }
+
 // BLS24315Fp provides type parametrization for field emulation:
 //   - limbs: 5
 //   - limb width: 64 bits
@@ -0,0 +1,57 @@
+package emulated
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+)
+
+// Table is a lookup table of emulated elements. It is implemented using one
+// [logderivlookup.Table] per limb, so that a lookup costs a constant number of
+// constraints per limb independently of the number of entries in the table.
+//
+// The table is meant for selecting many times from a fixed set of elements
+// (for example precomputed multiples of a point). For a single selection
+// [Field.Mux] is cheaper as it doesn't need to commit to the table.
+type Table[T FieldParams] struct {
+	f     *Field[T]
+	limbs []*logderivlookup.Table
+}
+
+// NewTable returns a new empty lookup table of emulated elements.
+func (f *Field[T]) NewTable() *Table[T] {
+	limbs := make([]*logderivlookup.Table, f.fParams.NbLimbs())
+	for i := range limbs {
+		limbs[i] = logderivlookup.New(f.api)
+	}
+	return &Table[T]{f: f, limbs: limbs}
+}
+
+// Insert reduces e and inserts it into the table. It returns the index of the
+// inserted element. It doesn't modify e.
+func (t *Table[T]) Insert(e *Element[T]) (index int) {
+	r := t.f.Reduce(e)
+	for i := range t.limbs {
+		if i < len(r.Limbs) {
+			index = t.limbs[i].Insert(r.Limbs[i])
+		} else {
+			index = t.limbs[i].Insert(0)
+		}
+	}
+	return index
+}
+
+// Lookup returns the elements at the given indices in the table. It fails if
+// any of the indices is not smaller than the number of inserted elements.
+func (t *Table[T]) Lookup(inds ...frontend.Variable) []*Element[T] {
+	res := make([]*Element[T], len(inds))
+	for i := range res {
+		res[i] = t.f.newInternalElement(make([]frontend.Variable, len(t.limbs)), 0)
+	}
+	for j := range t.limbs {
+		vals := t.limbs[j].Lookup(inds...)
+		for i := range vals {
+			res[i].Limbs[j] = vals[i]
+		}
+	}
+	return res
+}
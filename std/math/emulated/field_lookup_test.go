@@ -0,0 +1,80 @@
+package emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type lookupCircuit[T FieldParams] struct {
+	Entries  []Element[T]
+	Queries  []frontend.Variable
+	Expected []Element[T]
+}
+
+func (c *lookupCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	t := f.NewTable()
+	for i := range c.Entries {
+		// insert non-reduced elements to check that the table reduces them
+		t.Insert(f.Add(&c.Entries[i], &c.Entries[i]))
+	}
+	res := t.Lookup(c.Queries...)
+	for i := range res {
+		f.AssertIsEqual(res[i], f.MulConst(&c.Expected[i], big.NewInt(2)))
+	}
+	return nil
+}
+
+func testLookup[T FieldParams](t *testing.T) {
+	assert := test.NewAssert(t)
+	var fp T
+	nbEntries, nbQueries := 8, 5
+	circuit := lookupCircuit[T]{
+		Entries:  make([]Element[T], nbEntries),
+		Queries:  make([]frontend.Variable, nbQueries),
+		Expected: make([]Element[T], nbQueries),
+	}
+	entries := make([]*big.Int, nbEntries)
+	witness := lookupCircuit[T]{
+		Entries:  make([]Element[T], nbEntries),
+		Queries:  make([]frontend.Variable, nbQueries),
+		Expected: make([]Element[T], nbQueries),
+	}
+	for i := range entries {
+		entries[i], _ = rand.Int(rand.Reader, fp.Modulus())
+		witness.Entries[i] = ValueOf[T](entries[i])
+	}
+	for i := range witness.Queries {
+		q, _ := rand.Int(rand.Reader, big.NewInt(int64(nbEntries)))
+		witness.Queries[i] = q
+		witness.Expected[i] = ValueOf[T](entries[q.Int64()])
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong result
+	q0 := witness.Queries[0].(*big.Int).Int64()
+	witness.Expected[0] = ValueOf[T](new(big.Int).Add(entries[q0], big.NewInt(1)))
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// out of range query
+	witness.Expected[0] = ValueOf[T](entries[q0])
+	witness.Queries[0] = nbEntries
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestLookup(t *testing.T) {
+	testLookup[Goldilocks](t)
+	testLookup[Secp256k1Fp](t)
+	testLookup[BN254Fp](t)
+}
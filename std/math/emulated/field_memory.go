@@ -0,0 +1,79 @@
+package emulated
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/memory"
+)
+
+// Memory is a read-write memory of emulated elements where every address
+// stores a tuple of elements of fixed length. It is implemented using a single
+// [memory.Memory] storing the limbs of the tuples, so that an access costs a
+// constant number of constraints per limb independently of the size of the
+// memory.
+//
+// Differently from [Table], the stored elements can be modified at variable
+// addresses. The consistency check is deferred until the end of the circuit
+// definition, see [memory.New].
+type Memory[T FieldParams] struct {
+	f     *Field[T]
+	width int
+	mem   *memory.Memory
+}
+
+// NewMemory returns a new memory initialized with the tuples of elements
+// initial. The size of the memory is fixed to len(initial) and all the tuples
+// must have the same length. It doesn't modify the inputs.
+func (f *Field[T]) NewMemory(initial [][]*Element[T]) *Memory[T] {
+	if len(initial) == 0 {
+		panic("memory must not be empty")
+	}
+	width := len(initial[0])
+	cells := make([][]frontend.Variable, len(initial))
+	for i := range initial {
+		if len(initial[i]) != width {
+			panic("memory tuples must have the same length")
+		}
+		cells[i] = f.memoryLimbs(initial[i])
+	}
+	return &Memory[T]{f: f, width: width, mem: memory.NewTuples(f.api, cells)}
+}
+
+// Read returns the tuple of elements stored at address addr. The circuit is not
+// satisfiable if addr is not smaller than the size of the memory.
+func (m *Memory[T]) Read(addr frontend.Variable) []*Element[T] {
+	limbs := m.mem.ReadTuple(addr)
+	nbLimbs := int(m.f.fParams.NbLimbs())
+	res := make([]*Element[T], m.width)
+	for i := range res {
+		res[i] = m.f.newInternalElement(limbs[i*nbLimbs:(i+1)*nbLimbs], 0)
+	}
+	return res
+}
+
+// Write reduces the elements of the tuple e and stores it at address addr. It
+// doesn't modify e. The circuit is not satisfiable if addr is not smaller than
+// the size of the memory.
+func (m *Memory[T]) Write(addr frontend.Variable, e []*Element[T]) {
+	if len(e) != m.width {
+		panic("tuple length mismatch")
+	}
+	m.mem.WriteTuple(addr, m.f.memoryLimbs(e))
+}
+
+// memoryLimbs returns the limbs of the reduced elements e, padded to the
+// number of limbs of the field.
+func (f *Field[T]) memoryLimbs(e []*Element[T]) []frontend.Variable {
+	nbLimbs := int(f.fParams.NbLimbs())
+	res := make([]frontend.Variable, 0, len(e)*nbLimbs)
+	for i := range e {
+		r := f.Reduce(e[i])
+		for j := 0; j < nbLimbs; j++ {
+			if j < len(r.Limbs) {
+				res = append(res, r.Limbs[j])
+			} else {
+				res = append(res, 0)
+			}
+		}
+	}
+	return res
+}
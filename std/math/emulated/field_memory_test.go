@@ -0,0 +1,68 @@
+package emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type memoryCircuit[T FieldParams] struct {
+	Initial  [4][2]Element[T]
+	Addrs    [2]frontend.Variable
+	Expected [2]Element[T]
+}
+
+func (c *memoryCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	initial := make([][]*Element[T], len(c.Initial))
+	for i := range c.Initial {
+		initial[i] = []*Element[T]{&c.Initial[i][0], &c.Initial[i][1]}
+	}
+	m := f.NewMemory(initial)
+	// swap and multiply the components of the tuple at Addrs[0] and store the
+	// result at Addrs[1]
+	a := m.Read(c.Addrs[0])
+	m.Write(c.Addrs[1], []*Element[T]{f.Mul(a[1], a[1]), f.Add(a[0], a[0])})
+	res := m.Read(c.Addrs[1])
+	f.AssertIsEqual(res[0], &c.Expected[0])
+	f.AssertIsEqual(res[1], &c.Expected[1])
+	return nil
+}
+
+func testMemory[T FieldParams](t *testing.T) {
+	assert := test.NewAssert(t)
+	var fp T
+	var circuit, witness memoryCircuit[T]
+	var vals [4][2]*big.Int
+	for i := range vals {
+		for j := range vals[i] {
+			vals[i][j], _ = rand.Int(rand.Reader, fp.Modulus())
+			witness.Initial[i][j] = ValueOf[T](vals[i][j])
+		}
+	}
+	witness.Addrs = [2]frontend.Variable{1, 3}
+	sq := new(big.Int).Mul(vals[1][1], vals[1][1])
+	witness.Expected[0] = ValueOf[T](sq.Mod(sq, fp.Modulus()))
+	dbl := new(big.Int).Lsh(vals[1][0], 1)
+	witness.Expected[1] = ValueOf[T](dbl.Mod(dbl, fp.Modulus()))
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the value before the write
+	witness.Expected[0] = ValueOf[T](vals[3][0])
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestMemory(t *testing.T) {
+	testMemory[Goldilocks](t)
+	testMemory[Secp256k1Fp](t)
+	testMemory[BN254Fp](t)
+}
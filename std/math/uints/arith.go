@@ -0,0 +1,245 @@
+package uints
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Arithmetic over long integers is performed on limbs. A limb packs several
+// consecutive bytes into a single native element so that the products of two
+// limbs together with the accumulated carries still fit into the native field.
+// The results are then decomposed back into range checked bytes.
+
+// AddWithCarry returns the sum a+b+carryIn modulo 2^len(T) and the carry out
+// of the most significant byte. It asserts that the input carry is boolean.
+func (bf *BinaryField[T]) AddWithCarry(a, b T, carryIn frontend.Variable) (sum T, carryOut frontend.Variable) {
+	bf.api.AssertIsBoolean(carryIn)
+	return bf.addCarry(carryIn, a, b)
+}
+
+// SubWithBorrow returns the difference a-b-borrowIn modulo 2^len(T) and the
+// borrow out of the most significant byte. It asserts that the input borrow is
+// boolean. The output borrow is 1 if a < b+borrowIn and 0 otherwise.
+func (bf *BinaryField[T]) SubWithBorrow(a, b T, borrowIn frontend.Variable) (diff T, borrowOut frontend.Variable) {
+	bf.api.AssertIsBoolean(borrowIn)
+	lw := bf.limbBytes()
+	la, lb := bf.toLimbs(a, lw), bf.toLimbs(b, lw)
+	base := bf.limbBase(lw)
+	borrow := borrowIn
+	var ret T
+	for i := range la {
+		// v = a_i - b_i - borrow + 2^w is in range [0, 2^(w+1))
+		v := bf.api.Add(bf.api.Sub(la[i], lb[i], borrow), base)
+		bts, h := bf.split(v, lw, 1)
+		for j := range bts {
+			ret[i*lw+j] = bts[j]
+		}
+		borrow = bf.api.Sub(1, h)
+	}
+	return ret, borrow
+}
+
+// Sub returns the difference a-b modulo 2^len(T).
+func (bf *BinaryField[T]) Sub(a, b T) T {
+	res, _ := bf.SubWithBorrow(a, b, 0)
+	return res
+}
+
+// Mul returns the product a*b modulo 2^len(T).
+func (bf *BinaryField[T]) Mul(a, b T) T {
+	lw := bf.limbBytes()
+	la, lb := bf.toLimbs(a, lw), bf.toLimbs(b, lw)
+	bts, _ := bf.mulLimbs(la, lb, nil, len(la))
+	var ret T
+	for i := range bts {
+		ret[i] = bts[i]
+	}
+	return ret
+}
+
+// MulFull returns the full product a*b as a pair of low and high halves.
+func (bf *BinaryField[T]) MulFull(a, b T) (lo, hi T) {
+	lw := bf.limbBytes()
+	la, lb := bf.toLimbs(a, lw), bf.toLimbs(b, lw)
+	bts, _ := bf.mulLimbs(la, lb, nil, 2*len(la))
+	for i := 0; i < len(lo); i++ {
+		lo[i] = bts[i]
+		hi[i] = bts[len(lo)+i]
+	}
+	return lo, hi
+}
+
+// DivMod returns the quotient and remainder of a divided by b. The method
+// asserts that b is non-zero.
+func (bf *BinaryField[T]) DivMod(a, b T) (q, r T) {
+	nbBytes := bf.lenBts()
+	hintIn := make([]frontend.Variable, 1+2*nbBytes)
+	hintIn[0] = nbBytes
+	for i := 0; i < nbBytes; i++ {
+		hintIn[1+i] = a[i].Val
+		hintIn[1+nbBytes+i] = b[i].Val
+	}
+	res, err := bf.api.Compiler().NewHint(divModHint, 2*nbBytes, hintIn...)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < nbBytes; i++ {
+		q[i] = bf.ByteValueOf(res[i])
+		r[i] = bf.ByteValueOf(res[nbBytes+i])
+	}
+	// q*b + r = a without overflowing
+	lw := bf.limbBytes()
+	bts, carry := bf.mulLimbs(bf.toLimbs(q, lw), bf.toLimbs(b, lw), bf.toLimbs(r, lw), 2*(nbBytes/lw))
+	for i := 0; i < nbBytes; i++ {
+		bf.ByteAssertEq(bts[i], a[i])
+		bf.api.AssertIsEqual(bts[nbBytes+i].Val, 0)
+	}
+	bf.api.AssertIsEqual(carry, 0)
+	// r < b. This also implies that b is non-zero.
+	bf.api.AssertIsEqual(bf.IsLess(r, b), 1)
+	return q, r
+}
+
+// IsZero returns 1 if a is zero and 0 otherwise.
+func (bf *BinaryField[T]) IsZero(a T) frontend.Variable {
+	// all bytes are range checked and non-negative, so the sum cannot overflow
+	// and is zero only if all the bytes are zero.
+	vals := make([]frontend.Variable, bf.lenBts())
+	for i := range vals {
+		vals[i] = a[i].Val
+	}
+	if len(vals) == 1 {
+		return bf.api.IsZero(vals[0])
+	}
+	return bf.api.IsZero(bf.api.Add(vals[0], vals[1], vals[2:]...))
+}
+
+// IsEqual returns 1 if a and b are equal and 0 otherwise.
+func (bf *BinaryField[T]) IsEqual(a, b T) frontend.Variable {
+	lw := bf.limbBytes()
+	la, lb := bf.toLimbs(a, lw), bf.toLimbs(b, lw)
+	ret := frontend.Variable(1)
+	for i := range la {
+		ret = bf.api.And(ret, bf.api.IsZero(bf.api.Sub(la[i], lb[i])))
+	}
+	return ret
+}
+
+// IsLess returns 1 if a < b and 0 otherwise.
+func (bf *BinaryField[T]) IsLess(a, b T) frontend.Variable {
+	_, borrow := bf.SubWithBorrow(a, b, 0)
+	return borrow
+}
+
+// IsLessOrEqual returns 1 if a <= b and 0 otherwise.
+func (bf *BinaryField[T]) IsLessOrEqual(a, b T) frontend.Variable {
+	return bf.api.Sub(1, bf.IsLess(b, a))
+}
+
+// addCarry returns the sum of carryIn and inputs modulo 2^len(T) and the carry
+// out of the most significant byte.
+func (bf *BinaryField[T]) addCarry(carryIn frontend.Variable, a ...T) (T, frontend.Variable) {
+	if len(a) == 0 {
+		panic("zero-length input")
+	}
+	lw := bf.limbBytes()
+	limbs := make([][]frontend.Variable, len(a))
+	for i := range a {
+		limbs[i] = bf.toLimbs(a[i], lw)
+	}
+	// every column is at most len(a)*(2^w-1)+carry and the carry is at most
+	// len(a).
+	carryBits := bits.Len(uint(len(a)))
+	carry := carryIn
+	var ret T
+	for i := range limbs[0] {
+		v := carry
+		for j := range limbs {
+			v = bf.api.Add(v, limbs[j][i])
+		}
+		var bts []U8
+		bts, carry = bf.split(v, lw, carryBits)
+		for j := range bts {
+			ret[i*lw+j] = bts[j]
+		}
+	}
+	return ret, carry
+}
+
+// mulLimbs computes the product of a and b given as limbs and adds the
+// optional addend c. It returns the first nbLimbs limbs of the result as bytes
+// and the remaining carry.
+func (bf *BinaryField[T]) mulLimbs(a, b, c []frontend.Variable, nbLimbs int) ([]U8, frontend.Variable) {
+	lw := bf.limbBytes()
+	// a single column is a sum of at most len(a) products of two limbs and
+	// the addend. The carry from the previous column is at most one bit longer
+	// than a limb times the number of products.
+	colBits := 16*lw + bits.Len(uint(len(a))) + 1
+	carryBits := colBits + 1 - 8*lw
+	ret := make([]U8, 0, nbLimbs*lw)
+	carry := frontend.Variable(0)
+	for k := 0; k < nbLimbs; k++ {
+		v := carry
+		for i := 0; i < len(a); i++ {
+			if j := k - i; j >= 0 && j < len(b) {
+				v = bf.api.Add(v, bf.api.Mul(a[i], b[j]))
+			}
+		}
+		if k < len(c) {
+			v = bf.api.Add(v, c[k])
+		}
+		var bts []U8
+		bts, carry = bf.split(v, lw, carryBits)
+		ret = append(ret, bts...)
+	}
+	return ret, carry
+}
+
+// split decomposes v into nbBytes range checked bytes and a carry of at most
+// carryBits bits such that v = Σ bytes[i]*2^(8i) + 2^(8*nbBytes)*carry.
+func (bf *BinaryField[T]) split(v frontend.Variable, nbBytes, carryBits int) ([]U8, frontend.Variable) {
+	res, err := bf.api.Compiler().NewHint(splitBytesHint, nbBytes+1, nbBytes, v)
+	if err != nil {
+		panic(err)
+	}
+	bts := make([]U8, nbBytes)
+	composed := make([]frontend.Variable, nbBytes+1)
+	for i := range bts {
+		bts[i] = bf.ByteValueOf(res[i])
+		composed[i] = bf.api.Mul(bts[i].Val, bf.limbBase(i))
+	}
+	bf.rchecker.Check(res[nbBytes], carryBits)
+	composed[nbBytes] = bf.api.Mul(res[nbBytes], bf.limbBase(nbBytes))
+	bf.api.AssertIsEqual(bf.api.Add(composed[0], composed[1], composed[2:]...), v)
+	return bts, res[nbBytes]
+}
+
+// limbBytes returns the number of bytes packed into a single limb.
+func (bf *BinaryField[T]) limbBytes() int {
+	n := bf.lenBts()
+	for lw := min(n, 8); lw > 1; lw /= 2 {
+		if 16*lw+bits.Len(uint(n/lw))+3 < bf.api.Compiler().FieldBitLen() {
+			return lw
+		}
+	}
+	return 1
+}
+
+// toLimbs packs the bytes of a into limbs of lw bytes.
+func (bf *BinaryField[T]) toLimbs(a T, lw int) []frontend.Variable {
+	ret := make([]frontend.Variable, bf.lenBts()/lw)
+	for i := range ret {
+		ret[i] = a[i*lw].Val
+		for j := 1; j < lw; j++ {
+			ret[i] = bf.api.Add(ret[i], bf.api.Mul(a[i*lw+j].Val, bf.limbBase(j)))
+		}
+	}
+	return ret
+}
+
+// limbBase returns 2^(8*nbBytes).
+func (bf *BinaryField[T]) limbBase(nbBytes int) frontend.Variable {
+	return new(big.Int).Lsh(big.NewInt(1), uint(8*nbBytes))
+}
@@ -0,0 +1,197 @@
+package uints
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type arithCircuit[T Long] struct {
+	A, B                  T
+	Sum, Diff, Prod       T
+	ProdHi                T
+	Quo, Rem              T
+	Carry, Borrow, IsLess frontend.Variable
+	IsEqual               frontend.Variable
+}
+
+func (c *arithCircuit[T]) Define(api frontend.API) error {
+	uapi, err := New[T](api)
+	if err != nil {
+		return err
+	}
+	sum, carry := uapi.AddWithCarry(c.A, c.B, 0)
+	uapi.AssertEq(sum, c.Sum)
+	api.AssertIsEqual(carry, c.Carry)
+	diff, borrow := uapi.SubWithBorrow(c.A, c.B, 0)
+	uapi.AssertEq(diff, c.Diff)
+	api.AssertIsEqual(borrow, c.Borrow)
+	uapi.AssertEq(uapi.Mul(c.A, c.B), c.Prod)
+	lo, hi := uapi.MulFull(c.A, c.B)
+	uapi.AssertEq(lo, c.Prod)
+	uapi.AssertEq(hi, c.ProdHi)
+	q, r := uapi.DivMod(c.A, c.B)
+	uapi.AssertEq(q, c.Quo)
+	uapi.AssertEq(r, c.Rem)
+	api.AssertIsEqual(uapi.IsLess(c.A, c.B), c.IsLess)
+	api.AssertIsEqual(uapi.IsEqual(c.A, c.B), c.IsEqual)
+	api.AssertIsEqual(uapi.IsLessOrEqual(c.A, c.A), 1)
+	api.AssertIsEqual(uapi.IsZero(uapi.Sub(c.A, c.A)), 1)
+	return nil
+}
+
+func newArithAssignment[T Long](nbBytes int, a, b *big.Int, conv func(*big.Int) T) *arithCircuit[T] {
+	modulus := new(big.Int).Lsh(big.NewInt(1), uint(8*nbBytes))
+	sum := new(big.Int).Add(a, b)
+	carry := 0
+	if sum.Cmp(modulus) >= 0 {
+		carry = 1
+	}
+	diff := new(big.Int).Sub(a, b)
+	borrow, isLess := 0, 0
+	if diff.Sign() < 0 {
+		borrow, isLess = 1, 1
+	}
+	isEqual := 0
+	if a.Cmp(b) == 0 {
+		isEqual = 1
+	}
+	prod := new(big.Int).Mul(a, b)
+	prodHi, prodLo := new(big.Int).QuoRem(prod, modulus, new(big.Int))
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	return &arithCircuit[T]{
+		A:       conv(a),
+		B:       conv(b),
+		Sum:     conv(sum.Mod(sum, modulus)),
+		Diff:    conv(diff.Mod(diff, modulus)),
+		Prod:    conv(prodLo),
+		ProdHi:  conv(prodHi),
+		Quo:     conv(q),
+		Rem:     conv(r),
+		Carry:   carry,
+		Borrow:  borrow,
+		IsLess:  isLess,
+		IsEqual: isEqual,
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	assert := test.NewAssert(t)
+	u16 := func(v *big.Int) U16 { return NewU16(uint16(v.Uint64())) }
+	u64 := func(v *big.Int) U64 { return NewU64(v.Uint64()) }
+	for _, v := range [][2]uint64{{0x1234, 0x56}, {0x56, 0x1234}, {0xffff, 0xffff}, {0xabcd, 1}} {
+		a, b := new(big.Int).SetUint64(v[0]), new(big.Int).SetUint64(v[1])
+		err := test.IsSolved(&arithCircuit[U16]{}, newArithAssignment(2, a, b, u16), ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+	for _, v := range [][2]uint64{{0x123456789abcdef0, 0x1234567}, {3, ^uint64(0)}, {^uint64(0), ^uint64(0)}} {
+		a, b := new(big.Int).SetUint64(v[0]), new(big.Int).SetUint64(v[1])
+		err := test.IsSolved(&arithCircuit[U64]{}, newArithAssignment(8, a, b, u64), ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+	max256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	a, _ := new(big.Int).SetString("f0e1d2c3b4a5968778695a4b3c2d1e0f00112233445566778899aabbccddeeff", 16)
+	b, _ := new(big.Int).SetString("123456789abcdef0fedcba9876543210", 16)
+	for _, v := range [][2]*big.Int{{a, b}, {b, a}, {max256, max256}, {max256, big.NewInt(7)}} {
+		err := test.IsSolved(&arithCircuit[U256]{}, newArithAssignment(32, v[0], v[1], NewU256), ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type divModWrongCircuit struct {
+	A, B, Quo, Rem U128
+}
+
+func (c *divModWrongCircuit) Define(api frontend.API) error {
+	uapi, err := New[U128](api)
+	if err != nil {
+		return err
+	}
+	q, r := uapi.DivMod(c.A, c.B)
+	uapi.AssertEq(q, c.Quo)
+	uapi.AssertEq(r, c.Rem)
+	return nil
+}
+
+func TestDivModByZero(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&divModWrongCircuit{}, &divModWrongCircuit{
+		A:   NewU128(big.NewInt(10)),
+		B:   NewU128(big.NewInt(0)),
+		Quo: NewU128(big.NewInt(0)),
+		Rem: NewU128(big.NewInt(0)),
+	}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type carryInCircuit struct {
+	A, B, Sum U64
+	CarryIn   frontend.Variable
+}
+
+func (c *carryInCircuit) Define(api frontend.API) error {
+	uapi, err := New[U64](api)
+	if err != nil {
+		return err
+	}
+	sum, _ := uapi.AddWithCarry(c.A, c.B, c.CarryIn)
+	uapi.AssertEq(sum, c.Sum)
+	diff, _ := uapi.SubWithBorrow(c.Sum, c.B, c.CarryIn)
+	uapi.AssertEq(diff, c.A)
+	return nil
+}
+
+func TestCarryInIsBoolean(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&carryInCircuit{}, &carryInCircuit{A: NewU64(10), B: NewU64(20), Sum: NewU64(31), CarryIn: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&carryInCircuit{}, &carryInCircuit{A: NewU64(10), B: NewU64(20), Sum: NewU64(32), CarryIn: 2}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type lshiftCircuit struct {
+	In, Expected U32
+	Shift        int
+}
+
+func (c *lshiftCircuit) Define(api frontend.API) error {
+	uapi, err := New[U32](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.Lshift(c.In, c.Shift)
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+func TestLshift(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, shift := range []int{0, 3, 4, 8, 11, 16, 31, 32} {
+		err := test.IsSolved(&lshiftCircuit{Shift: shift}, &lshiftCircuit{Shift: shift, In: NewU32(0x12345678), Expected: NewU32(uint32(uint64(0x12345678) << shift))}, ecc.BN254.ScalarField())
+		assert.NoError(err, shift)
+	}
+}
+
+type orCircuit struct {
+	In       [2]U64
+	Expected U64
+}
+
+func (c *orCircuit) Define(api frontend.API) error {
+	uapi, err := New[U64](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.Or(c.In[0], c.In[1])
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+func TestOr(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&orCircuit{}, &orCircuit{In: [2]U64{NewU64(0x1234567800ff00ff), NewU64(0x0f0f0f0f0f0f0f0f)}, Expected: NewU64(0x1234567800ff00ff | 0x0f0f0f0f0f0f0f0f)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
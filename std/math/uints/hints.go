@@ -15,7 +15,10 @@ func GetHints() []solver.Hint {
 	return []solver.Hint{
 		andHint,
 		xorHint,
+		orHint,
 		toBytes,
+		splitBytesHint,
+		divModHint,
 	}
 }
 
@@ -29,6 +32,11 @@ func andHint(_ *big.Int, inputs, outputs []*big.Int) error {
 	return nil
 }
 
+func orHint(_ *big.Int, inputs, outputs []*big.Int) error {
+	outputs[0].Or(inputs[0], inputs[1])
+	return nil
+}
+
 func toBytes(m *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 	if len(inputs) != 2 {
 		return fmt.Errorf("input must be 2 elements")
@@ -38,7 +46,7 @@ func toBytes(m *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 	}
 	nbLimbs := int(inputs[0].Uint64())
 	if len(outputs) != nbLimbs {
-		return fmt.Errorf("output must be 8 elements")
+		return fmt.Errorf("output must be %d elements", nbLimbs)
 	}
 	if !inputs[1].IsUint64() {
 		return fmt.Errorf("input must be 64 bits")
@@ -51,3 +59,64 @@ func toBytes(m *big.Int, inputs []*big.Int, outputs []*big.Int) error {
 	}
 	return nil
 }
+
+func splitBytesHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) != 2 {
+		return fmt.Errorf("input must be 2 elements")
+	}
+	if !inputs[0].IsUint64() {
+		return fmt.Errorf("first input must be uint64")
+	}
+	nbBytes := int(inputs[0].Uint64())
+	if len(outputs) != nbBytes+1 {
+		return fmt.Errorf("output must be %d elements", nbBytes+1)
+	}
+	base := big.NewInt(1 << 8)
+	tmp := new(big.Int).Set(inputs[1])
+	for i := 0; i < nbBytes; i++ {
+		outputs[i].Mod(tmp, base)
+		tmp.Rsh(tmp, 8)
+	}
+	outputs[nbBytes].Set(tmp)
+	return nil
+}
+
+func divModHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) == 0 || !inputs[0].IsUint64() {
+		return fmt.Errorf("first input must be uint64")
+	}
+	nbBytes := int(inputs[0].Uint64())
+	if len(inputs) != 1+2*nbBytes {
+		return fmt.Errorf("input must be %d elements", 1+2*nbBytes)
+	}
+	if len(outputs) != 2*nbBytes {
+		return fmt.Errorf("output must be %d elements", 2*nbBytes)
+	}
+	a := recompose(inputs[1 : 1+nbBytes])
+	b := recompose(inputs[1+nbBytes:])
+	if b.Sign() == 0 {
+		return fmt.Errorf("division by zero")
+	}
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	decompose(q, outputs[:nbBytes])
+	decompose(r, outputs[nbBytes:])
+	return nil
+}
+
+func recompose(bts []*big.Int) *big.Int {
+	ret := new(big.Int)
+	for i := len(bts) - 1; i >= 0; i-- {
+		ret.Lsh(ret, 8)
+		ret.Add(ret, bts[i])
+	}
+	return ret
+}
+
+func decompose(v *big.Int, bts []*big.Int) {
+	base := big.NewInt(1 << 8)
+	tmp := new(big.Int).Set(v)
+	for i := range bts {
+		bts[i].Mod(tmp, base)
+		tmp.Rsh(tmp, 8)
+	}
+}
@@ -2,7 +2,9 @@
 //
 // Usually arithmetic in a circuit is performed in the native field, which is of
 // prime order. However, for compatibility with native operations we rely on
-// operating on smaller primitive types as 8-bit, 32-bit and 64-bit integer.
+// operating on smaller primitive types as 8-bit, 16-bit, 32-bit and 64-bit
+// integers and on wide 128-bit and 256-bit integers (as used for example for
+// EVM words).
 // Naively, these operations have to be implemented bitwise as there are no
 // closed equations for boolean operations (XOR, AND, OR).
 //
@@ -11,7 +13,7 @@
 // inefficients circuits.
 //
 // This package performs boolean operations using lookup tables on bytes. So,
-// long integers are split into 4 or 8 bytes and we perform the operations
+// long integers are split into 2 to 32 bytes and we perform the operations
 // bytewise. In the lookup tables, we store results for all possible 2^8×2^8
 // inputs. With this approach, every bytewise operation costs as single lookup,
 // which depending on the backend is relatively cheap (one to three
@@ -24,7 +26,7 @@ package uints
 
 import (
 	"fmt"
-	"math/bits"
+	"math/big"
 
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/internal/logderivprecomp"
@@ -78,16 +80,21 @@ func (e *U8) GnarkInitHook() {
 	}
 }
 
+type U256 [32]U8
+type U128 [16]U8
 type U64 [8]U8
 type U32 [4]U8
+type U16 [2]U8
 
-type Long interface{ U32 | U64 }
+type Long interface {
+	U16 | U32 | U64 | U128 | U256
+}
 
-type BinaryField[T U32 | U64] struct {
-	api        frontend.API
-	xorT, andT *logderivprecomp.Precomputed
-	rchecker   frontend.Rangechecker
-	allOne     U8
+type BinaryField[T Long] struct {
+	api             frontend.API
+	xorT, andT, orT *logderivprecomp.Precomputed
+	rchecker        frontend.Rangechecker
+	allOne          U8
 }
 
 func New[T Long](api frontend.API) (*BinaryField[T], error) {
@@ -99,11 +106,16 @@ func New[T Long](api frontend.API) (*BinaryField[T], error) {
 	if err != nil {
 		return nil, fmt.Errorf("new and table: %w", err)
 	}
+	orT, err := logderivprecomp.New(api, orHint, []uint{8})
+	if err != nil {
+		return nil, fmt.Errorf("new or table: %w", err)
+	}
 	rchecker := rangecheck.New(api)
 	bf := &BinaryField[T]{
 		api:      api,
 		xorT:     xorT,
 		andT:     andT,
+		orT:      orT,
 		rchecker: rchecker,
 	}
 	// TODO: this is const. add way to init constants
@@ -117,6 +129,13 @@ func NewU8(v uint8) U8 {
 	return U8{Val: v, internal: true}
 }
 
+func NewU16(v uint16) U16 {
+	return [2]U8{
+		NewU8(uint8((v >> (0 * 8)) & 0xff)),
+		NewU8(uint8((v >> (1 * 8)) & 0xff)),
+	}
+}
+
 func NewU32(v uint32) U32 {
 	return [4]U8{
 		NewU8(uint8((v >> (0 * 8)) & 0xff)),
@@ -139,6 +158,34 @@ func NewU64(v uint64) U64 {
 	}
 }
 
+// NewU128 returns the little-endian byte decomposition of v. It panics if v
+// is negative or does not fit into 128 bits.
+func NewU128(v *big.Int) U128 {
+	var ret U128
+	copy(ret[:], newWide(v, len(ret)))
+	return ret
+}
+
+// NewU256 returns the little-endian byte decomposition of v. It panics if v
+// is negative or does not fit into 256 bits.
+func NewU256(v *big.Int) U256 {
+	var ret U256
+	copy(ret[:], newWide(v, len(ret)))
+	return ret
+}
+
+func newWide(v *big.Int, nbBytes int) []U8 {
+	if v.Sign() < 0 || v.BitLen() > 8*nbBytes {
+		panic(fmt.Sprintf("value does not fit into %d bytes", nbBytes))
+	}
+	bts := v.FillBytes(make([]byte, nbBytes))
+	ret := make([]U8, nbBytes)
+	for i := range ret {
+		ret[i] = NewU8(bts[nbBytes-i-1])
+	}
+	return ret
+}
+
 func NewU8Array(v []uint8) []U8 {
 	ret := make([]U8, len(v))
 	for i := range v {
@@ -147,6 +194,14 @@ func NewU8Array(v []uint8) []U8 {
 	return ret
 }
 
+func NewU16Array(v []uint16) []U16 {
+	ret := make([]U16, len(v))
+	for i := range v {
+		ret[i] = NewU16(v[i])
+	}
+	return ret
+}
+
 func NewU32Array(v []uint32) []U32 {
 	ret := make([]U32, len(v))
 	for i := range v {
@@ -168,14 +223,25 @@ func (bf *BinaryField[T]) ByteValueOf(a frontend.Variable) U8 {
 	return U8{Val: a, internal: true}
 }
 
+// ValueOf decomposes a into bytes. It panics if the type T is too wide to be
+// represented by a single native element, for example [U256] over the BN254
+// scalar field. The wide integers must then be built from their bytes with
+// [BinaryField.PackLSB].
 func (bf *BinaryField[T]) ValueOf(a frontend.Variable) T {
 	var r T
-	bts, err := bf.api.Compiler().NewHint(toBytes, len(r), len(r), a)
+	bf.assertFitsNative()
+	// the toBytes hint only decomposes 64-bit values, the wider ones also
+	// return the part above len(r) bytes, which is zero for a valid input.
+	hint, nbOutputs := toBytes, len(r)
+	if len(r) > 8 {
+		hint, nbOutputs = splitBytesHint, len(r)+1
+	}
+	bts, err := bf.api.Compiler().NewHint(hint, nbOutputs, len(r), a)
 	if err != nil {
 		panic(err)
 	}
 
-	for i := range bts {
+	for i := 0; i < len(r); i++ {
 		r[i] = bf.ByteValueOf(bts[i])
 	}
 	expectedValue := bf.ToValue(r)
@@ -184,10 +250,17 @@ func (bf *BinaryField[T]) ValueOf(a frontend.Variable) T {
 	return r
 }
 
+// ToValue recomposes the bytes of a into a single native element. It panics if
+// the type T is too wide to be represented by a single native element, see
+// [BinaryField.ValueOf].
 func (bf *BinaryField[T]) ToValue(a T) frontend.Variable {
+	bf.assertFitsNative()
+	if bf.lenBts() == 1 {
+		return a[0].Val
+	}
 	v := make([]frontend.Variable, bf.lenBts())
 	for i := range v {
-		v[i] = bf.api.Mul(a[i].Val, 1<<(i*8))
+		v[i] = bf.api.Mul(a[i].Val, new(big.Int).Lsh(big.NewInt(1), uint(8*i)))
 	}
 	vv := bf.api.Add(v[0], v[1], v[2:]...)
 	return vv
@@ -244,6 +317,7 @@ func (bf *BinaryField[T]) twoArgWideFn(tbl *logderivprecomp.Precomputed, a ...T)
 
 func (bf *BinaryField[T]) And(a ...T) T { return bf.twoArgWideFn(bf.andT, a...) }
 func (bf *BinaryField[T]) Xor(a ...T) T { return bf.twoArgWideFn(bf.xorT, a...) }
+func (bf *BinaryField[T]) Or(a ...T) T  { return bf.twoArgWideFn(bf.orT, a...) }
 
 func (bf *BinaryField[T]) not(a U8) U8 {
 	ret := bf.xorT.Query(a.Val, bf.allOne.Val)
@@ -258,18 +332,9 @@ func (bf *BinaryField[T]) Not(a T) T {
 	return r
 }
 
+// Add returns the sum of the inputs modulo 2^len(T).
 func (bf *BinaryField[T]) Add(a ...T) T {
-	tLen := bf.lenBts() * 8
-	inLen := len(a)
-	va := make([]frontend.Variable, inLen)
-	for i := range a {
-		va[i] = bf.ToValue(a[i])
-	}
-	vres := bf.api.Add(va[0], va[1], va[2:]...)
-	maxBitlen := bits.Len(uint(inLen)) + tLen
-	// bitslice.Partition below checks that the input is less than 2^maxBitlen and that we have omitted carry correctly
-	vreslow, _ := bitslice.Partition(bf.api, vres, uint(tLen), bitslice.WithNbDigits(maxBitlen), bitslice.WithUnconstrainedOutputs())
-	res := bf.ValueOf(vreslow)
+	res, _ := bf.addCarry(0, a...)
 	return res
 }
 
@@ -324,6 +389,36 @@ func (bf *BinaryField[T]) Rshift(a T, c int) T {
 	return ret
 }
 
+// Lshift returns a shifted left by c bits. The shifted-out bits are dropped.
+func (bf *BinaryField[T]) Lshift(a T, c int) T {
+	lenB := bf.lenBts()
+	shiftBl := c / 8
+	shiftBt := c % 8
+	var ret T
+	for i := 0; i < shiftBl && i < lenB; i++ {
+		ret[i] = NewU8(0)
+	}
+	if shiftBl >= lenB {
+		return ret
+	}
+	if shiftBt == 0 {
+		for i := shiftBl; i < lenB; i++ {
+			ret[i] = a[i-shiftBl]
+		}
+		return ret
+	}
+	partitioned := make([][2]frontend.Variable, lenB-shiftBl)
+	for i := range partitioned {
+		lower, upper := bitslice.Partition(bf.api, a[i].Val, uint(8-shiftBt), bitslice.WithNbDigits(8))
+		partitioned[i] = [2]frontend.Variable{lower, upper}
+	}
+	ret[shiftBl].Val = bf.api.Mul(1<<shiftBt, partitioned[0][0])
+	for i := shiftBl + 1; i < lenB; i++ {
+		ret[i].Val = bf.api.Add(bf.api.Mul(1<<shiftBt, partitioned[i-shiftBl][0]), partitioned[i-shiftBl-1][1])
+	}
+	return ret
+}
+
 func (bf *BinaryField[T]) ByteAssertEq(a, b U8) {
 	bf.api.AssertIsEqual(a.Val, b.Val)
 }
@@ -339,7 +434,13 @@ func (bf *BinaryField[T]) lenBts() int {
 	return len(a)
 }
 
-func reslice[T U32 | U64](in []T) [][]U8 {
+func (bf *BinaryField[T]) assertFitsNative() {
+	if 8*bf.lenBts() >= bf.api.Compiler().FieldBitLen() {
+		panic(fmt.Sprintf("%d-bit integer does not fit into native field", 8*bf.lenBts()))
+	}
+}
+
+func reslice[T Long](in []T) [][]U8 {
 	if len(in) == 0 {
 		panic("zero-length input")
 	}
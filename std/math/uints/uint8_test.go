@@ -1,6 +1,7 @@
 package uints
 
 import (
+	"math/big"
 	"math/bits"
 	"testing"
 
@@ -104,6 +105,11 @@ func TestValueOf(t *testing.T) {
 	assert.NoError(err)
 	err = test.IsSolved(&valueOfCircuit[U32]{}, &valueOfCircuit[U32]{In: 0x1234567812345678, Expected: [4]U8{NewU8(0x78), NewU8(0x56), NewU8(0x34), NewU8(0x12)}}, ecc.BN254.ScalarField())
 	assert.Error(err)
+	wide, _ := new(big.Int).SetString("0x0123456789abcdef0123456789abcdef", 0)
+	err = test.IsSolved(&valueOfCircuit[U128]{}, &valueOfCircuit[U128]{In: wide, Expected: NewU128(wide)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&valueOfCircuit[U128]{}, &valueOfCircuit[U128]{In: new(big.Int).Lsh(wide, 8), Expected: NewU128(wide)}, ecc.BN254.ScalarField())
+	assert.Error(err)
 }
 
 type addCircuit struct {
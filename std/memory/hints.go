@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hints used in this package
+func GetHints() []solver.Hint {
+	return []solver.Hint{accessHint}
+}
+
+// accessHint performs an access on the memory state. The inputs are the memory
+// size n, the tuple width w, the address, 1 for writes and 0 for reads, the
+// timestamp of the access, the written tuple and the memory state: the n
+// tuples followed by the n timestamps of the last accesses. It returns the
+// previous tuple and timestamp at the address, followed by the memory state
+// after the access.
+func accessHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 2 || !inputs[0].IsUint64() || !inputs[1].IsUint64() {
+		return fmt.Errorf("first inputs must be memory size and tuple width")
+	}
+	n, w := int(inputs[0].Uint64()), int(inputs[1].Uint64())
+	if len(inputs) != 5+w+n*(w+1) {
+		return fmt.Errorf("expecting %d inputs", 5+w+n*(w+1))
+	}
+	if len(outputs) != w+1+n*(w+1) {
+		return fmt.Errorf("expecting %d outputs", w+1+n*(w+1))
+	}
+	addr, write, ts := inputs[2], inputs[3], inputs[4]
+	if !addr.IsUint64() || addr.Uint64() >= uint64(n) {
+		return fmt.Errorf("address %s out of bounds", addr)
+	}
+	a := int(addr.Uint64())
+	val := inputs[5 : 5+w]
+	vals, tss := inputs[5+w:5+w+n*w], inputs[5+w+n*w:]
+	for k := 0; k < w; k++ {
+		outputs[k].Set(vals[a*w+k])
+	}
+	outputs[w].Set(tss[a])
+	newVals, newTs := outputs[w+1:w+1+n*w], outputs[w+1+n*w:]
+	for i := range vals {
+		newVals[i].Set(vals[i])
+	}
+	for i := range tss {
+		newTs[i].Set(tss[i])
+	}
+	if write.Sign() != 0 {
+		for k := 0; k < w; k++ {
+			newVals[a*w+k].Set(val[k])
+		}
+	}
+	newTs[a].Set(ts)
+	return nil
+}
@@ -0,0 +1,232 @@
+// Package memory implements read-write memory using offline memory checking.
+//
+// Differently from [logderivlookup.Table], which only allows to perform lookups
+// from a table which is fixed before the lookups, the memory allows to
+// interleave reads and writes at variable addresses. This allows to model the
+// memory and registers of a virtual machine.
+//
+// The consistency of the memory is checked using the offline memory checking
+// argument by Blum et al. [BEGKN91] as used in [Spice]. Every operation is
+// assigned a unique timestamp known at compile time. We maintain two multisets
+// of tuples (address, value, timestamp): the read set RS and the write set WS.
+// Initially, WS contains the tuples (i, init_i, 0) for every address i. Every
+// operation at address a with timestamp t
+//   - obtains from the prover the current value v and the timestamp t' of the
+//     last operation at a, asserts that t' < t and adds (a, v, t') to RS;
+//   - adds (a, v', t) to WS, where v' is the written value for writes and v for
+//     reads.
+//
+// Finally, for every address i the prover provides the final value v_i and
+// timestamp t_i and we add (i, v_i, t_i) to RS. The memory is consistent if RS
+// is a permutation of WS, which we check using the [multiset] package. The
+// check is deferred until the end of the circuit definition.
+//
+// The memory can also store tuples of values of fixed length at every address,
+// see [NewTuples]. Then the value in the tuples of RS and WS is replaced by the
+// components of the stored tuple.
+//
+// The addresses do not have to be range checked. A read or write at an address
+// out of the range [0, n) of the memory leads to an unsatisfiable circuit.
+//
+// The prover computes the returned values with a hint per operation, which is
+// given the memory state (the values and the timestamps of the last operations
+// at every address) returned by the hint of the previous operation. The hints
+// are pure functions of their inputs, and every operation costs n(w+1) hint
+// inputs and outputs but no constraints, where w is the length of the stored
+// tuples.
+//
+// [BEGKN91]: https://doi.org/10.1007/BF01185212
+// [Spice]: https://eprint.iacr.org/2018/907
+package memory
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/permutation/multiset"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// only for documentation purposes.
+var _ = logderivlookup.New
+
+// Memory holds the initial memory state and the performed operations.
+type Memory struct {
+	api      frontend.API
+	rchecker frontend.Rangechecker
+
+	width    int
+	initial  [][]frontend.Variable
+	accesses []access
+	closed   bool
+
+	// vals and ts are the memory state computed by the hints: the current
+	// values and the timestamps of the last accesses at every address.
+	vals [][]frontend.Variable
+	ts   []frontend.Variable
+}
+
+// access is a single memory operation. The tuple (addr, prevVal, prevTs) is
+// added to the read set and (addr, val, ts) to the write set, where ts is the
+// index of the access plus one.
+type access struct {
+	addr    frontend.Variable
+	prevVal []frontend.Variable
+	prevTs  frontend.Variable
+	val     []frontend.Variable
+}
+
+// New returns a new [*Memory] initialized with the values initial. The size of
+// the memory is fixed to len(initial). It defers the memory consistency check.
+func New(api frontend.API, initial []frontend.Variable) *Memory {
+	cells := make([][]frontend.Variable, len(initial))
+	for i := range initial {
+		cells[i] = []frontend.Variable{initial[i]}
+	}
+	return NewTuples(api, cells)
+}
+
+// NewTuples returns a new [*Memory] where every address stores a tuple of
+// values, initialized with the tuples initial. All the tuples must have the
+// same length. Storing the tuples in a single memory is cheaper than using a
+// memory per component, as the timestamps are shared. The tuples are accessed
+// with [Memory.ReadTuple] and [Memory.WriteTuple].
+func NewTuples(api frontend.API, initial [][]frontend.Variable) *Memory {
+	if len(initial) == 0 {
+		panic("memory must not be empty")
+	}
+	width := len(initial[0])
+	if width == 0 {
+		panic("memory tuples must not be empty")
+	}
+	m := &Memory{
+		api:      api,
+		rchecker: rangecheck.New(api),
+		width:    width,
+		initial:  initial,
+		vals:     make([][]frontend.Variable, len(initial)),
+		ts:       make([]frontend.Variable, len(initial)),
+	}
+	for i := range initial {
+		if len(initial[i]) != width {
+			panic("memory tuples must have the same length")
+		}
+		m.vals[i] = initial[i]
+		m.ts[i] = 0
+	}
+	api.Compiler().Defer(m.commit)
+	return m
+}
+
+// Size returns the number of addressable elements in the memory.
+func (m *Memory) Size() int {
+	return len(m.initial)
+}
+
+// Read returns the value stored at address addr. It panics if the memory is
+// already committed or if it stores tuples.
+func (m *Memory) Read(addr frontend.Variable) frontend.Variable {
+	if m.width != 1 {
+		panic("memory stores tuples")
+	}
+	return m.access(addr, nil)[0]
+}
+
+// Write stores value val at address addr. It panics if the memory is already
+// committed or if it stores tuples.
+func (m *Memory) Write(addr, val frontend.Variable) {
+	m.access(addr, []frontend.Variable{val})
+}
+
+// ReadWrite returns the value stored at address addr and replaces it with val.
+// It panics if the memory is already committed or if it stores tuples.
+func (m *Memory) ReadWrite(addr, val frontend.Variable) frontend.Variable {
+	return m.access(addr, []frontend.Variable{val})[0]
+}
+
+// ReadTuple returns the tuple stored at address addr. It panics if the memory
+// is already committed.
+func (m *Memory) ReadTuple(addr frontend.Variable) []frontend.Variable {
+	return m.access(addr, nil)
+}
+
+// WriteTuple stores the tuple vals at address addr. It panics if the memory is
+// already committed or if the length of vals doesn't match the length of the
+// stored tuples.
+func (m *Memory) WriteTuple(addr frontend.Variable, vals []frontend.Variable) {
+	m.access(addr, vals)
+}
+
+// access performs a memory operation at addr. If val is nil, then the current
+// value is kept. It returns the value stored before the operation.
+func (m *Memory) access(addr frontend.Variable, val []frontend.Variable) []frontend.Variable {
+	if m.closed {
+		panic("accessing committed memory")
+	}
+	if val != nil && len(val) != m.width {
+		panic(fmt.Sprintf("expected %d values, got %d", m.width, len(val)))
+	}
+	ts := len(m.accesses) + 1
+	write, written := frontend.Variable(1), val
+	if val == nil {
+		write, written = 0, make([]frontend.Variable, m.width)
+		for i := range written {
+			written[i] = 0
+		}
+	}
+	n, w := len(m.initial), m.width
+	hintIn := make([]frontend.Variable, 0, 5+w+n*(w+1))
+	hintIn = append(hintIn, n, w, addr, write, ts)
+	hintIn = append(hintIn, written...)
+	for i := range m.vals {
+		hintIn = append(hintIn, m.vals[i]...)
+	}
+	hintIn = append(hintIn, m.ts...)
+	res, err := m.api.Compiler().NewHint(accessHint, w+1+n*(w+1), hintIn...)
+	if err != nil {
+		panic(err)
+	}
+	prevVal, prevTs := res[:w], res[w]
+	for i := range m.vals {
+		m.vals[i] = res[w+1+i*w : w+1+(i+1)*w]
+	}
+	m.ts = res[w+1+n*w:]
+	// the previous timestamp must be strictly less than the current. If the
+	// difference overflows, then prevTs is not a valid timestamp and the
+	// permutation check fails.
+	m.rchecker.Check(m.api.Sub(ts-1, prevTs), bits.Len(uint(ts)))
+	if val == nil {
+		val = prevVal
+	}
+	m.accesses = append(m.accesses, access{addr: addr, prevVal: prevVal, prevTs: prevTs, val: val})
+	return prevVal
+}
+
+func (m *Memory) commit(api frontend.API) error {
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+	readSet := make([][]frontend.Variable, 0, len(m.accesses)+len(m.initial))
+	writeSet := make([][]frontend.Variable, 0, len(m.accesses)+len(m.initial))
+	entry := func(addr frontend.Variable, val []frontend.Variable, ts frontend.Variable) []frontend.Variable {
+		res := make([]frontend.Variable, 0, len(val)+2)
+		res = append(res, addr)
+		res = append(res, val...)
+		return append(res, ts)
+	}
+	for i := range m.initial {
+		writeSet = append(writeSet, entry(i, m.initial[i], 0))
+	}
+	for i, a := range m.accesses {
+		readSet = append(readSet, entry(a.addr, a.prevVal, a.prevTs))
+		writeSet = append(writeSet, entry(a.addr, a.val, i+1))
+	}
+	for i := range m.initial {
+		readSet = append(readSet, entry(i, m.vals[i], m.ts[i]))
+	}
+	multiset.AssertIsTablePermutation(api, writeSet, readSet)
+	return nil
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+)
+
+type memoryCircuit struct {
+	Initial [4]frontend.Variable
+	Addrs   [3]frontend.Variable
+	Val     frontend.Variable
+	Result  frontend.Variable
+}
+
+func (c *memoryCircuit) Define(api frontend.API) error {
+	m := New(api, c.Initial[:])
+	m.Write(c.Addrs[0], c.Val)
+	a := m.Read(c.Addrs[1])
+	b := m.ReadWrite(c.Addrs[2], api.Add(a, 1))
+	m.Write(c.Addrs[0], api.Mul(a, b))
+	api.AssertIsEqual(m.Read(c.Addrs[0]), c.Result)
+	return nil
+}
+
+func TestMemory(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&memoryCircuit{},
+		test.WithValidAssignment(&memoryCircuit{
+			Initial: [4]frontend.Variable{10, 20, 30, 40},
+			Addrs:   [3]frontend.Variable{1, 1, 3},
+			Val:     5,
+			Result:  5 * 40,
+		}),
+		test.WithValidAssignment(&memoryCircuit{
+			Initial: [4]frontend.Variable{10, 20, 30, 40},
+			Addrs:   [3]frontend.Variable{2, 0, 0},
+			Val:     5,
+			Result:  10 * 10,
+		}),
+		test.WithInvalidAssignment(&memoryCircuit{
+			Initial: [4]frontend.Variable{10, 20, 30, 40},
+			Addrs:   [3]frontend.Variable{2, 0, 0},
+			Val:     5,
+			Result:  10 * 11,
+		}),
+		test.WithInvalidAssignment(&memoryCircuit{
+			Initial: [4]frontend.Variable{10, 20, 30, 40},
+			Addrs:   [3]frontend.Variable{4, 0, 0},
+			Val:     5,
+			Result:  10 * 10,
+		}),
+		test.WithCurves(ecc.BN254), test.WithBackends(backend.GROTH16, backend.PLONK))
+}
+
+type tuplesCircuit struct {
+	Initial [3][2]frontend.Variable
+	Addrs   [2]frontend.Variable
+	Result  [2]frontend.Variable
+}
+
+func (c *tuplesCircuit) Define(api frontend.API) error {
+	initial := make([][]frontend.Variable, len(c.Initial))
+	for i := range c.Initial {
+		initial[i] = c.Initial[i][:]
+	}
+	m := NewTuples(api, initial)
+	a := m.ReadTuple(c.Addrs[0])
+	m.WriteTuple(c.Addrs[1], []frontend.Variable{api.Add(a[0], a[1]), api.Mul(a[0], a[1])})
+	res := m.ReadTuple(c.Addrs[0])
+	api.AssertIsEqual(res[0], c.Result[0])
+	api.AssertIsEqual(res[1], c.Result[1])
+	return nil
+}
+
+func TestMemoryTuples(t *testing.T) {
+	assert := test.NewAssert(t)
+	initial := [3][2]frontend.Variable{{1, 2}, {3, 4}, {5, 6}}
+	assert.CheckCircuit(&tuplesCircuit{},
+		test.WithValidAssignment(&tuplesCircuit{Initial: initial, Addrs: [2]frontend.Variable{1, 1}, Result: [2]frontend.Variable{7, 12}}),
+		test.WithValidAssignment(&tuplesCircuit{Initial: initial, Addrs: [2]frontend.Variable{2, 0}, Result: [2]frontend.Variable{5, 6}}),
+		test.WithInvalidAssignment(&tuplesCircuit{Initial: initial, Addrs: [2]frontend.Variable{1, 1}, Result: [2]frontend.Variable{3, 4}}),
+		test.WithInvalidAssignment(&tuplesCircuit{Initial: initial, Addrs: [2]frontend.Variable{1, 1}, Result: [2]frontend.Variable{7, 4}}),
+		test.WithCurves(ecc.BN254), test.WithBackends(backend.GROTH16, backend.PLONK))
+}
+
+type accessesCircuit struct {
+	Initial [8]frontend.Variable
+	Addrs   []frontend.Variable
+	Result  frontend.Variable
+}
+
+func (c *accessesCircuit) Define(api frontend.API) error {
+	m := New(api, c.Initial[:])
+	acc := frontend.Variable(0)
+	for i := range c.Addrs {
+		acc = api.Add(acc, m.ReadWrite(c.Addrs[i], i))
+	}
+	api.AssertIsEqual(acc, c.Result)
+	return nil
+}
+
+func newAccessesAssignment(nbAccesses, offset int) *accessesCircuit {
+	res := accessesCircuit{Addrs: make([]frontend.Variable, nbAccesses)}
+	vals := make([]int, len(res.Initial))
+	for i := range res.Initial {
+		vals[i] = i + offset
+		res.Initial[i] = vals[i]
+	}
+	sum := 0
+	for i := range res.Addrs {
+		addr := (i*5 + offset) % len(vals)
+		res.Addrs[i] = addr
+		sum += vals[addr]
+		vals[addr] = i
+	}
+	res.Result = sum
+	return &res
+}
+
+func TestMemoryAccesses(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the state is passed between the hints, so that the size of the constraint
+	// system (including the inputs of the hints) is linear in the number of
+	// accesses
+	sizes := make([]int64, 2)
+	var ccs constraint.ConstraintSystem
+	for i, nbAccesses := range []int{128, 256} {
+		var err error
+		ccs, err = frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &accessesCircuit{Addrs: make([]frontend.Variable, nbAccesses)})
+		assert.NoError(err)
+		sizes[i], err = ccs.WriteTo(io.Discard)
+		assert.NoError(err)
+	}
+	assert.Less(float64(sizes[1]), 2.2*float64(sizes[0]))
+
+	// the hints are pure, so that concurrent solvers are independent
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w, err := frontend.NewWitness(newAccessesAssignment(256, i), ecc.BN254.ScalarField())
+			if err == nil {
+				_, err = ccs.Solve(w)
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		assert.NoError(err)
+	}
+}
+
+func TestMemoryHintMutations(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the hints are pure, so that they can be re-executed with mutated outputs
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &accessesCircuit{Addrs: make([]frontend.Variable, 16)})
+	assert.NoError(err)
+	w, err := frontend.NewWitness(newAccessesAssignment(16, 3), ecc.BN254.ScalarField())
+	assert.NoError(err)
+	wires, err := constraint.FindUnderconstrainedWires(ccs, w)
+	assert.NoError(err)
+	assert.Empty(wires)
+}
@@ -0,0 +1,45 @@
+package multiset
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark/constraint/solver"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hints used in this package
+func GetHints() []solver.Hint {
+	return []solver.Hint{sortHint}
+}
+
+func sortHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 1 || !inputs[0].IsUint64() || inputs[0].Uint64() == 0 {
+		return fmt.Errorf("first input must be number of columns")
+	}
+	nbCols := int(inputs[0].Uint64())
+	if (len(inputs)-1)%nbCols != 0 {
+		return fmt.Errorf("inputs not full rows")
+	}
+	if len(outputs) != len(inputs)-1 {
+		return fmt.Errorf("output length mismatch")
+	}
+	nbRows := (len(inputs) - 1) / nbCols
+	rows := make([][]*big.Int, nbRows)
+	for i := range rows {
+		rows[i] = inputs[1+i*nbCols : 1+(i+1)*nbCols]
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i][0].Cmp(rows[j][0]) < 0
+	})
+	for i := range rows {
+		for j := range rows[i] {
+			outputs[i*nbCols+j].Set(rows[i][j])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Package multiset implements permutation and sorting arguments.
+//
+// To show that a multiset B is a permutation of a multiset A, we use the
+// product argument. Given a random challenge x, we check that
+//
+//	∏_{a∈A} (x-a) == ∏_{b∈B} (x-b).
+//
+// By the Schwartz-Zippel lemma, the equation holds with overwhelming
+// probability only if A and B contain the same elements with the same
+// multiplicities. When the entries are tuples (rows of a table), then we first
+// compress every row using random linear combination with coefficients
+// (1, r, r^2, ...) derived from the commitment and then apply the product
+// argument on the compressed rows.
+//
+// The challenges are derived from a commitment to all inputs using the
+// [multicommit] package. Thus the builder must implement [frontend.Committer]
+// interface.
+//
+// Additionally, the package allows to check that B is a sorted permutation of
+// A. For that, we check that the consecutive differences of the (key column of
+// the) entries in B are non-negative using range checks. This requires the
+// entries to be bounded.
+package multiset
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/multicommit"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// AssertIsPermutation asserts that b is a permutation of a.
+func AssertIsPermutation(api frontend.API, a, b []frontend.Variable) {
+	AssertIsTablePermutation(api, asTable(a), asTable(b))
+}
+
+// AssertIsTablePermutation asserts that the rows of b are a permutation of the
+// rows of a. All rows in a and b must have the same length.
+func AssertIsTablePermutation(api frontend.API, a, b [][]frontend.Variable) {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("length mismatch: %d != %d", len(a), len(b)))
+	}
+	if len(a) == 0 {
+		return
+	}
+	nbCols := len(a[0])
+	var toCommit []frontend.Variable
+	for _, t := range [][][]frontend.Variable{a, b} {
+		for i := range t {
+			if len(t[i]) != nbCols {
+				panic("row length mismatch")
+			}
+			toCommit = append(toCommit, t[i]...)
+		}
+	}
+	multicommit.WithCommitment(api, func(api frontend.API, commitment frontend.Variable) error {
+		coeffs := randCoefficients(api, nbCols, commitment)
+		pa, pb := frontend.Variable(1), frontend.Variable(1)
+		for i := range a {
+			pa = api.Mul(pa, api.Sub(commitment, compress(api, coeffs, a[i])))
+			pb = api.Mul(pb, api.Sub(commitment, compress(api, coeffs, b[i])))
+		}
+		api.AssertIsEqual(pa, pb)
+		return nil
+	}, toCommit...)
+}
+
+// AssertIsSortedPermutation asserts that b is a permutation of a and that b is
+// sorted in non-decreasing order. All entries must be less than 2^nbBits.
+func AssertIsSortedPermutation(api frontend.API, a, b []frontend.Variable, nbBits int) {
+	AssertIsSortedTablePermutation(api, asTable(a), asTable(b), nbBits)
+}
+
+// AssertIsSortedTablePermutation asserts that the rows of b are a permutation
+// of the rows of a and that the rows of b are sorted in non-decreasing order by
+// the first column. All entries in the first column must be less than
+// 2^nbBits.
+func AssertIsSortedTablePermutation(api frontend.API, a, b [][]frontend.Variable, nbBits int) {
+	// the range checker defers its commitment. Initialize it before scheduling
+	// the permutation argument so that its deferred callback is run first.
+	assertIsSorted(api, b, nbBits)
+	AssertIsTablePermutation(api, a, b)
+}
+
+// Sort returns the entries of a sorted in non-decreasing order. It asserts
+// that the returned slice is a sorted permutation of a. All entries must be
+// less than 2^nbBits.
+func Sort(api frontend.API, a []frontend.Variable, nbBits int) []frontend.Variable {
+	sorted := SortTable(api, asTable(a), nbBits)
+	ret := make([]frontend.Variable, len(sorted))
+	for i := range sorted {
+		ret[i] = sorted[i][0]
+	}
+	return ret
+}
+
+// SortTable returns the rows of a sorted in non-decreasing order by the first
+// column. It asserts that the returned rows are a sorted permutation of the
+// rows of a. The order of the rows with equal first entries is not
+// constrained. All entries in the first column must be less than 2^nbBits.
+func SortTable(api frontend.API, a [][]frontend.Variable, nbBits int) [][]frontend.Variable {
+	if len(a) == 0 {
+		return nil
+	}
+	nbCols := len(a[0])
+	hintIn := []frontend.Variable{nbCols}
+	for i := range a {
+		if len(a[i]) != nbCols {
+			panic("row length mismatch")
+		}
+		hintIn = append(hintIn, a[i]...)
+	}
+	res, err := api.Compiler().NewHint(sortHint, len(a)*nbCols, hintIn...)
+	if err != nil {
+		panic(err)
+	}
+	ret := make([][]frontend.Variable, len(a))
+	for i := range ret {
+		ret[i] = res[i*nbCols : (i+1)*nbCols]
+	}
+	AssertIsSortedTablePermutation(api, a, ret, nbBits)
+	return ret
+}
+
+// assertIsSorted asserts that the first column of t is non-decreasing and that
+// its first entry is less than 2^nbBits.
+func assertIsSorted(api frontend.API, t [][]frontend.Variable, nbBits int) {
+	if nbBits+1 >= api.Compiler().FieldBitLen() {
+		panic("entries must be bounded")
+	}
+	if len(t) == 0 {
+		return
+	}
+	rc := rangecheck.New(api)
+	// otherwise a large first entry (i.e. a negative one) would wrap around
+	// and be followed by small ones.
+	rc.Check(t[0][0], nbBits)
+	for i := 1; i < len(t); i++ {
+		// the difference of two bounded elements is small only if it is
+		// non-negative.
+		rc.Check(api.Sub(t[i][0], t[i-1][0]), nbBits)
+	}
+}
+
+func randCoefficients(api frontend.API, nbCols int, commitment frontend.Variable) []frontend.Variable {
+	if nbCols == 1 {
+		return []frontend.Variable{1}
+	}
+	hasher, err := mimc.NewMiMC(api)
+	if err != nil {
+		panic(err)
+	}
+	hasher.Write(commitment)
+	r := hasher.Sum()
+	coeffs := make([]frontend.Variable, nbCols)
+	coeffs[0] = 1
+	for i := 1; i < nbCols; i++ {
+		coeffs[i] = api.Mul(coeffs[i-1], r)
+	}
+	return coeffs
+}
+
+func compress(api frontend.API, coeffs []frontend.Variable, row []frontend.Variable) frontend.Variable {
+	res := row[0]
+	for i := 1; i < len(row); i++ {
+		res = api.Add(res, api.Mul(coeffs[i], row[i]))
+	}
+	return res
+}
+
+func asTable(v []frontend.Variable) [][]frontend.Variable {
+	ret := make([][]frontend.Variable, len(v))
+	for i := range v {
+		ret[i] = []frontend.Variable{v[i]}
+	}
+	return ret
+}
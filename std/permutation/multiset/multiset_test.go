@@ -0,0 +1,107 @@
+package multiset
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type permutationCircuit struct {
+	A, B []frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	AssertIsPermutation(api, c.A, c.B)
+	return nil
+}
+
+func TestPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit := &permutationCircuit{A: make([]frontend.Variable, 5), B: make([]frontend.Variable, 5)}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(&permutationCircuit{A: []frontend.Variable{1, 2, 3, 3, 5}, B: []frontend.Variable{3, 5, 1, 3, 2}}),
+		test.WithInvalidAssignment(&permutationCircuit{A: []frontend.Variable{1, 2, 3, 3, 5}, B: []frontend.Variable{3, 5, 1, 2, 2}}),
+		test.WithCurves(ecc.BN254), test.WithBackends(backend.GROTH16, backend.PLONK))
+}
+
+type tablePermutationCircuit struct {
+	A, B [][2]frontend.Variable
+}
+
+func (c *tablePermutationCircuit) Define(api frontend.API) error {
+	a, b := make([][]frontend.Variable, len(c.A)), make([][]frontend.Variable, len(c.B))
+	for i := range c.A {
+		a[i] = c.A[i][:]
+		b[i] = c.B[i][:]
+	}
+	AssertIsTablePermutation(api, a, b)
+	return nil
+}
+
+func TestTablePermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit := &tablePermutationCircuit{A: make([][2]frontend.Variable, 3), B: make([][2]frontend.Variable, 3)}
+	err := test.IsSolved(circuit, &tablePermutationCircuit{
+		A: [][2]frontend.Variable{{1, 10}, {2, 20}, {3, 30}},
+		B: [][2]frontend.Variable{{3, 30}, {1, 10}, {2, 20}},
+	}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// columns are permuted individually, but not the rows.
+	err = test.IsSolved(circuit, &tablePermutationCircuit{
+		A: [][2]frontend.Variable{{1, 10}, {2, 20}, {3, 30}},
+		B: [][2]frontend.Variable{{3, 10}, {1, 30}, {2, 20}},
+	}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type sortedPermutationCircuit struct {
+	A, B []frontend.Variable
+}
+
+func (c *sortedPermutationCircuit) Define(api frontend.API) error {
+	AssertIsSortedPermutation(api, c.A, c.B, 16)
+	return nil
+}
+
+func TestSortedPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit := &sortedPermutationCircuit{A: make([]frontend.Variable, 5), B: make([]frontend.Variable, 5)}
+	err := test.IsSolved(circuit, &sortedPermutationCircuit{A: []frontend.Variable{5, 3, 1, 3, 2}, B: []frontend.Variable{1, 2, 3, 3, 5}}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(circuit, &sortedPermutationCircuit{A: []frontend.Variable{5, 3, 1, 3, 2}, B: []frontend.Variable{3, 5, 1, 3, 2}}, ecc.BN254.ScalarField())
+	assert.Error(err)
+	// -1 is not less than 2^16, it must not be accepted as the smallest entry
+	err = test.IsSolved(circuit, &sortedPermutationCircuit{A: []frontend.Variable{5, 3, -1, 3, 2}, B: []frontend.Variable{-1, 2, 3, 3, 5}}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type sortCircuit struct {
+	In       [][2]frontend.Variable
+	Expected [][2]frontend.Variable
+}
+
+func (c *sortCircuit) Define(api frontend.API) error {
+	in := make([][]frontend.Variable, len(c.In))
+	for i := range c.In {
+		in[i] = c.In[i][:]
+	}
+	res := SortTable(api, in, 8)
+	for i := range res {
+		api.AssertIsEqual(res[i][0], c.Expected[i][0])
+		api.AssertIsEqual(res[i][1], c.Expected[i][1])
+	}
+	return nil
+}
+
+func TestSortTable(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit := &sortCircuit{In: make([][2]frontend.Variable, 4), Expected: make([][2]frontend.Variable, 4)}
+	err := test.IsSolved(circuit, &sortCircuit{
+		In:       [][2]frontend.Variable{{4, 1}, {2, 2}, {4, 3}, {1, 4}},
+		Expected: [][2]frontend.Variable{{1, 4}, {2, 2}, {4, 1}, {4, 3}},
+	}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -109,6 +110,25 @@ func ValueOfProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth1
 		if err != nil {
 			return ret, fmt.Errorf("commitment pok: %w", err)
 		}
+	case *Proof[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tProof, ok := proof.(*groth16backend_bls12377.Proof)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.Proof, got %T", proof)
+		}
+		ar.Ar = emsw_bls12377.NewG1Affine(tProof.Ar)
+		ar.Krs = emsw_bls12377.NewG1Affine(tProof.Krs)
+		ar.Bs = emsw_bls12377.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]pedersen.Commitment[emsw_bls12377.G1Affine], len(tProof.Commitments))
+		for i := range tProof.Commitments {
+			ar.Commitments[i], err = pedersen.ValueOfCommitment[emsw_bls12377.G1Affine](tProof.Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment[%d]: %w", i, err)
+			}
+		}
+		ar.CommitmentPok, err = pedersen.ValueOfKnowledgeProof[emsw_bls12377.G1Affine](tProof.CommitmentPok)
+		if err != nil {
+			return ret, fmt.Errorf("commitment pok: %w", err)
+		}
 	case *Proof[sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
 		tProof, ok := proof.(*groth16backend_bls24315.Proof)
 		if !ok {
@@ -274,6 +294,33 @@ func ValueOfVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl
 				return ret, fmt.Errorf("commitment key[%d]: %w", i, err)
 			}
 		}
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]:
+		tVk, ok := vk.(*groth16backend_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.VerifyingKey, got %T", vk)
+		}
+		// compute E
+		e, err := bls12377.Pair([]bls12377.G1Affine{tVk.G1.Alpha}, []bls12377.G2Affine{tVk.G2.Beta})
+		if err != nil {
+			return ret, fmt.Errorf("precompute pairing: %w", err)
+		}
+		s.E = emsw_bls12377.NewGTEl(e)
+		s.G1.K = make([]emsw_bls12377.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = emsw_bls12377.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bls12377.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.DeltaNeg = emsw_bls12377.NewG2Affine(deltaNeg)
+		s.G2.GammaNeg = emsw_bls12377.NewG2Affine(gammaNeg)
+		s.CommitmentKeys = make([]pedersen.VerifyingKey[emsw_bls12377.G2Affine], len(tVk.CommitmentKeys))
+		for i := range tVk.CommitmentKeys {
+			s.CommitmentKeys[i], err = pedersen.ValueOfVerifyingKey[emsw_bls12377.G2Affine](&tVk.CommitmentKeys[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment key[%d]: %w", i, err)
+			}
+		}
 	case *VerifyingKey[sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT]:
 		tVk, ok := vk.(*groth16backend_bls24315.VerifyingKey)
 		if !ok {
@@ -421,6 +468,33 @@ func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT,
 				return ret, fmt.Errorf("commitment key[%d]: %w", i, err)
 			}
 		}
+	case *VerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]:
+		tVk, ok := vk.(*groth16backend_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.VerifyingKey, got %T", vk)
+		}
+		// compute E
+		e, err := bls12377.Pair([]bls12377.G1Affine{tVk.G1.Alpha}, []bls12377.G2Affine{tVk.G2.Beta})
+		if err != nil {
+			return ret, fmt.Errorf("precompute pairing: %w", err)
+		}
+		s.E = emsw_bls12377.NewGTEl(e)
+		s.G1.K = make([]emsw_bls12377.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = emsw_bls12377.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bls12377.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.DeltaNeg = emsw_bls12377.NewG2AffineFixed(deltaNeg)
+		s.G2.GammaNeg = emsw_bls12377.NewG2AffineFixed(gammaNeg)
+		s.CommitmentKeys = make([]pedersen.VerifyingKey[emsw_bls12377.G2Affine], len(tVk.CommitmentKeys))
+		for i := range tVk.CommitmentKeys {
+			s.CommitmentKeys[i], err = pedersen.ValueOfVerifyingKeyFixed[emsw_bls12377.G2Affine](&tVk.CommitmentKeys[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment key[%d]: %w", i, err)
+			}
+		}
 	case *VerifyingKey[sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT]:
 		tVk, ok := vk.(*groth16backend_bls24315.VerifyingKey)
 		if !ok {
@@ -20,6 +20,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/std/algebra"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -156,6 +157,31 @@ func TestBW6InBN254(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestBLS12InBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInner(assert, ecc.BLS12_377.ScalarField())
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[emsw_bls12377.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuit[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]{
+		InnerWitness: PlaceholderWitness[emsw_bls12377.ScalarField](innerCcs),
+		VerifyingKey: PlaceholderVerifyingKey[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl](innerCcs),
+	}
+	outerAssignment := &OuterCircuit[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+		VerifyingKey: circuitVk,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 // assignment tests
 
 type WitnessCircut struct {
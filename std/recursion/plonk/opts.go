@@ -75,6 +75,7 @@ func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
 
 type verifierCfg struct {
 	withCompleteArithmetic bool
+	msmWindowSize          int
 }
 
 // VerifierOption allows to modify the behaviour of PLONK verifier.
@@ -94,6 +95,18 @@ func WithCompleteArithmetic() VerifierOption {
 	}
 }
 
+// WithWindowedMSM uses windowed multi-scalar multiplication with lookup tables
+// for computing the linearized polynomial digest and for folding the KZG
+// commitments, see [algopts.WithWindowedMSM]. It reduces the number of
+// constraints when the inner circuit has many BSB22 commitments or when
+// verifying many proofs at once.
+func WithWindowedMSM(windowSize int) VerifierOption {
+	return func(cfg *verifierCfg) error {
+		cfg.msmWindowSize = windowSize
+		return nil
+	}
+}
+
 func newCfg(opts ...VerifierOption) (*verifierCfg, error) {
 	cfg := new(verifierCfg)
 	for i := range opts {
@@ -19,6 +19,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra"
 	"github.com/consensys/gnark/std/algebra/algopts"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -136,6 +137,43 @@ func ValueOfProof[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra
 		if err != nil {
 			return ret, fmt.Errorf("z shifted opening proof value assignment: %w", err)
 		}
+	case *Proof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tProof, ok := proof.(*plonkbackend_bls12377.Proof)
+		if !ok {
+			return ret, fmt.Errorf("expected emsw_bls12377.Proof, got %T", proof)
+		}
+		for i := range r.LRO {
+			r.LRO[i], err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tProof.LRO[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment LRO[%d] value assignment: %w", i, err)
+			}
+		}
+		r.Z, err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tProof.Z)
+		if err != nil {
+			return ret, fmt.Errorf("commitment Z value assignment: %w", err)
+		}
+		for i := range r.H {
+			r.H[i], err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tProof.H[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment H[%d] value assignment: %w", i, err)
+			}
+		}
+		r.Bsb22Commitments = make([]kzg.Commitment[emsw_bls12377.G1Affine], len(tProof.Bsb22Commitments))
+		for i := range r.Bsb22Commitments {
+			r.Bsb22Commitments[i], err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tProof.Bsb22Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("bsb22 commitment %d value assignment: %w", i, err)
+			}
+		}
+		// TODO: actually we compute the opening point later. Maybe we can precompute it here and later assert its correctness?
+		r.BatchedProof, err = kzg.ValueOfBatchOpeningProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine](tProof.BatchedProof)
+		if err != nil {
+			return ret, fmt.Errorf("batch opening proof value assignment: %w", err)
+		}
+		r.ZShiftedOpening, err = kzg.ValueOfOpeningProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine](tProof.ZShiftedOpening)
+		if err != nil {
+			return ret, fmt.Errorf("z shifted opening proof value assignment: %w", err)
+		}
 	case *Proof[sw_bls24315.ScalarField, sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
 		tProof, ok := proof.(*plonkbackend_bls24315.Proof)
 		if !ok {
@@ -339,6 +377,17 @@ func ValueOfBaseVerifyingKey[FR emulated.FieldParams, G1El algebra.G1ElementT, G
 			return ret, fmt.Errorf("verifying key witness assignment: %w", err)
 		}
 		r.CosetShift = sw_bls12381.NewScalar(tVk.CosetShift)
+	case *BaseVerifyingKey[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine]:
+		tVk, ok := vk.(*plonkbackend_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.VerifyingKey, got %T", vk)
+		}
+		r.NbPublicVariables = tVk.NbPublicVariables
+		r.Kzg, err = kzg.ValueOfVerifyingKeyFixed[emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](tVk.Kzg)
+		if err != nil {
+			return ret, fmt.Errorf("verifying key witness assignment: %w", err)
+		}
+		r.CosetShift = emsw_bls12377.NewScalar(tVk.CosetShift)
 	case *BaseVerifyingKey[sw_bls24315.ScalarField, sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
 		tVk, ok := vk.(*plonkbackend_bls24315.VerifyingKey)
 		if !ok {
@@ -475,6 +524,51 @@ func ValueOfCircuitVerifyingKey[FR emulated.FieldParams, G1El algebra.G1ElementT
 		for i := range r.CommitmentConstraintIndexes {
 			r.CommitmentConstraintIndexes[i] = tVk.CommitmentConstraintIndexes[i]
 		}
+	case *CircuitVerifyingKey[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine]:
+		tVk, ok := vk.(*plonkbackend_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.VerifyingKey, got %T", vk)
+		}
+		r.Size = tVk.Size
+		r.SizeInv = emsw_bls12377.NewScalar(tVk.SizeInv)
+		r.Generator = emsw_bls12377.NewScalar(tVk.Generator)
+		for i := range r.S {
+			r.S[i], err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.S[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment S[%d] witness assignment: %w", i, err)
+			}
+		}
+		r.Ql, err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.Ql)
+		if err != nil {
+			return ret, fmt.Errorf("commitment Ql witness assignment: %w", err)
+		}
+		r.Qr, err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.Qr)
+		if err != nil {
+			return ret, fmt.Errorf("commitment Qr witness assignment: %w", err)
+		}
+		r.Qm, err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.Qm)
+		if err != nil {
+			return ret, fmt.Errorf("commitment Qm witness assignment: %w", err)
+		}
+		r.Qo, err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.Qo)
+		if err != nil {
+			return ret, fmt.Errorf("commitment Qo witness assignment: %w", err)
+		}
+		r.Qk, err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.Qk)
+		if err != nil {
+			return ret, fmt.Errorf("commitment Qk witness assignment: %w", err)
+		}
+		r.Qcp = make([]kzg.Commitment[emsw_bls12377.G1Affine], len(tVk.Qcp))
+		for i := range r.Qcp {
+			r.Qcp[i], err = kzg.ValueOfCommitment[emsw_bls12377.G1Affine](tVk.Qcp[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment Qcp[%d] witness assignment: %w", i, err)
+			}
+		}
+		r.CommitmentConstraintIndexes = make([]frontend.Variable, len(tVk.CommitmentConstraintIndexes))
+		for i := range r.CommitmentConstraintIndexes {
+			r.CommitmentConstraintIndexes[i] = tVk.CommitmentConstraintIndexes[i]
+		}
 	case *CircuitVerifyingKey[sw_bls24315.ScalarField, sw_bls24315.G1Affine]:
 		tVk, ok := vk.(*plonkbackend_bls24315.VerifyingKey)
 		if !ok {
@@ -746,6 +840,10 @@ type Verifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2E
 	curve     algebra.Curve[FR, G1El]
 	pairing   algebra.Pairing[G1El, G2El, GtEl]
 	kzg       *kzg.Verifier[FR, G1El, G2El, GtEl]
+
+	// kzgWindowed are the KZG verifiers using windowed MSM, indexed by the
+	// window size. They are built on first use.
+	kzgWindowed map[int]*kzg.Verifier[FR, G1El, G2El, GtEl]
 }
 
 // NewVerifier returns a new [Verifier] instance.
@@ -992,6 +1090,9 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 	if cfg.withCompleteArithmetic {
 		msmOpts = append(msmOpts, algopts.WithCompleteArithmetic())
 	}
+	if cfg.msmWindowSize > 0 {
+		msmOpts = append(msmOpts, algopts.WithWindowedMSM(cfg.msmWindowSize))
+	}
 	linearizedPolynomialDigest, err := v.curve.MultiScalarMul(points, scalars, msmOpts...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("linearized polynomial digest MSM: %w", err)
@@ -1012,7 +1113,11 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 	digestsToFold[3] = proof.LRO[2]
 	digestsToFold[4] = vk.S[0]
 	digestsToFold[5] = vk.S[1]
-	foldedProof, foldedDigest, err := v.kzg.FoldProof(
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	foldedProof, foldedDigest, err := kzgVerifier.FoldProof(
 		digestsToFold,
 		proof.BatchedProof,
 		*zeta,
@@ -1034,12 +1139,20 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 // verifying key.
 func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[FR, G1El, G2El], proof Proof[FR, G1El, G2El], witness Witness[FR], opts ...VerifierOption) error {
 
+	cfg, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return err
+	}
 	commitments, proofs, points, err := v.PrepareVerification(vk, proof, witness, opts...)
 	if err != nil {
 		return err
 	}
 
-	err = v.kzg.BatchVerifyMultiPoints(commitments, proofs, points, vk.Kzg)
+	err = kzgVerifier.BatchVerifyMultiPoints(commitments, proofs, points, vk.Kzg)
 	if err != nil {
 		return fmt.Errorf("batch verify kzg: %w", err)
 	}
@@ -1055,7 +1168,15 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertSameProofs(vk VerifyingKey[FR, G1
 		return fmt.Errorf("no proofs to check")
 	}
 	if len(proofs) == 1 {
-		return v.AssertProof(vk, proofs[0], witnesses[0])
+		return v.AssertProof(vk, proofs[0], witnesses[0], opts...)
+	}
+	cfg, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return err
 	}
 	var foldedDigests []kzg.Commitment[G1El]
 	var foldedProofs []kzg.OpeningProof[FR, G1El]
@@ -1069,7 +1190,7 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertSameProofs(vk VerifyingKey[FR, G1
 		foldedProofs = append(foldedProofs, pr...)
 		foldedPoints = append(foldedPoints, pts...)
 	}
-	if err := v.kzg.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, vk.Kzg); err != nil {
+	if err := kzgVerifier.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, vk.Kzg); err != nil {
 		return fmt.Errorf("batch verify kzg: %w", err)
 	}
 	return nil
@@ -1088,6 +1209,14 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertDifferentProofs(bvk BaseVerifying
 	if len(proofs) == 0 {
 		return fmt.Errorf("no proofs to check")
 	}
+	cfg, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return err
+	}
 	var foldedDigests []kzg.Commitment[G1El]
 	var foldedProofs []kzg.OpeningProof[FR, G1El]
 	var foldedPoints []emulated.Element[FR]
@@ -1104,12 +1233,32 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertDifferentProofs(bvk BaseVerifying
 		foldedProofs = append(foldedProofs, pr...)
 		foldedPoints = append(foldedPoints, pts...)
 	}
-	if err := v.kzg.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, bvk.Kzg); err != nil {
+	if err := kzgVerifier.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, bvk.Kzg); err != nil {
 		return fmt.Errorf("batch verify kzg: %w", err)
 	}
 	return nil
 }
 
+// kzgVerifier returns the KZG verifier to use with the options in cfg. The
+// verifiers are built once and reused in subsequent calls.
+func (v *Verifier[FR, G1El, G2El, GtEl]) kzgVerifier(cfg *verifierCfg) (*kzg.Verifier[FR, G1El, G2El, GtEl], error) {
+	if cfg.msmWindowSize == 0 {
+		return v.kzg, nil
+	}
+	if kzgVerifier, ok := v.kzgWindowed[cfg.msmWindowSize]; ok {
+		return kzgVerifier, nil
+	}
+	kzgVerifier, err := kzg.NewVerifier[FR, G1El, G2El, GtEl](v.api, kzg.WithWindowedMSM(cfg.msmWindowSize))
+	if err != nil {
+		return nil, fmt.Errorf("new kzg verifier: %w", err)
+	}
+	if v.kzgWindowed == nil {
+		v.kzgWindowed = make(map[int]*kzg.Verifier[FR, G1El, G2El, GtEl])
+	}
+	v.kzgWindowed[cfg.msmWindowSize] = kzgVerifier
+	return kzgVerifier, nil
+}
+
 func (v *Verifier[FR, G1El, G2El, GtEl]) bindPublicData(fs *fiatshamir.Transcript, challenge string, vk VerifyingKey[FR, G1El, G2El], witness Witness[FR]) error {
 
 	// permutation
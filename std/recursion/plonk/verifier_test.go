@@ -16,6 +16,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/std/algebra"
+	emsw_bls12377 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
@@ -30,6 +31,7 @@ type OuterCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra
 	Proof        Proof[FR, G1El, G2El]
 	VerifyingKey VerifyingKey[FR, G1El, G2El] `gnark:"-"`
 	InnerWitness Witness[FR]                  `gnark:",public"`
+	opts         []VerifierOption
 }
 
 func (c *OuterCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
@@ -37,7 +39,7 @@ func (c *OuterCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
 	if err != nil {
 		return fmt.Errorf("new verifier: %w", err)
 	}
-	err = verifier.AssertProof(c.VerifyingKey, c.Proof, c.InnerWitness, WithCompleteArithmetic())
+	err = verifier.AssertProof(c.VerifyingKey, c.Proof, c.InnerWitness, append([]VerifierOption{WithCompleteArithmetic()}, c.opts...)...)
 	return err
 }
 
@@ -161,6 +163,32 @@ func TestBLS12381InBN254WoCommit(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestBLS12377InBN254WoCommit(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerWoCommit(assert, ecc.BLS12_377.ScalarField(), ecc.BN254.ScalarField())
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[emsw_bls12377.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuit[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]{
+		InnerWitness: PlaceholderWitness[emsw_bls12377.ScalarField](innerCcs),
+		Proof:        PlaceholderProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerCcs),
+		VerifyingKey: circuitVk,
+	}
+	outerAssignment := &OuterCircuit[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 //-----------------------------------------------------------------
 // With api.Commit
 
@@ -297,6 +325,32 @@ func TestBLS12381InBN254Commit(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestBLS12377InBN254Commit(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerCommit(assert, ecc.BLS12_377.ScalarField(), ecc.BN254.ScalarField())
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[emsw_bls12377.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuit[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]{
+		InnerWitness: PlaceholderWitness[emsw_bls12377.ScalarField](innerCcs),
+		Proof:        PlaceholderProof[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine](innerCcs),
+		VerifyingKey: circuitVk,
+	}
+	outerAssignment := &OuterCircuit[emsw_bls12377.ScalarField, emsw_bls12377.G1Affine, emsw_bls12377.G2Affine, emsw_bls12377.GTEl]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 func TestBN254InBN254Commit(t *testing.T) {
 
 	assert := test.NewAssert(t)
@@ -323,6 +377,33 @@ func TestBN254InBN254Commit(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestBN254InBN254CommitWindowedMSM(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerCommit(assert, ecc.BN254.ScalarField(), ecc.BN254.ScalarField())
+
+	// outer proof
+	circuitVk, err := ValueOfVerifyingKey[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[sw_bn254.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuit[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]{
+		InnerWitness: PlaceholderWitness[sw_bn254.ScalarField](innerCcs),
+		Proof:        PlaceholderProof[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		VerifyingKey: circuitVk,
+		opts:         []VerifierOption{WithWindowedMSM(4)},
+	}
+	outerAssignment := &OuterCircuit[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 type InnerCircuitParametric struct {
 	X         frontend.Variable
 	Y         frontend.Variable `gnark:",public"`
@@ -0,0 +1,459 @@
+package regex
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxStates is the maximal number of states in the DFA before minimization.
+// Every state corresponds to 256 entries in the in-circuit lookup tables, so
+// large automata are impractical anyway.
+const maxStates = 1 << 12
+
+// DFA is a deterministic finite automaton over bytes. It is constructed
+// out-of-circuit from a regular expression using [Compile] and then used
+// in-circuit with [New].
+//
+// For the captures, the DFA also holds a reverse automaton which reads the
+// input backwards, and whose state after reading a suffix of the input is the
+// set of the threads of the NFA from which the suffix leads to a match.
+type DFA struct {
+	start       int
+	accepting   []bool
+	transitions [][256]int
+
+	reverseStart       int
+	reverseTransitions [][256]int
+	// captures[s][r] has bit g set if the next byte is part of the capture
+	// group g in a match, when the forward automaton is in state s and the
+	// reverse automaton is in state r after reading the rest of the input.
+	captures [][]uint64
+	nbGroups int
+}
+
+// Compile compiles the regular expression pattern into a DFA. The pattern uses
+// the syntax of the [regexp] package. The whole input must match the pattern,
+// i.e. the pattern is implicitly anchored at both ends.
+//
+// The automaton is defined over bytes and not over UTF-8 encoded runes. A rune
+// in the pattern matches a single byte with the same value, so only runes up to
+// 0xff can be matched. Empty-width assertions other than the beginning and end
+// of text (for example word boundaries) are not supported.
+func Compile(pattern string) (*DFA, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	nbGroups := re.MaxCap() + 1
+	if nbGroups > 64 {
+		return nil, fmt.Errorf("too many capture groups")
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("compile: %w", err)
+	}
+	b := &builder{prog: prog, index: make(map[string]int)}
+	// the group 0 corresponds to the whole match and is open from the start.
+	start, err := b.closure([]thread{{pc: uint32(prog.Start), open: 1}}, true)
+	if err != nil {
+		return nil, err
+	}
+	d := &DFA{nbGroups: nbGroups}
+	d.start = b.state(start)
+	for i := 0; i < len(b.sets); i++ {
+		if len(b.sets) > maxStates {
+			return nil, fmt.Errorf("automaton has more than %d states", maxStates)
+		}
+		var trans [256]int
+		for c := 0; c < 256; c++ {
+			var next []thread
+			for _, t := range b.sets[i] {
+				inst := &prog.Inst[t.pc]
+				if t.atEnd || !consumes(inst, byte(c)) {
+					continue
+				}
+				next = append(next, thread{pc: inst.Out, open: t.open})
+			}
+			nextSet, err := b.closure(next, false)
+			if err != nil {
+				return nil, err
+			}
+			trans[c] = b.state(nextSet)
+		}
+		d.transitions = append(d.transitions, trans)
+		d.accepting = append(d.accepting, b.accepts(b.sets[i]))
+	}
+	if err := b.reverse(d); err != nil {
+		return nil, err
+	}
+	d.minimize()
+	return d, nil
+}
+
+// MustCompile is like [Compile] but panics if the pattern cannot be compiled.
+func MustCompile(pattern string) *DFA {
+	d, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NbStates returns the number of states of the automaton.
+func (d *DFA) NbStates() int {
+	return len(d.transitions)
+}
+
+// NbGroups returns the number of capture groups, including the implicit group
+// 0 which corresponds to the whole match.
+func (d *DFA) NbGroups() int {
+	return d.nbGroups
+}
+
+// Match reports whether the input matches the automaton.
+func (d *DFA) Match(in []byte) bool {
+	s := d.start
+	for _, c := range in {
+		s = d.transitions[s][c]
+	}
+	return d.accepting[s]
+}
+
+// Capture returns for every byte in the input whether it belongs to the given
+// capture group in a match of the input. If the input has several matches
+// capturing different bytes, then a byte is considered captured if it is
+// captured in any of the matches. If the input doesn't match, then no byte is
+// captured.
+func (d *DFA) Capture(in []byte, group int) []bool {
+	reverse := d.reverseRun(in)
+	ret := make([]bool, len(in))
+	s := d.start
+	for i, c := range in {
+		ret[i] = d.captures[s][reverse[i]]>>group&1 == 1
+		s = d.transitions[s][c]
+	}
+	return ret
+}
+
+// reverseRun returns the states of the reverse automaton: the state i is the
+// state after reading in[i:] backwards.
+func (d *DFA) reverseRun(in []byte) []int {
+	ret := make([]int, len(in)+1)
+	ret[len(in)] = d.reverseStart
+	for i := len(in) - 1; i >= 0; i-- {
+		ret[i] = d.reverseTransitions[ret[i+1]][in[i]]
+	}
+	return ret
+}
+
+// thread is a state of the NFA simulation. It contains the program counter,
+// the bitmask of currently open capture groups and a flag if the thread has
+// passed the end-of-text assertion and thus cannot consume more input.
+type thread struct {
+	pc    uint32
+	open  uint64
+	atEnd bool
+}
+
+type builder struct {
+	prog  *syntax.Prog
+	sets  [][]thread
+	index map[string]int
+}
+
+// state returns the DFA state index for the set of threads, allocating a new
+// state if needed.
+func (b *builder) state(set []thread) int {
+	var sb strings.Builder
+	for _, t := range set {
+		sb.WriteString(strconv.FormatUint(uint64(t.pc), 16))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.FormatUint(t.open, 16))
+		if t.atEnd {
+			sb.WriteByte('$')
+		}
+		sb.WriteByte(',')
+	}
+	key := sb.String()
+	if i, ok := b.index[key]; ok {
+		return i
+	}
+	b.index[key] = len(b.sets)
+	b.sets = append(b.sets, set)
+	return len(b.sets) - 1
+}
+
+// closure returns the sorted set of threads reachable using empty transitions.
+// Only threads which are at consuming or matching instructions are kept.
+func (b *builder) closure(threads []thread, atStart bool) ([]thread, error) {
+	seen := make(map[thread]bool)
+	var ret []thread
+	stack := append([]thread{}, threads...)
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		inst := &b.prog.Inst[t.pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, thread{pc: inst.Out, open: t.open, atEnd: t.atEnd}, thread{pc: inst.Arg, open: t.open, atEnd: t.atEnd})
+		case syntax.InstNop:
+			stack = append(stack, thread{pc: inst.Out, open: t.open, atEnd: t.atEnd})
+		case syntax.InstCapture:
+			open := t.open
+			if group := inst.Arg / 2; group < 64 {
+				if inst.Arg%2 == 0 {
+					open |= 1 << group
+				} else {
+					open &^= 1 << group
+				}
+			}
+			stack = append(stack, thread{pc: inst.Out, open: open, atEnd: t.atEnd})
+		case syntax.InstEmptyWidth:
+			switch syntax.EmptyOp(inst.Arg) {
+			case syntax.EmptyBeginText:
+				if atStart {
+					stack = append(stack, thread{pc: inst.Out, open: t.open, atEnd: t.atEnd})
+				}
+			case syntax.EmptyEndText:
+				stack = append(stack, thread{pc: inst.Out, open: t.open, atEnd: true})
+			default:
+				return nil, fmt.Errorf("unsupported empty-width assertion %v", syntax.EmptyOp(inst.Arg))
+			}
+		case syntax.InstFail:
+		case syntax.InstMatch, syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			ret = append(ret, t)
+		default:
+			return nil, fmt.Errorf("unsupported instruction %v", inst.Op)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].pc != ret[j].pc {
+			return ret[i].pc < ret[j].pc
+		}
+		if ret[i].open != ret[j].open {
+			return ret[i].open < ret[j].open
+		}
+		return !ret[i].atEnd && ret[j].atEnd
+	})
+	return ret, nil
+}
+
+func (b *builder) accepts(set []thread) bool {
+	for _, t := range set {
+		if b.prog.Inst[t.pc].Op == syntax.InstMatch {
+			return true
+		}
+	}
+	return false
+}
+
+func consumes(inst *syntax.Inst, c byte) bool {
+	switch inst.Op {
+	case syntax.InstRune, syntax.InstRune1:
+		return inst.MatchRune(rune(c))
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return c != '\n'
+	}
+	return false
+}
+
+// reverse builds the reverse automaton of d from the sets of threads of the
+// forward states, and computes the captures of the pairs of forward and
+// reverse states.
+func (b *builder) reverse(d *DFA) error {
+	// index the threads of the forward states
+	ids := make(map[thread]int)
+	var threads []thread
+	forward := make([][]int, len(b.sets))
+	for i, set := range b.sets {
+		for _, t := range set {
+			id, ok := ids[t]
+			if !ok {
+				id = len(threads)
+				ids[t] = id
+				threads = append(threads, t)
+			}
+			forward[i] = append(forward[i], id)
+		}
+	}
+	// succ[id] are the threads following the thread id after it consumes a
+	// byte.
+	succ := make([][]int, len(threads))
+	for id, t := range threads {
+		inst := &b.prog.Inst[t.pc]
+		if t.atEnd || inst.Op == syntax.InstMatch {
+			continue
+		}
+		next, err := b.closure([]thread{{pc: inst.Out, open: t.open}}, false)
+		if err != nil {
+			return err
+		}
+		for _, n := range next {
+			if nID, ok := ids[n]; ok {
+				succ[id] = append(succ[id], nID)
+			}
+		}
+	}
+
+	var sets [][]bool
+	index := make(map[string]int)
+	state := func(set []bool) int {
+		key := make([]byte, len(set))
+		for i := range set {
+			if set[i] {
+				key[i] = 1
+			}
+		}
+		if i, ok := index[string(key)]; ok {
+			return i
+		}
+		index[string(key)] = len(sets)
+		sets = append(sets, set)
+		return len(sets) - 1
+	}
+	// the empty suffix leads to a match from the matching threads
+	end := make([]bool, len(threads))
+	for id, t := range threads {
+		end[id] = b.prog.Inst[t.pc].Op == syntax.InstMatch
+	}
+	d.reverseStart = state(end)
+	for i := 0; i < len(sets); i++ {
+		if len(sets) > maxStates {
+			return fmt.Errorf("reverse automaton has more than %d states", maxStates)
+		}
+		var trans [256]int
+		for c := 0; c < 256; c++ {
+			set := make([]bool, len(threads))
+			for id, t := range threads {
+				if t.atEnd || !consumes(&b.prog.Inst[t.pc], byte(c)) {
+					continue
+				}
+				for _, n := range succ[id] {
+					if sets[i][n] {
+						set[id] = true
+						break
+					}
+				}
+			}
+			trans[c] = state(set)
+		}
+		d.reverseTransitions = append(d.reverseTransitions, trans)
+	}
+
+	// a byte is captured if it is consumed by a thread which is both reached
+	// from the prefix and leads to a match with the suffix.
+	d.captures = make([][]uint64, len(forward))
+	for s := range forward {
+		d.captures[s] = make([]uint64, len(sets))
+		for r := range sets {
+			for _, id := range forward[s] {
+				if sets[r][id] {
+					d.captures[s][r] |= threads[id].open
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// minimize merges the equivalent states of the forward and the reverse
+// automata. Two forward states are equivalent if they agree on acceptance, on
+// the captures with every reverse state and for every byte on the equivalence
+// class of the next state. Then, two reverse states are equivalent if they
+// agree on the captures with every (merged) forward state and for every byte
+// on the equivalence class of the next state.
+func (d *DFA) minimize() {
+	class, nbClasses := refine(d.transitions, func(s int) string {
+		var sb strings.Builder
+		sb.WriteString(strconv.FormatBool(d.accepting[s]))
+		for r := range d.captures[s] {
+			sb.WriteByte(',')
+			sb.WriteString(strconv.FormatUint(d.captures[s][r], 16))
+		}
+		return sb.String()
+	})
+	transitions := make([][256]int, nbClasses)
+	captures := make([][]uint64, nbClasses)
+	accepting := make([]bool, nbClasses)
+	for i := range d.transitions {
+		for c := 0; c < 256; c++ {
+			transitions[class[i]][c] = class[d.transitions[i][c]]
+		}
+		captures[class[i]] = d.captures[i]
+		accepting[class[i]] = d.accepting[i]
+	}
+	d.transitions, d.captures, d.accepting = transitions, captures, accepting
+	d.start = class[d.start]
+
+	class, nbClasses = refine(d.reverseTransitions, func(r int) string {
+		var sb strings.Builder
+		for s := range d.captures {
+			sb.WriteString(strconv.FormatUint(d.captures[s][r], 16))
+			sb.WriteByte(',')
+		}
+		return sb.String()
+	})
+	transitions = make([][256]int, nbClasses)
+	for i := range d.reverseTransitions {
+		for c := 0; c < 256; c++ {
+			transitions[class[i]][c] = class[d.reverseTransitions[i][c]]
+		}
+	}
+	for s := range d.captures {
+		row := make([]uint64, nbClasses)
+		for r := range d.captures[s] {
+			row[class[r]] = d.captures[s][r]
+		}
+		d.captures[s] = row
+	}
+	d.reverseTransitions = transitions
+	d.reverseStart = class[d.reverseStart]
+}
+
+// refine returns the equivalence classes of the states of an automaton using
+// partition refinement, and the number of classes. Two states are equivalent
+// if they have the same signature and for every byte the next states are
+// equivalent.
+func refine(transitions [][256]int, signature func(s int) string) ([]int, int) {
+	class := make([]int, len(transitions))
+	index := make(map[string]int)
+	for i := range class {
+		key := signature(i)
+		if _, ok := index[key]; !ok {
+			index[key] = len(index)
+		}
+		class[i] = index[key]
+	}
+	nbClasses := len(index)
+	for {
+		index := make(map[string]int)
+		next := make([]int, len(class))
+		for i := range transitions {
+			var sb strings.Builder
+			sb.WriteString(strconv.Itoa(class[i]))
+			for c := 0; c < 256; c++ {
+				sb.WriteByte(',')
+				sb.WriteString(strconv.Itoa(class[transitions[i][c]]))
+			}
+			key := sb.String()
+			if _, ok := index[key]; !ok {
+				index[key] = len(index)
+			}
+			next[i] = index[key]
+		}
+		class = next
+		if len(index) == nbClasses {
+			return class, nbClasses
+		}
+		nbClasses = len(index)
+	}
+}
@@ -0,0 +1,182 @@
+// Package regex implements regular expression matching over byte strings.
+//
+// The regular expression is compiled out-of-circuit into a deterministic
+// finite automaton (DFA) using [Compile]. In-circuit, the transition function
+// of the automaton is stored in a lookup table indexed by state*256+byte and we
+// perform a single lookup per input byte to obtain the next state. At the end,
+// we look up if the final state is accepting. The lookups are performed using
+// the [logderivlookup] package, so the cost is roughly linear in the number of
+// states times 256 plus the length of the input.
+//
+// Additionally, the DFA holds a reverse automaton which reads the input
+// backwards, and records for every pair of forward and reverse states which
+// capture groups the next byte belongs to in a match. Running both automata
+// allows to extract the positions of the captured substrings, which is useful
+// for example for parsing the fields of the headers of DKIM-signed e-mails.
+package regex
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// Matcher performs in-circuit matching using a DFA. The lookup tables are
+// built on the first use and reused for all subsequent matches.
+type Matcher struct {
+	api      frontend.API
+	dfa      *DFA
+	rchecker frontend.Rangechecker
+
+	transitions        *logderivlookup.Table
+	reverseTransitions *logderivlookup.Table
+	accepting          *logderivlookup.Table
+	captures           map[int]*logderivlookup.Table
+}
+
+// New returns a new [*Matcher] for the automaton d.
+func New(api frontend.API, d *DFA) *Matcher {
+	return &Matcher{
+		api:      api,
+		dfa:      d,
+		rchecker: rangecheck.New(api),
+		captures: make(map[int]*logderivlookup.Table),
+	}
+}
+
+// IsMatch returns 1 if the input matches the automaton and 0 otherwise.
+func (m *Matcher) IsMatch(in []uints.U8) frontend.Variable {
+	states := m.run(in)
+	return m.isAccepting(states[len(states)-1])
+}
+
+// AssertMatch asserts that the input matches the automaton.
+func (m *Matcher) AssertMatch(in []uints.U8) {
+	m.api.AssertIsEqual(m.IsMatch(in), 1)
+}
+
+// AssertMatchWithCapture asserts that the input matches the automaton and
+// returns for every input byte a boolean indicating if the byte belongs to the
+// capture group. Additionally, it asserts that the captured bytes form a single
+// contiguous substring and returns its start position and length. If nothing
+// is captured, then both start and length are zero. If the input has several
+// matches capturing different bytes, then the mask is the union of the
+// captures, see [DFA.Capture].
+func (m *Matcher) AssertMatchWithCapture(in []uints.U8, group int) (mask []frontend.Variable, start, length frontend.Variable) {
+	if group < 0 || group >= m.dfa.NbGroups() {
+		panic(fmt.Sprintf("capture group %d out of range", group))
+	}
+	states := m.run(in)
+	m.api.AssertIsEqual(m.isAccepting(states[len(states)-1]), 1)
+	if len(in) == 0 {
+		return nil, 0, 0
+	}
+	reverse := m.reverseRun(in)
+	tbl := m.captureTable(group)
+	nbReverse := len(m.dfa.reverseTransitions)
+	inds := make([]frontend.Variable, len(in))
+	for i := range in {
+		inds[i] = m.api.Add(m.api.Mul(states[i], nbReverse), reverse[i])
+	}
+	mask = tbl.Lookup(inds...)
+	// the substring starts at every position where the mask changes from 0 to
+	// 1. We allow at most one start.
+	var nbStarts frontend.Variable = 0
+	start, length = 0, 0
+	prev := frontend.Variable(0)
+	for i := range mask {
+		isStart := m.api.Mul(mask[i], m.api.Sub(1, prev))
+		nbStarts = m.api.Add(nbStarts, isStart)
+		start = m.api.Add(start, m.api.Mul(isStart, i))
+		length = m.api.Add(length, mask[i])
+		prev = mask[i]
+	}
+	m.api.AssertIsBoolean(nbStarts)
+	return mask, start, length
+}
+
+// run returns the sequence of states visited when consuming the input. The
+// first state is the start state and the last state is the state after
+// consuming all the input.
+func (m *Matcher) run(in []uints.U8) []frontend.Variable {
+	states := make([]frontend.Variable, len(in)+1)
+	states[0] = m.dfa.start
+	if len(in) == 0 {
+		return states
+	}
+	tbl := m.transitionTable()
+	for i := range in {
+		// the bytes must be range checked as otherwise the index could point
+		// to a transition of another state.
+		m.rchecker.Check(in[i].Val, 8)
+		states[i+1] = tbl.Lookup(m.index(states[i], in[i]))[0]
+	}
+	return states
+}
+
+// reverseRun returns the sequence of states visited by the reverse automaton
+// when consuming the input backwards: the state i is the state after consuming
+// in[i:]. The bytes must have been range checked by [Matcher.run].
+func (m *Matcher) reverseRun(in []uints.U8) []frontend.Variable {
+	states := make([]frontend.Variable, len(in)+1)
+	states[len(in)] = m.dfa.reverseStart
+	if m.reverseTransitions == nil {
+		m.reverseTransitions = logderivlookup.New(m.api)
+		for s := range m.dfa.reverseTransitions {
+			for c := 0; c < 256; c++ {
+				m.reverseTransitions.Insert(m.dfa.reverseTransitions[s][c])
+			}
+		}
+	}
+	for i := len(in) - 1; i >= 0; i-- {
+		states[i] = m.reverseTransitions.Lookup(m.index(states[i+1], in[i]))[0]
+	}
+	return states
+}
+
+func (m *Matcher) isAccepting(state frontend.Variable) frontend.Variable {
+	if m.accepting == nil {
+		m.accepting = logderivlookup.New(m.api)
+		for i := range m.dfa.accepting {
+			if m.dfa.accepting[i] {
+				m.accepting.Insert(1)
+			} else {
+				m.accepting.Insert(0)
+			}
+		}
+	}
+	return m.accepting.Lookup(state)[0]
+}
+
+func (m *Matcher) index(state frontend.Variable, b uints.U8) frontend.Variable {
+	return m.api.Add(m.api.Mul(state, 256), b.Val)
+}
+
+func (m *Matcher) transitionTable() *logderivlookup.Table {
+	if m.transitions == nil {
+		m.transitions = logderivlookup.New(m.api)
+		for s := range m.dfa.transitions {
+			for c := 0; c < 256; c++ {
+				m.transitions.Insert(m.dfa.transitions[s][c])
+			}
+		}
+	}
+	return m.transitions
+}
+
+func (m *Matcher) captureTable(group int) *logderivlookup.Table {
+	if tbl, ok := m.captures[group]; ok {
+		return tbl
+	}
+	tbl := logderivlookup.New(m.api)
+	for s := range m.dfa.captures {
+		for r := range m.dfa.captures[s] {
+			tbl.Insert(m.dfa.captures[s][r] >> group & 1)
+		}
+	}
+	m.captures[group] = tbl
+	return tbl
+}
@@ -0,0 +1,112 @@
+package regex
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+func TestCompile(t *testing.T) {
+	assert := test.NewAssert(t)
+	patterns := []string{`abc`, `a*b+c?`, `(ab|cd)*`, `[a-z]+@[a-z]+\.com`, `^x.*y$`, `(?s).*from:([a-z]+)\r\n.*`, `a{2,4}`, `[^0-9]*`}
+	inputs := []string{"", "abc", "ab", "aabbb", "abcdab", "alice@example.com", "x\ny", "xaby", "to:b\r\nfrom:alice\r\nsubject", "aaa", "aaaaa", "abc1"}
+	for _, p := range patterns {
+		d, err := Compile(p)
+		assert.NoError(err, p)
+		re := regexp.MustCompile(`^(?:` + p + `)$`)
+		for _, in := range inputs {
+			assert.Equal(re.MatchString(in), d.Match([]byte(in)), "%s %q", p, in)
+		}
+	}
+	_, err := Compile(`\bword\b`)
+	assert.Error(err)
+}
+
+func TestCapture(t *testing.T) {
+	assert := test.NewAssert(t)
+	d := MustCompile(`(?s).*from:([a-z]+)\r\n.*`)
+	in := "to:b\r\nfrom:alice\r\nsubject"
+	mask := d.Capture([]byte(in), 1)
+	var captured []byte
+	for i := range mask {
+		if mask[i] {
+			captured = append(captured, in[i])
+		}
+	}
+	assert.Equal("alice", string(captured))
+
+	// the bytes captured by threads which don't lead to a match are not
+	// captured
+	in = "xfrom:abc from:alice\r\n"
+	mask = d.Capture([]byte(in), 1)
+	captured = nil
+	for i := range mask {
+		if mask[i] {
+			captured = append(captured, in[i])
+		}
+	}
+	assert.Equal("alice", string(captured))
+	assert.Equal(make([]bool, 4), d.Capture([]byte("abcd"), 1))
+}
+
+type matchCircuit struct {
+	dfa      *DFA
+	In       []uints.U8
+	Expected frontend.Variable
+}
+
+func (c *matchCircuit) Define(api frontend.API) error {
+	m := New(api, c.dfa)
+	api.AssertIsEqual(m.IsMatch(c.In), c.Expected)
+	return nil
+}
+
+func TestMatch(t *testing.T) {
+	assert := test.NewAssert(t)
+	d := MustCompile(`[a-z]+@[a-z]+\.com`)
+	for _, v := range []struct {
+		in       string
+		expected int
+	}{{"alice@example.com", 1}, {"alice@example.org", 0}, {"alice@examplecom", 0}} {
+		circuit := &matchCircuit{dfa: d, In: make([]uints.U8, len(v.in))}
+		assignment := &matchCircuit{In: uints.NewU8Array([]byte(v.in)), Expected: v.expected}
+		err := test.IsSolved(circuit, assignment, ecc.BN254.ScalarField())
+		assert.NoError(err, v.in)
+	}
+}
+
+type captureCircuit struct {
+	dfa           *DFA
+	In            []uints.U8
+	Start, Length frontend.Variable
+}
+
+func (c *captureCircuit) Define(api frontend.API) error {
+	m := New(api, c.dfa)
+	_, start, length := m.AssertMatchWithCapture(c.In, 1)
+	api.AssertIsEqual(start, c.Start)
+	api.AssertIsEqual(length, c.Length)
+	return nil
+}
+
+func TestMatchWithCapture(t *testing.T) {
+	assert := test.NewAssert(t)
+	d := MustCompile(`(?s).*from:([a-z]+)\r\n.*`)
+	in := "to:b\r\nfrom:alice\r\nsubject"
+	circuit := &captureCircuit{dfa: d, In: make([]uints.U8, len(in))}
+	err := test.IsSolved(circuit, &captureCircuit{In: uints.NewU8Array([]byte(in)), Start: 11, Length: 5}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(circuit, &captureCircuit{In: uints.NewU8Array([]byte(in)), Start: 11, Length: 4}, ecc.BN254.ScalarField())
+	assert.Error(err)
+	in1 := "xfrom:abc from:alice\r\n"
+	circuit1 := &captureCircuit{dfa: d, In: make([]uints.U8, len(in1))}
+	err = test.IsSolved(circuit1, &captureCircuit{In: uints.NewU8Array([]byte(in1)), Start: 15, Length: 5}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	in2 := "to:b\r\nfrom=alice\r\nsubject"
+	err = test.IsSolved(circuit, &captureCircuit{In: uints.NewU8Array([]byte(in2)), Start: 0, Length: 0}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
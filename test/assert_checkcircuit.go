@@ -25,6 +25,7 @@ import (
 //   - the circuit compiles
 //   - the circuit can be solved with the test engine
 //   - the circuit can be solved with the constraint system solver
+//   - the hint outputs are constrained, when the option [WithHintFuzzing] is set
 //   - the circuit can be solved with the prover
 //   - the circuit can be verified with the verifier
 //   - the circuit can be verified with gnark-solidity-checker
@@ -78,6 +79,15 @@ func (assert *Assert) CheckCircuit(circuit frontend.Circuit, opts ...TestingOpti
 
 					// TODO @gbotrel check serialization round trip with constraint system.
 
+					if opt.hintFuzzCount > 0 {
+						for _, w := range validWitnesses {
+							w := w
+							assert.Run(func(assert *Assert) {
+								assert.noError(fuzzHints(ccs, w.full, opt.hintFuzzCount, opt.hintFuzzSeed, opt.solverOpts...), &w)
+							}, "hint_fuzzing")
+						}
+					}
+
 					// 2- if we are not running the full prover;
 					// we need to run the solver on the constraint system only
 					if !opt.checkProver {
@@ -2,6 +2,7 @@ package test
 
 import (
 	"crypto/rand"
+	"fmt"
 	"math/big"
 	mrand "math/rand"
 	"reflect"
@@ -10,8 +11,13 @@ import (
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs"
 	"github.com/consensys/gnark/frontend/schema"
+	"github.com/consensys/gnark/internal/hints"
 )
 
 var seedCorpus []*big.Int
@@ -244,3 +250,145 @@ func (assert *Assert) solvingFailed(circuit frontend.Circuit, invalidAssignment
 	mustError(err)
 
 }
+
+// hintCall records a call to a hint function when solving with a single task,
+// in which case the calls are in a deterministic order.
+type hintCall struct {
+	name    string
+	outputs []*big.Int
+	fixed   bool // commitment or mask, not tampered with
+}
+
+// fuzzHints solves ccs with the valid witness w, tampering with the outputs of
+// fuzzCount hint calls randomly picked from seed. It returns an error if a
+// tampered solution still satisfies all the constraints while modifying a wire
+// which is not a hint output. See [WithHintFuzzing].
+func fuzzHints(ccs constraint.ConstraintSystem, w witness.Witness, fuzzCount int, seed int64, solverOpts ...solver.Option) error {
+	config, err := solver.NewConfig(solverOpts...)
+	if err != nil {
+		return err
+	}
+	m := ccs.Field()
+	commitmentID := solver.GetHintID(cs.Bsb22CommitmentComputePlaceholder)
+	randomizeID := solver.GetHintID(hints.Randomize)
+
+	// solve wraps all the hint functions, calls tamper on the outputs of every
+	// hint call and records them. It returns the hint calls and the values of
+	// the wires.
+	solve := func(tamper func(call int, outputs []*big.Int)) ([]hintCall, []*big.Int, error) {
+		opts := append(append([]solver.Option{}, solverOpts...), solver.WithNbTasks(1))
+		var calls []hintCall
+		record := func(name string, fixed bool, outputs []*big.Int) {
+			c := hintCall{name: name, fixed: fixed, outputs: make([]*big.Int, len(outputs))}
+			for i := range outputs {
+				c.outputs[i] = new(big.Int).Mod(outputs[i], m)
+			}
+			calls = append(calls, c)
+		}
+
+		// the commitments and the masks are not constrained by design; we
+		// make them deterministic so that they only differ between two
+		// solutions if their inputs differ.
+		masks := mrand.New(mrand.NewSource(0)) //#nosec G404 weak rng is fine here
+		opts = append(opts,
+			solver.OverrideHint(commitmentID, func(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+				if err := constraint.DeterministicCommitmentHint(mod, inputs, outputs); err != nil {
+					return err
+				}
+				record("commitment", true, outputs)
+				return nil
+			}),
+			solver.OverrideHint(randomizeID, func(mod *big.Int, _ []*big.Int, outputs []*big.Int) error {
+				for i := range outputs {
+					outputs[i].Rand(masks, mod)
+				}
+				record("mask", true, outputs)
+				return nil
+			}),
+		)
+		for id, f := range config.HintFunctions {
+			if id == commitmentID || id == randomizeID {
+				continue
+			}
+			f, name := f, solver.GetHintName(f)
+			opts = append(opts, solver.OverrideHint(id, func(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+				if err := f(mod, inputs, outputs); err != nil {
+					return err
+				}
+				tamper(len(calls), outputs)
+				record(name, false, outputs)
+				return nil
+			}))
+		}
+		solution, err := ccs.Solve(w, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		wires, err := constraint.SolutionValues(ccs, solution)
+		return calls, wires, err
+	}
+
+	honestCalls, honestWires, err := solve(func(int, []*big.Int) {})
+	if err != nil {
+		return err
+	}
+	var candidates []int
+	for i, c := range honestCalls {
+		if !c.fixed && len(c.outputs) > 0 {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	rng := mrand.New(mrand.NewSource(seed)) //#nosec G404 weak rng is fine here
+	for i := 0; i < fuzzCount; i++ {
+		target := candidates[rng.Intn(len(candidates))]
+		output := rng.Intn(len(honestCalls[target].outputs))
+		honest := honestCalls[target].outputs[output]
+		for _, mutation := range constraint.HintMutations() {
+			tampered := mutation.Mutate(rng, m, honest)
+			calls, wires, err := solve(func(call int, outputs []*big.Int) {
+				if call == target {
+					outputs[output].Set(tampered)
+				}
+			})
+			if err != nil {
+				continue
+			}
+			if wireID, ok := tamperedWire(honestCalls, calls, honestWires, wires); ok {
+				return fmt.Errorf("solving succeeded with output %d of hint call %d (%s) tampered (%s): %s instead of %s, modifying wire %d (fuzzing seed %d)",
+					output, target, honestCalls[target].name, mutation.Name, tampered, honest, wireID, seed)
+			}
+		}
+	}
+	return nil
+}
+
+// tamperedWire returns a wire whose value differs between the honest and the
+// tampered solutions and which is not a hint output. As the wire IDs of the
+// hint outputs are not known, the modified wires are matched with the modified
+// hint outputs by their values. The wires without a value are ignored.
+func tamperedWire(honestCalls, calls []hintCall, honestWires, wires []*big.Int) (int, bool) {
+	type change struct{ from, to string }
+	changes := make(map[change]int)
+	for i := range honestCalls {
+		for j, v := range honestCalls[i].outputs {
+			if v.Cmp(calls[i].outputs[j]) != 0 {
+				changes[change{v.String(), calls[i].outputs[j].String()}]++
+			}
+		}
+	}
+	for wireID := range wires {
+		if honestWires[wireID] == nil || wires[wireID] == nil || honestWires[wireID].Cmp(wires[wireID]) == 0 {
+			continue
+		}
+		c := change{honestWires[wireID].String(), wires[wireID].String()}
+		if changes[c] == 0 {
+			return wireID, true
+		}
+		changes[c]--
+	}
+	return 0, false
+}
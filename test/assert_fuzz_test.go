@@ -0,0 +1,72 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+)
+
+type hintFuzzingCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *hintFuzzingCircuit) Define(api frontend.API) error {
+	bits := api.ToBinary(c.X, 8)
+	api.AssertIsEqual(api.Mul(api.FromBinary(bits...), api.Inverse(c.X)), 1)
+	api.AssertIsEqual(api.Add(api.IsZero(c.X), api.Div(c.Y, c.X)), c.Y)
+	return nil
+}
+
+func TestHintFuzzing(t *testing.T) {
+	assert := NewAssert(t)
+	assert.CheckCircuit(&hintFuzzingCircuit{},
+		WithValidAssignment(&hintFuzzingCircuit{X: 1, Y: 42}),
+		WithCurves(ecc.BN254),
+		WithHintFuzzing(10),
+	)
+}
+
+func divisionHint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	outputs[0].ModInverse(inputs[1], mod)
+	outputs[0].Mul(outputs[0], inputs[0]).Mod(outputs[0], mod)
+	return nil
+}
+
+type underconstrainedDivisionCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *underconstrainedDivisionCircuit) Define(api frontend.API) error {
+	// the quotient is only checked to be non-zero, not to be Y/X
+	q, err := api.Compiler().NewHint(divisionHint, 1, c.Y, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(q[0], 0)
+	api.AssertIsDifferent(c.X, 0)
+	return nil
+}
+
+func TestHintFuzzingUnderconstrained(t *testing.T) {
+	field := ecc.BN254.ScalarField()
+	w, err := frontend.NewWitness(&underconstrainedDivisionCircuit{X: 3, Y: 42}, field)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		ccs, err := frontend.Compile(field, builder, &underconstrainedDivisionCircuit{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fuzzHints(ccs, w, 1, 0, solver.WithHints(divisionHint)); err == nil {
+			t.Fatal("tampered quotient was not detected")
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -25,6 +26,9 @@ type testingConfig struct {
 
 	validAssignments   []frontend.Circuit
 	invalidAssignments []frontend.Circuit
+
+	hintFuzzCount int
+	hintFuzzSeed  int64
 }
 
 // default options
@@ -110,6 +114,42 @@ func NoFuzzing() TestingOption {
 	}
 }
 
+// WithHintFuzzing is a testing option which tampers with the hint outputs when
+// solving the valid assignments. For each valid assignment, fuzzCount hint
+// calls are picked at random and, for each of them, the circuit is re-solved
+// with one of the outputs replaced by a random value, by an off-by-one value
+// and by a value shifted by a field modulus. The assertion fails if any of the
+// tampered solutions satisfies all the constraints while modifying a wire which
+// is not a hint output, as the prover is then free to choose the result of the
+// computations depending on the tampered output.
+//
+// The public variables are fixed by the witness, so that the wires computed by
+// the constraints play the role of the outputs of the circuit. The commitment
+// placeholders and the random masks are not tampered with, as their values are
+// not constrained in the circuit by design.
+//
+// The hint calls are picked from a fixed seed, so that the failures are
+// reproducible. The seed is given in the failure message and can be changed
+// with [WithHintFuzzingSeed] for exploring other hint calls.
+func WithHintFuzzing(fuzzCount int) TestingOption {
+	return func(opt *testingConfig) error {
+		if fuzzCount <= 0 {
+			return errors.New("fuzz count must be positive")
+		}
+		opt.hintFuzzCount = fuzzCount
+		return nil
+	}
+}
+
+// WithHintFuzzingSeed is a testing option which sets the seed of the random
+// choices of [WithHintFuzzing]. The default seed is 0.
+func WithHintFuzzingSeed(seed int64) TestingOption {
+	return func(opt *testingConfig) error {
+		opt.hintFuzzSeed = seed
+		return nil
+	}
+}
+
 // NoProverChecks is a testing option which disables prover checks,
 // even when the build tag "prover_checks" or "release_checks" are set.
 func NoProverChecks() TestingOption {
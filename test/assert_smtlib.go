@@ -0,0 +1,60 @@
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// smtSolver is the SMT solver used by [Assert.CheckDeterminism]. It must
+// support the finite field theory of cvc5.
+const smtSolver = "cvc5"
+
+// CheckDeterminism checks with a SMT solver that the internal wires of the
+// compiled circuit are uniquely determined by its public and secret inputs,
+// for every curve and backend of the testing options. The constraint system is
+// exported with [constraint.WriteSMTLIB] and [constraint.WithDeterminismQuery],
+// and the check fails unless the solver proves the query unsatisfiable.
+//
+// Solving the query is expensive, so it should only be used on small circuits
+// (for example a single gadget). The check is skipped when the solver binary
+// (cvc5) is not found in the PATH.
+func (assert *Assert) CheckDeterminism(circuit frontend.Circuit, opts ...TestingOption) {
+	if _, err := exec.LookPath(smtSolver); err != nil {
+		assert.t.Skipf("%s not found in PATH, skipping determinism check", smtSolver)
+	}
+	opt := assert.options(opts...)
+
+	for _, curve := range opt.curves {
+		for _, b := range opt.backends {
+			curve, b := curve, b
+			assert.Run(func(assert *Assert) {
+				ccs, err := assert.compile(circuit, curve, b, opt.compileOpts)
+				assert.NoError(err)
+
+				f, err := os.Create(filepath.Join(assert.t.TempDir(), "determinism.smt2"))
+				assert.NoError(err)
+				err = constraint.WriteSMTLIB(f, ccs, constraint.WithDeterminismQuery())
+				assert.NoError(err)
+				assert.NoError(f.Close())
+
+				cmd := exec.Command(smtSolver, "--lang", "smt2", f.Name())
+				assert.t.Log("running ", cmd.String())
+				out, err := cmd.CombinedOutput()
+				assert.NoError(err, string(out))
+
+				switch result := strings.TrimSpace(string(out)); result {
+				case "unsat":
+				case "sat":
+					assert.FailNow("internal wires are not determined by the inputs")
+				default:
+					assert.FailNow("unexpected solver result", result)
+				}
+			}, curve.String(), b.String())
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+type determinismCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *determinismCircuit) Define(api frontend.API) error {
+	bits := api.ToBinary(c.X, 3)
+	api.AssertIsEqual(api.Mul(api.FromBinary(bits...), bits[0]), c.Y)
+	return nil
+}
+
+func TestCheckDeterminism(t *testing.T) {
+	assert := NewAssert(t)
+	assert.CheckDeterminism(&determinismCircuit{}, WithCurves(ecc.BN254))
+}